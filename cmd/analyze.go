@@ -9,75 +9,69 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/zhuiye8/claude-stats/pkg/parser"
 	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
 	"github.com/zhuiye8/claude-stats/pkg/models"
 )
 
 // analyzeCmd 代表analyze命令
 var analyzeCmd = &cobra.Command{
-	Use:   "analyze [目录路径]",
-	Short: "分析Claude Code使用统计",
-	Long: `分析指定目录中的Claude Code JSONL日志文件，生成详细的使用统计报告。
-
-支持的功能：
-• 自动检测Claude日志目录
-• Token使用统计（输入、输出、缓存）
-• 成本估算（API和订阅模式）
-• 按模型、日期、会话分组统计
-• 多种输出格式（表格、JSON、CSV）
-• 多配置目录支持（通过CLAUDE_CONFIG_DIR环境变量）
-
-示例：
-  claude-stats analyze                    # 分析默认Claude目录
-  claude-stats analyze ~/claude-logs     # 分析指定目录
-  claude-stats analyze --json            # JSON格式输出
-  claude-stats analyze --csv report.csv  # 导出CSV报告
-  claude-stats analyze --breakdown       # 显示模型详细分解
-  claude-stats analyze --since 20240101 --until 20241231  # 指定日期范围
-  
-多配置目录：
-  export CLAUDE_CONFIG_DIR="/path1,/path2"  # 分析多个目录
-  claude-stats analyze --breakdown           # 聚合分析所有配置目录`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runAnalyze,
+	Use:   "analyze [dir]",
+	Short: "cmd.analyze.short",
+	Long:  "cmd.analyze.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runAnalyze,
 }
 
 func init() {
 	rootCmd.AddCommand(analyzeCmd)
 
 	// 添加命令特定的标志位
-	analyzeCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "输出格式 (table, json, csv)")
-	analyzeCmd.Flags().StringVarP(&outputFile, "output", "o", "", "输出文件路径")
-	analyzeCmd.Flags().StringVar(&startDate, "since", "", "开始日期 (YYYYMMDD)")
-	analyzeCmd.Flags().StringVar(&endDate, "until", "", "结束日期 (YYYYMMDD)")
-	analyzeCmd.Flags().StringVar(&modelFilter, "model", "", "过滤特定模型")
-	analyzeCmd.Flags().BoolVarP(&showDetails, "details", "d", false, "显示详细信息")
-	analyzeCmd.Flags().BoolVar(&noColor, "no-color", false, "禁用颜色输出")
+	analyzeCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "flag.format_analyze")
+	analyzeCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	analyzeCmd.Flags().StringVar(&startDate, "since", "", "flag.since")
+	analyzeCmd.Flags().StringVar(&endDate, "until", "", "flag.until")
+	analyzeCmd.Flags().StringVar(&modelFilter, "model", "", "flag.model")
+	analyzeCmd.Flags().StringVar(&projectFilter, "project", "", "flag.project")
+	analyzeCmd.Flags().StringVar(&sidechainMode, "sidechain", "include", "flag.sidechain")
+	analyzeCmd.Flags().StringVar(&redactMode, "redact", "none", "flag.redact")
+	analyzeCmd.Flags().BoolVarP(&showDetails, "details", "d", false, "flag.details")
+	analyzeCmd.Flags().BoolVar(&showCharts, "chart", false, "flag.chart")
+	analyzeCmd.Flags().StringVar(&templateFile, "template", "", "flag.template")
+	analyzeCmd.Flags().StringVar(&templateString, "template-string", "", "flag.template_string")
+	analyzeCmd.Flags().BoolVar(&noColor, "no-color", false, "flag.no_color")
 	
 	// 新增：增强功能标志位
-	analyzeCmd.Flags().StringSliceVar(&configDirs, "config-dirs", []string{}, "指定多个Claude配置目录，逗号分隔")
-	analyzeCmd.Flags().BoolVarP(&offline, "offline", "O", false, "离线模式，使用缓存定价数据")
-	analyzeCmd.Flags().BoolVar(&breakdown, "breakdown", false, "显示按模型分解的详细成本")
-	analyzeCmd.Flags().StringVar(&order, "order", "desc", "排序顺序 (asc, desc)")
-	analyzeCmd.Flags().StringVar(&costMode, "mode", "auto", "成本计算模式 (auto, calculate, display)")
+	analyzeCmd.Flags().StringSliceVar(&configDirs, "config-dirs", []string{}, "flag.config_dirs")
+	analyzeCmd.Flags().BoolVarP(&offline, "offline", "O", false, "flag.offline_pricing")
+	analyzeCmd.Flags().BoolVar(&breakdown, "breakdown", false, "flag.breakdown")
+	analyzeCmd.Flags().StringVar(&order, "order", "desc", "flag.order")
+	analyzeCmd.Flags().StringVar(&costMode, "mode", "auto", "flag.mode")
+	analyzeCmd.Flags().StringVar(&planName, "plan", "", "flag.plan")
 }
 
 func runAnalyze(cmd *cobra.Command, args []string) error {
+	redactor, err := resolveRedactor(redactMode)
+	if err != nil {
+		return err
+	}
+
 	// 确定要分析的目录列表
 	targetDirs := getTargetDirectories(args)
 	
 	if verbose {
-		fmt.Printf("🔍 分析目录: %s\n", strings.Join(targetDirs, ", "))
+		fmt.Println(redactor.Text(i18n.T("common.analyzing_dirs", strings.Join(targetDirs, ", "))))
 		if len(targetDirs) > 1 {
-			fmt.Printf("💡 将聚合分析 %d 个配置目录的数据\n", len(targetDirs))
+			fmt.Println(i18n.T("analyze.multi_dirs", len(targetDirs)))
 		}
 	}
 
 	// 检查目录是否存在
 	for _, dir := range targetDirs {
 		if _, err := os.Stat(dir); os.IsNotExist(err) {
-			fmt.Printf("⚠️  目录不存在，跳过: %s\n", dir)
+			fmt.Println(redactor.Text(i18n.T("common.dir_missing", dir)))
 		}
 	}
 
@@ -85,12 +79,15 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	claudeParser := parser.NewClaudeParser()
 	claudeParser.Verbose = verbose
 	claudeParser.SkipErrors = true
+	if err := applyProjectOptions(claudeParser, redactor); err != nil {
+		return err
+	}
 
 	// 设置日期过滤器
 	if startDate != "" || endDate != "" {
 		dateFilter, err := createDateFilter(startDate, endDate)
 		if err != nil {
-			return fmt.Errorf("日期格式错误: %w", err)
+			return i18n.Errorf("err.date_format", err)
 		}
 		claudeParser.DateFilter = dateFilter
 	}
@@ -101,6 +98,11 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		DailyStats:   make(map[string]models.TokenUsage),
 		SessionStats: make(map[string]models.SessionInfo),
 		ProjectStats: make(map[string]models.ProjectStats),
+		BranchStats:  make(map[string]models.BranchStats),
+		ToolStats:    make(map[string]models.ToolStats),
+		CacheStats:   make(map[string]models.CacheStats),
+		HourlyStats:  make(map[string]models.UsageBucket),
+		HourlyDetail: make(map[string]models.UsageBucket),
 		MessageTypes: make(map[string]int),
 	}
 
@@ -112,12 +114,12 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		}
 		
 		if verbose {
-			fmt.Printf("📂 正在处理目录: %s\n", targetDir)
+			fmt.Println(redactor.Text(i18n.T("common.processing_dir", targetDir)))
 		}
 		
 		stats, err := claudeParser.ParseDirectory(targetDir)
 		if err != nil {
-			fmt.Printf("⚠️  解析目录失败，跳过 %s: %v\n", targetDir, err)
+			fmt.Println(redactor.Text(i18n.T("common.parse_dir_failed", targetDir, err)))
 			continue
 		}
 
@@ -126,17 +128,26 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		successfulDirs = append(successfulDirs, targetDir)
 		
 		if verbose {
-			fmt.Printf("✅ 完成目录: %s (会话:%d, 消息:%d)\n", 
-				targetDir, stats.TotalSessions, stats.TotalMessages)
+			fmt.Println(redactor.Text(i18n.T("analyze.dir_done",
+				targetDir, stats.TotalSessions, stats.TotalMessages)))
 		}
 	}
 
-	if len(successfulDirs) == 0 {
-		return fmt.Errorf("没有找到有效的Claude配置目录")
+	// 合并归档中已被 Claude Code 清理的历史数据
+	archived, err := replayArchive(claudeParser)
+	if err != nil {
+		return err
+	}
+	if archived != nil {
+		mergeUsageStats(aggregatedStats, archived)
+	}
+
+	if len(successfulDirs) == 0 && archived == nil {
+		return i18n.Errorf("err.no_valid_dirs")
 	}
 
 	if verbose && len(successfulDirs) > 1 {
-		fmt.Printf("📊 聚合完成: 共处理 %d 个目录\n", len(successfulDirs))
+		fmt.Println(i18n.T("analyze.aggregated", len(successfulDirs)))
 	}
 
 	// 应用模型过滤器
@@ -147,15 +158,34 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	// 最终处理
 	claudeParser.FinalizeStats(aggregatedStats)
 	
-	// 估算订阅限额信息
-	if aggregatedStats.DetectedMode == "subscription" {
-		aggregatedStats.SubscriptionQuota = aggregatedStats.EstimateSubscriptionQuota()
+	// 订阅限额：按当前5小时窗口的实际用量计算，指定了计划时即使未检测到订阅模式也显示
+	quotaOptions, err := loadQuotaOptions(cmd)
+	if err != nil {
+		return err
+	}
+	if aggregatedStats.DetectedMode == "subscription" || quotaOptions.Plan != "" {
+		// 限额窗口与 blocks 命令使用相同的计费窗口
+		blocksReport, err := claudeParser.AnalyzeBlocks(aggregatedStats)
+		if err != nil {
+			return i18n.Errorf("err.blocks_analyze", err)
+		}
+		quotaOptions.Blocks = blocksReport.Blocks
+
+		quota, err := claudeParser.AnalyzeQuota(aggregatedStats, quotaOptions)
+		if err != nil {
+			return err
+		}
+		aggregatedStats.SubscriptionQuota = quota
 	}
 
 	// 格式化并输出结果
 	formatter := formatter.NewFormatter()
 	formatter.ShowDetails = showDetails
+	formatter.ShowCharts = showCharts
 	formatter.Verbose = verbose
+	if err := applyTemplate(formatter); err != nil {
+		return err
+	}
 	
 	// 设置颜色选项
 	if noColor {
@@ -164,16 +194,16 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 
 	output, err := formatter.Format(aggregatedStats, outputFormat)
 	if err != nil {
-		return fmt.Errorf("格式化失败: %w", err)
+		return i18n.Errorf("err.format_failed", err)
 	}
 
 	// 输出结果
 	if outputFile != "" {
 		err = writeToFile(output, outputFile)
 		if err != nil {
-			return fmt.Errorf("写入文件失败: %w", err)
+			return i18n.Errorf("err.write_failed", err)
 		}
-		fmt.Printf("✅ 报告已保存到: %s\n", outputFile)
+		fmt.Println(i18n.T("common.saved", outputFile))
 	} else {
 		fmt.Print(output)
 	}
@@ -297,33 +327,83 @@ func mergeUsageStats(target, source *models.UsageStats) {
 	for sessionID, session := range source.SessionStats {
 		if existing, exists := target.SessionStats[sessionID]; exists {
 			// 如果会话ID冲突，合并会话数据
-			existing.MessageCount += session.MessageCount
-			existing.Tokens.Add(session.Tokens)
-			if session.EndTime.After(existing.EndTime) {
-				existing.EndTime = session.EndTime
-			}
-			if session.StartTime.Before(existing.StartTime) {
-				existing.StartTime = session.StartTime
-			}
+			existing.Merge(session)
 			target.SessionStats[sessionID] = existing
 		} else {
 			target.SessionStats[sessionID] = session
 		}
 	}
 
+	// 合并子代理统计
+	target.Sidechain.Merge(source.Sidechain)
+	for date, bucket := range source.SidechainDaily {
+		if target.SidechainDaily == nil {
+			target.SidechainDaily = make(map[string]models.UsageBucket)
+		}
+		existing := target.SidechainDaily[date]
+		existing.Merge(bucket)
+		target.SidechainDaily[date] = existing
+	}
+
 	// 合并项目统计
 	for projectKey, project := range source.ProjectStats {
 		if existing, exists := target.ProjectStats[projectKey]; exists {
-			existing.Tokens.Add(project.Tokens)
-			if project.LastActivity.After(existing.LastActivity) {
-				existing.LastActivity = project.LastActivity
-			}
+			existing.Merge(project)
 			target.ProjectStats[projectKey] = existing
 		} else {
 			target.ProjectStats[projectKey] = project
 		}
 	}
 
+	// 合并分支统计
+	for branchKey, branch := range source.BranchStats {
+		if existing, exists := target.BranchStats[branchKey]; exists {
+			existing.Merge(branch)
+			target.BranchStats[branchKey] = existing
+		} else {
+			target.BranchStats[branchKey] = branch
+		}
+	}
+
+	// 合并工具统计
+	for name, tool := range source.ToolStats {
+		existing := target.ToolStats[name]
+		existing.Name = name
+		existing.Merge(tool)
+		target.ToolStats[name] = existing
+	}
+
+	// 合并用户统计
+	parser.MergeUserStats(target, source)
+
+	// 合并按小时统计
+	for hour, bucket := range source.HourlyStats {
+		existing := target.HourlyStats[hour]
+		existing.Merge(bucket)
+		target.HourlyStats[hour] = existing
+	}
+	for key, bucket := range source.HourlyDetail {
+		existing := target.HourlyDetail[key]
+		existing.Merge(bucket)
+		target.HourlyDetail[key] = existing
+	}
+
+	parser.MergeHourlyPrompts(target, source)
+
+	// 合并时延样本
+	target.LatencySamples = append(target.LatencySamples, source.LatencySamples...)
+
+	// 合并缓存统计
+	for key, cache := range source.CacheStats {
+		existing, exists := target.CacheStats[key]
+		if !exists {
+			target.CacheStats[key] = cache
+			continue
+		}
+		existing.Merge(cache)
+		target.CacheStats[key] = existing
+	}
+
 	// 合并消息类型统计
 	for msgType, count := range source.MessageTypes {
 		target.MessageTypes[msgType] += count
@@ -353,7 +433,7 @@ func createDateFilter(start, end string) (*parser.DateFilter, error) {
 	if start != "" {
 		startTime, err := parseDate(start)
 		if err != nil {
-			return nil, fmt.Errorf("开始日期解析失败: %w", err)
+			return nil, i18n.Errorf("err.start_date", err)
 		}
 		filter.StartDate = &startTime
 	}
@@ -361,7 +441,7 @@ func createDateFilter(start, end string) (*parser.DateFilter, error) {
 	if end != "" {
 		endTime, err := parseDate(end)
 		if err != nil {
-			return nil, fmt.Errorf("结束日期解析失败: %w", err)
+			return nil, i18n.Errorf("err.end_date", err)
 		}
 		filter.EndDate = &endTime
 	}
@@ -386,7 +466,7 @@ func parseDate(dateStr string) (time.Time, error) {
 		}
 	}
 	
-	return time.Time{}, fmt.Errorf("无法解析日期: %s，支持格式: YYYYMMDD, YYYY-MM-DD, YYYY/MM/DD", dateStr)
+	return time.Time{}, i18n.Errorf("err.parse_date", dateStr)
 }
 
 // filterByModel 按模型过滤统计数据
@@ -417,6 +497,28 @@ func filterByModel(stats *models.UsageStats, model string) *models.UsageStats {
 	return filtered
 }
 
+// loadQuotaOptions 读取订阅计划：--plan 优先，其次为配置文件中的 plan；各计划额度可在 plans 中覆盖
+//
+//	plan: max5x
+//	plans:
+//	  max5x:
+//	    messages: 250
+//	    tokens: 100000
+func loadQuotaOptions(cmd *cobra.Command) (parser.QuotaOptions, error) {
+	options := parser.QuotaOptions{
+		Plan: viper.GetString("plan"),
+	}
+	if cmd.Flags().Changed("plan") {
+		options.Plan = planName
+	}
+	if viper.IsSet("plans") {
+		if err := viper.UnmarshalKey("plans", &options.Plans); err != nil {
+			return options, i18n.Errorf("err.plans_config", err)
+		}
+	}
+	return options, nil
+}
+
 // writeToFile 写入文件
 func writeToFile(content, filename string) error {
 	return os.WriteFile(filename, []byte(content), 0644)
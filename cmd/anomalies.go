@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+	"github.com/zhuiye8/claude-stats/pkg/parser"
+)
+
+// anomaliesFoundExitCode check 模式下发现异常时的退出码（1 保留给运行错误）
+const anomaliesFoundExitCode = 2
+
+// anomaliesCmd 代表anomalies命令
+var anomaliesCmd = &cobra.Command{
+	Use:   "anomalies [dir]",
+	Short: "cmd.anomalies.short",
+	Long:  "cmd.anomalies.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runAnomalies,
+}
+
+func init() {
+	rootCmd.AddCommand(anomaliesCmd)
+
+	// anomalies命令特定的标志位
+	anomaliesCmd.Flags().StringVar(&anomaliesMetric, "metric", "cost", "flag.anomalies_metric")
+	anomaliesCmd.Flags().Float64Var(&anomaliesThreshold, "threshold", 3.5, "flag.anomalies_threshold")
+	anomaliesCmd.Flags().IntVar(&anomaliesWindow, "window", 14, "flag.anomalies_window")
+	anomaliesCmd.Flags().StringSliceVar(&anomaliesKinds, "kind", []string{"day", "session", "block"}, "flag.anomalies_kind")
+	anomaliesCmd.Flags().StringVar(&anomaliesLast, "last", "", "flag.anomalies_last")
+	anomaliesCmd.Flags().BoolVar(&anomaliesCheck, "check", false, "flag.anomalies_check")
+
+	// 继承通用标志位
+	anomaliesCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "flag.format_anomalies")
+	anomaliesCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	anomaliesCmd.Flags().StringVar(&startDate, "since", "", "flag.since")
+	anomaliesCmd.Flags().StringVar(&endDate, "until", "", "flag.until")
+	anomaliesCmd.Flags().StringVar(&projectFilter, "project", "", "flag.project")
+	anomaliesCmd.Flags().StringVar(&sidechainMode, "sidechain", "include", "flag.sidechain")
+	anomaliesCmd.Flags().StringVar(&redactMode, "redact", "none", "flag.redact")
+	anomaliesCmd.Flags().BoolVar(&noColor, "no-color", false, "flag.no_color")
+}
+
+func runAnomalies(cmd *cobra.Command, args []string) error {
+	if anomaliesThreshold <= 0 {
+		return i18n.Errorf("err.anomalies_threshold", anomaliesThreshold)
+	}
+	if anomaliesWindow < 1 {
+		return i18n.Errorf("err.anomalies_window", anomaliesWindow)
+	}
+	last, err := parseLastDuration(anomaliesLast)
+	if err != nil {
+		return err
+	}
+
+	redactor, err := resolveRedactor(redactMode)
+	if err != nil {
+		return err
+	}
+
+	// 确定要分析的目录列表
+	targetDirs := getTargetDirectories(args)
+
+	if verbose {
+		fmt.Println(redactor.Text(i18n.T("common.analyzing_dirs", strings.Join(targetDirs, ", "))))
+	}
+
+	stats, err := parseDirectories(targetDirs, redactor)
+	if err != nil {
+		return err
+	}
+
+	// 计费窗口与 blocks 命令使用相同的划分
+	claudeParser := parser.NewClaudeParser()
+	blocksReport, err := claudeParser.AnalyzeBlocks(stats)
+	if err != nil {
+		return i18n.Errorf("err.blocks_analyze", err)
+	}
+
+	report, err := claudeParser.AnalyzeAnomalies(stats, parser.AnomalyOptions{
+		Metric:     anomaliesMetric,
+		Threshold:  anomaliesThreshold,
+		WindowDays: anomaliesWindow,
+		Kinds:      anomaliesKinds,
+		Blocks:     blocksReport.Blocks,
+	})
+	if err != nil {
+		return err
+	}
+
+	// 只保留最近一段时间内结束的异常
+	if last > 0 {
+		since := time.Now().Add(-last)
+		recent := []models.Anomaly{}
+		for _, anomaly := range report.Anomalies {
+			if anomaly.End.After(since) {
+				recent = append(recent, anomaly)
+			}
+		}
+		report.Anomalies = recent
+	}
+
+	// 格式化并输出结果
+	formatter := formatter.NewFormatter()
+	formatter.Verbose = verbose
+
+	// 设置颜色选项
+	if noColor {
+		formatter.Colors.Enabled = false
+	}
+
+	var output string
+
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		output, err = formatter.FormatAnomaliesJSON(report)
+	case "csv":
+		output, err = formatter.FormatAnomaliesCSV(report)
+	case "markdown":
+		output, err = formatter.FormatAnomaliesMarkdown(report)
+	case "table", "":
+		output, err = formatter.FormatAnomalies(report)
+	default:
+		return i18n.Errorf("err.unsupported_format", outputFormat)
+	}
+
+	if err != nil {
+		return i18n.Errorf("err.format_failed", err)
+	}
+
+	if err := writeOutput(output); err != nil {
+		return err
+	}
+
+	// check 模式：发现异常时以非零退出码结束，便于在脚本和定时任务中使用
+	if anomaliesCheck && len(report.Anomalies) > 0 {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return &ExitError{Code: anomaliesFoundExitCode}
+	}
+	return nil
+}
+
+// parseLastDuration 解析 --last，支持 Go 时长格式（如 6h）和天数（如 7d）
+func parseLastDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil || days < 1 {
+			return 0, i18n.Errorf("err.anomalies_last", value)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, i18n.Errorf("err.anomalies_last", value)
+	}
+	return duration, nil
+}
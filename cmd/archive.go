@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zhuiye8/claude-stats/pkg/archive"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+	"github.com/zhuiye8/claude-stats/pkg/parser"
+)
+
+// archiveCmd 代表archive命令
+var archiveCmd = &cobra.Command{
+	Use:   "archive [dir]",
+	Short: "cmd.archive.short",
+	Long:  "cmd.archive.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runArchive,
+}
+
+func init() {
+	rootCmd.AddCommand(archiveCmd)
+}
+
+func runArchive(cmd *cobra.Command, args []string) error {
+	targetDirs := getTargetDirectories(args)
+	if verbose {
+		fmt.Println(i18n.T("common.analyzing_dirs", strings.Join(targetDirs, ", ")))
+	}
+
+	// 归档保存全部用量记录，不应用任何过滤条件
+	claudeParser := parser.NewClaudeParser()
+	claudeParser.Verbose = verbose
+	claudeParser.SkipErrors = true
+
+	var records []models.UsageRecord
+	for _, targetDir := range targetDirs {
+		if _, err := os.Stat(targetDir); os.IsNotExist(err) {
+			continue // 跳过不存在的目录
+		}
+		err := claudeParser.StreamDirectory(targetDir, func(record models.UsageRecord) error {
+			records = append(records, record)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	arc := archive.New(resolveArchiveDir())
+	added, err := arc.Add(records)
+	if err != nil {
+		return i18n.Errorf("err.archive_failed", err)
+	}
+
+	// 由全部归档数据重建每日汇总
+	daily := parser.NewDailyRollup()
+	total := 0
+	err = arc.ForEach(func(record models.UsageRecord) error {
+		total++
+		return daily.Add(record)
+	})
+	if err != nil {
+		return i18n.Errorf("err.archive_failed", err)
+	}
+	if err := arc.WriteRollups(daily.Rows()); err != nil {
+		return i18n.Errorf("err.archive_failed", err)
+	}
+
+	months, err := arc.Months()
+	if err != nil {
+		return i18n.Errorf("err.archive_failed", err)
+	}
+	fmt.Println(i18n.T("archive.done", added, arc.Dir))
+	fmt.Println(i18n.T("archive.totals", total, len(months)))
+	return nil
+}
+
+// resolveArchiveDir 确定归档目录：--archive-dir > 配置文件 archive_dir > ~/.claude-stats-archive
+func resolveArchiveDir() string {
+	if archiveDir != "" {
+		return expandHome(archiveDir)
+	}
+	if configured := viper.GetString("archive_dir"); configured != "" {
+		return expandHome(configured)
+	}
+	return archive.DefaultDir()
+}
+
+// replayArchive 将归档、已导入的团队数据和 --snapshot 快照中未出现在实时日志里的记录重放为统计数据
+// 必须在解析完实时日志之后调用；--no-archive 只跳过归档和已导入数据，没有可合并的数据时返回 nil
+func replayArchive(claudeParser *parser.ClaudeParser) (*models.UsageStats, error) {
+	var merged *models.UsageStats
+	replay := func(source, message string, each func(emit func(models.UsageRecord) error) error) error {
+		stats, replayed, err := claudeParser.ReplayRecords(each)
+		if err != nil {
+			return err
+		}
+		if verbose {
+			fmt.Println(claudeParser.Redactor.Text(i18n.T(message, replayed, source)))
+		}
+		if replayed == 0 {
+			return nil
+		}
+		if merged == nil {
+			merged = stats
+		} else {
+			mergeUsageStats(merged, stats)
+		}
+		return nil
+	}
+
+	if !noArchive {
+		arc := archive.New(resolveArchiveDir())
+		if arc.Exists() {
+			if err := replay(arc.Dir, "archive.merged", arc.ForEach); err != nil {
+				return nil, i18n.Errorf("err.archive_read", arc.Dir, err)
+			}
+
+			imported, err := arc.ImportedUsers()
+			if err != nil {
+				return nil, i18n.Errorf("err.archive_read", arc.Dir, err)
+			}
+			for _, userArchive := range imported {
+				if err := replay(userArchive.Dir, "import.merged", userArchive.ForEach); err != nil {
+					return nil, i18n.Errorf("err.archive_read", userArchive.Dir, err)
+				}
+			}
+		}
+	}
+
+	for _, path := range snapshotFiles {
+		snapshot, err := archive.ReadSnapshot(expandHome(path))
+		if err != nil {
+			return nil, i18n.Errorf("err.snapshot_read", path, err)
+		}
+		err = replay(path, "snapshot.merged", func(emit func(models.UsageRecord) error) error {
+			for _, record := range snapshot.Entries {
+				if err := emit(record); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, i18n.Errorf("err.snapshot_read", path, err)
+		}
+	}
+
+	return merged, nil
+}
+
+// localUserLabel 确定本机实时日志的用户标签：配置文件 user > 系统用户名
+func localUserLabel() string {
+	if configured := viper.GetString("user"); configured != "" {
+		return configured
+	}
+	return parser.DefaultUserLabel()
+}
@@ -9,80 +9,71 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/zhuiye8/claude-stats/pkg/parser"
+	"github.com/zhuiye8/claude-stats/pkg/redact"
 	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
 	"github.com/zhuiye8/claude-stats/pkg/models"
 )
 
 // blocksCmd 代表blocks命令
 var blocksCmd = &cobra.Command{
-	Use:   "blocks [目录路径]",
-	Short: "分析5小时计费窗口使用情况",
-	Long: `分析Claude Code的5小时计费窗口使用情况，帮助理解使用模式和预测成本。
-
-5小时计费窗口是Claude订阅模式的计费单位，每个窗口内有固定的消息限制。
-此命令可以帮助您：
-• 了解不同时间段的使用强度
-• 识别活跃使用窗口
-• 预测当前窗口的使用趋势
-• 监控实时使用率
-
-支持功能：
-• 自动检测窗口边界（每5小时一个周期）
-• 显示活跃窗口状态和剩余时间
-• 计算燃烧速率和预测使用量
-• 实时监控模式（--live）
-• Token限制预警（--token-limit）
-
-示例：
-  claude-stats blocks                    # 显示基本窗口分析
-  claude-stats blocks --live             # 实时监控当前窗口
-  claude-stats blocks --live -t 500000   # 设置Token限制监控
-  claude-stats blocks --active           # 只显示活跃窗口
-  claude-stats blocks --recent           # 显示最近的窗口
-  claude-stats blocks --json             # JSON格式输出`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runBlocks,
+	Use:   "blocks [dir]",
+	Short: "cmd.blocks.short",
+	Long:  "cmd.blocks.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runBlocks,
 }
 
 func init() {
 	rootCmd.AddCommand(blocksCmd)
 
 	// blocks命令特定的标志位
-	blocksCmd.Flags().BoolVar(&blocksLive, "live", false, "实时监控模式")
-	blocksCmd.Flags().StringVarP(&blocksTokenLimit, "token-limit", "t", "", "Token限制 (数字或'max')")
-	blocksCmd.Flags().IntVar(&blocksRefreshInterval, "refresh-interval", 3, "实时模式刷新间隔(秒)")
-	blocksCmd.Flags().BoolVar(&blocksActive, "active", false, "只显示活跃窗口")
-	blocksCmd.Flags().BoolVar(&blocksRecent, "recent", false, "显示最近的窗口")
+	blocksCmd.Flags().BoolVar(&blocksLive, "live", false, "flag.blocks_live")
+	blocksCmd.Flags().StringVarP(&blocksTokenLimit, "token-limit", "t", "", "flag.blocks_token_limit")
+	blocksCmd.Flags().IntVar(&blocksRefreshInterval, "refresh-interval", 3, "flag.blocks_refresh_interval")
+	blocksCmd.Flags().BoolVar(&showCharts, "chart", false, "flag.chart")
+	blocksCmd.Flags().StringVar(&templateFile, "template", "", "flag.template")
+	blocksCmd.Flags().StringVar(&templateString, "template-string", "", "flag.template_string")
+	blocksCmd.Flags().BoolVar(&blocksActive, "active", false, "flag.blocks_active")
+	blocksCmd.Flags().BoolVar(&blocksRecent, "recent", false, "flag.blocks_recent")
 	
 	// 继承通用标志位
-	blocksCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "输出格式 (table, json)")
-	blocksCmd.Flags().StringVarP(&outputFile, "output", "o", "", "输出文件路径")
-	blocksCmd.Flags().StringVar(&startDate, "since", "", "开始日期 (YYYYMMDD)")
-	blocksCmd.Flags().StringVar(&endDate, "until", "", "结束日期 (YYYYMMDD)")
-	blocksCmd.Flags().BoolVar(&noColor, "no-color", false, "禁用颜色输出")
-	blocksCmd.Flags().BoolVarP(&offline, "offline", "O", false, "离线模式")
+	blocksCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "flag.format_blocks")
+	blocksCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	blocksCmd.Flags().StringVar(&startDate, "since", "", "flag.since")
+	blocksCmd.Flags().StringVar(&endDate, "until", "", "flag.until")
+	blocksCmd.Flags().BoolVar(&noColor, "no-color", false, "flag.no_color")
+	blocksCmd.Flags().BoolVarP(&offline, "offline", "O", false, "flag.offline")
+	blocksCmd.Flags().StringVar(&projectFilter, "project", "", "flag.project")
+	blocksCmd.Flags().StringVar(&sidechainMode, "sidechain", "include", "flag.sidechain")
+	blocksCmd.Flags().StringVar(&redactMode, "redact", "none", "flag.redact")
 }
 
 func runBlocks(cmd *cobra.Command, args []string) error {
+	redactor, err := resolveRedactor(redactMode)
+	if err != nil {
+		return err
+	}
+
 	// 确定要分析的目录列表
 	targetDirs := getTargetDirectories(args)
 	
 	if verbose {
-		fmt.Printf("🔍 分析目录: %s\n", strings.Join(targetDirs, ", "))
+		fmt.Println(redactor.Text(i18n.T("common.analyzing_dirs", strings.Join(targetDirs, ", "))))
 	}
 
 	// 如果是实时模式，循环执行
 	if blocksLive {
-		return runLiveBlocks(targetDirs)
+		return runLiveBlocks(targetDirs, redactor)
 	}
 
 	// 执行一次性分析
-	return runSingleBlocks(targetDirs)
+	return runSingleBlocks(targetDirs, redactor)
 }
 
 // runSingleBlocks 执行一次性blocks分析
-func runSingleBlocks(targetDirs []string) error {
-	stats, err := parseDirectories(targetDirs)
+func runSingleBlocks(targetDirs []string, redactor *redact.Redactor) error {
+	stats, err := parseDirectories(targetDirs, redactor)
 	if err != nil {
 		return err
 	}
@@ -91,7 +82,7 @@ func runSingleBlocks(targetDirs []string) error {
 	claudeParser := parser.NewClaudeParser()
 	blocksReport, err := claudeParser.AnalyzeBlocks(stats)
 	if err != nil {
-		return fmt.Errorf("分析blocks失败: %w", err)
+		return i18n.Errorf("err.blocks_analyze", err)
 	}
 
 	// 应用过滤器
@@ -102,10 +93,11 @@ func runSingleBlocks(targetDirs []string) error {
 }
 
 // runLiveBlocks 执行实时监控模式
-func runLiveBlocks(targetDirs []string) error {
+func runLiveBlocks(targetDirs []string, redactor *redact.Redactor) error {
 	if verbose {
-		fmt.Printf("🔴 启动实时监控模式 (刷新间隔: %d秒)\n", blocksRefreshInterval)
-		fmt.Printf("💡 按 Ctrl+C 退出监控\n\n")
+		fmt.Println(i18n.T("blocks.live_start", blocksRefreshInterval))
+		fmt.Println(i18n.T("blocks.live_exit_hint"))
+		fmt.Println()
 	}
 
 	// 解析Token限制
@@ -126,21 +118,21 @@ func runLiveBlocks(targetDirs []string) error {
 		fmt.Print("\033[2J\033[H")
 		
 		// 显示时间戳
-		fmt.Printf("🕐 监控时间: %s\n", time.Now().Format("2006-01-02 15:04:05"))
+		fmt.Println(i18n.T("blocks.live_time", time.Now().Format("2006-01-02 15:04:05")))
 		if tokenLimit > 0 {
-			fmt.Printf("⚡ Token限制: %s\n", formatNumber(tokenLimit))
+			fmt.Println(i18n.T("blocks.token_limit", formatNumber(tokenLimit)))
 		}
 		fmt.Println()
 
 		// 执行分析
-		stats, err := parseDirectories(targetDirs)
+		stats, err := parseDirectories(targetDirs, redactor)
 		if err != nil {
-			fmt.Printf("❌ 解析失败: %v\n", err)
+			fmt.Println(i18n.T("blocks.live_parse_failed", err))
 		} else {
 			claudeParser := parser.NewClaudeParser()
 			blocksReport, err := claudeParser.AnalyzeBlocks(stats)
 			if err != nil {
-				fmt.Printf("❌ 分析失败: %v\n", err)
+				fmt.Println(i18n.T("blocks.live_analyze_failed", err))
 			} else {
 				// 只显示活跃窗口
 				activeReport := filterActiveBlocks(blocksReport)
@@ -158,7 +150,7 @@ func runLiveBlocks(targetDirs []string) error {
 				
 				output, err := formatter.FormatBlocks(activeReport)
 				if err != nil {
-					fmt.Printf("❌ 格式化失败: %v\n", err)
+					fmt.Println(i18n.T("blocks.live_format_failed", err))
 				} else {
 					fmt.Print(output)
 				}
@@ -170,20 +162,30 @@ func runLiveBlocks(targetDirs []string) error {
 	}
 }
 
-// parseDirectories 解析所有目录并聚合数据
-func parseDirectories(targetDirs []string) (*models.UsageStats, error) {
-	// 创建解析器
-	claudeParser := parser.NewClaudeParser()
-	claudeParser.Verbose = verbose
-	claudeParser.SkipErrors = true
-
+// parseDirectories 解析所有目录并聚合数据，redactor 为解析器使用的脱敏器
+func parseDirectories(targetDirs []string, redactor *redact.Redactor) (*models.UsageStats, error) {
 	// 设置日期过滤器
+	var dateFilter *parser.DateFilter
 	if startDate != "" || endDate != "" {
-		dateFilter, err := createDateFilter(startDate, endDate)
+		filter, err := createDateFilter(startDate, endDate)
 		if err != nil {
-			return nil, fmt.Errorf("日期格式错误: %w", err)
+			return nil, i18n.Errorf("err.date_format", err)
 		}
-		claudeParser.DateFilter = dateFilter
+		dateFilter = filter
+	}
+
+	return parseDirectoriesWithFilter(targetDirs, dateFilter, redactor)
+}
+
+// parseDirectoriesWithFilter 使用指定的日期过滤器解析并聚合多个目录
+func parseDirectoriesWithFilter(targetDirs []string, dateFilter *parser.DateFilter, redactor *redact.Redactor) (*models.UsageStats, error) {
+	// 创建解析器
+	claudeParser := parser.NewClaudeParser()
+	claudeParser.Verbose = verbose
+	claudeParser.SkipErrors = true
+	claudeParser.DateFilter = dateFilter
+	if err := applyProjectOptions(claudeParser, redactor); err != nil {
+		return nil, err
 	}
 
 	// 解析所有目录并聚合数据
@@ -192,6 +194,11 @@ func parseDirectories(targetDirs []string) (*models.UsageStats, error) {
 		DailyStats:   make(map[string]models.TokenUsage),
 		SessionStats: make(map[string]models.SessionInfo),
 		ProjectStats: make(map[string]models.ProjectStats),
+		BranchStats:  make(map[string]models.BranchStats),
+		ToolStats:    make(map[string]models.ToolStats),
+		CacheStats:   make(map[string]models.CacheStats),
+		HourlyStats:  make(map[string]models.UsageBucket),
+		HourlyDetail: make(map[string]models.UsageBucket),
 		MessageTypes: make(map[string]int),
 	}
 
@@ -205,7 +212,7 @@ func parseDirectories(targetDirs []string) (*models.UsageStats, error) {
 		stats, err := claudeParser.ParseDirectory(targetDir)
 		if err != nil {
 			if verbose {
-				fmt.Printf("⚠️  解析目录失败，跳过 %s: %v\n", targetDir, err)
+				fmt.Println(redactor.Text(i18n.T("common.parse_dir_failed", targetDir, err)))
 			}
 			continue
 		}
@@ -215,8 +222,17 @@ func parseDirectories(targetDirs []string) (*models.UsageStats, error) {
 		successfulDirs = append(successfulDirs, targetDir)
 	}
 
-	if len(successfulDirs) == 0 {
-		return nil, fmt.Errorf("没有找到有效的Claude配置目录")
+	// 合并归档中已被 Claude Code 清理的历史数据
+	archived, err := replayArchive(claudeParser)
+	if err != nil {
+		return nil, err
+	}
+	if archived != nil {
+		mergeUsageStats(aggregatedStats, archived)
+	}
+
+	if len(successfulDirs) == 0 && archived == nil {
+		return nil, i18n.Errorf("err.no_valid_dirs")
 	}
 
 	// 最终处理
@@ -288,11 +304,11 @@ func checkTokenLimits(report *models.BlocksReport, tokenLimit int) {
 		usage := float64(currentTokens) / float64(tokenLimit) * 100
 		
 		if usage > 90 {
-			fmt.Printf("🚨 警告: 当前窗口Token使用率 %.1f%% (%.0f/%d)\n", usage, float64(currentTokens), tokenLimit)
+			fmt.Println(i18n.T("blocks.limit_critical", usage, float64(currentTokens), tokenLimit))
 		} else if usage > 75 {
-			fmt.Printf("⚠️  注意: 当前窗口Token使用率 %.1f%% (%.0f/%d)\n", usage, float64(currentTokens), tokenLimit)
+			fmt.Println(i18n.T("blocks.limit_warning", usage, float64(currentTokens), tokenLimit))
 		} else if usage > 50 {
-			fmt.Printf("💡 提示: 当前窗口Token使用率 %.1f%% (%.0f/%d)\n", usage, float64(currentTokens), tokenLimit)
+			fmt.Println(i18n.T("blocks.limit_notice", usage, float64(currentTokens), tokenLimit))
 		}
 	}
 }
@@ -302,6 +318,10 @@ func outputBlocksReport(report *models.BlocksReport) error {
 	// 格式化并输出结果
 	formatter := formatter.NewFormatter()
 	formatter.Verbose = verbose
+	formatter.ShowCharts = showCharts
+	if err := applyTemplate(formatter); err != nil {
+		return err
+	}
 	
 	// 设置颜色选项
 	if noColor {
@@ -314,23 +334,29 @@ func outputBlocksReport(report *models.BlocksReport) error {
 	switch strings.ToLower(outputFormat) {
 	case "json":
 		output, err = formatter.FormatBlocksJSON(report)
+	case "html":
+		output, err = formatter.FormatBlocksHTML(report)
+	case "markdown":
+		output, err = formatter.FormatBlocksMarkdown(report)
+	case "template":
+		output, err = formatter.FormatTemplate(report)
 	case "table", "":
 		output, err = formatter.FormatBlocks(report)
 	default:
-		return fmt.Errorf("不支持的格式: %s", outputFormat)
+		return i18n.Errorf("err.unsupported_format", outputFormat)
 	}
 	
 	if err != nil {
-		return fmt.Errorf("格式化失败: %w", err)
+		return i18n.Errorf("err.format_failed", err)
 	}
 
 	// 输出结果
 	if outputFile != "" {
 		err = writeToFile(output, outputFile)
 		if err != nil {
-			return fmt.Errorf("写入文件失败: %w", err)
+			return i18n.Errorf("err.write_failed", err)
 		}
-		fmt.Printf("✅ 报告已保存到: %s\n", outputFile)
+		fmt.Println(i18n.T("common.saved", outputFile))
 	} else {
 		fmt.Print(output)
 	}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+	"github.com/zhuiye8/claude-stats/pkg/parser"
+	"github.com/zhuiye8/claude-stats/pkg/redact"
+	"github.com/zhuiye8/claude-stats/pkg/vcs"
+)
+
+// commitGracePeriod 会话结束后仍视为该会话产生的提交的时间
+const commitGracePeriod = 30 * time.Minute
+
+// branchesCmd 代表branches命令
+var branchesCmd = &cobra.Command{
+	Use:   "branches [dir]",
+	Short: "cmd.branches.short",
+	Long:  "cmd.branches.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runBranches,
+}
+
+func init() {
+	rootCmd.AddCommand(branchesCmd)
+
+	// branches命令特定的标志位
+	branchesCmd.Flags().StringVar(&branchFilter, "branch", "", "flag.branch")
+	branchesCmd.Flags().BoolVar(&branchesCommits, "commits", false, "flag.branches_commits")
+	branchesCmd.Flags().StringVar(&branchesRepo, "repo", "", "flag.branches_repo")
+	branchesCmd.Flags().StringVar(&projectsSort, "sort", "cost", "flag.branches_sort")
+	branchesCmd.Flags().StringVar(&order, "order", "desc", "flag.order")
+	branchesCmd.Flags().BoolVarP(&showDetails, "details", "d", false, "flag.details")
+
+	// 继承通用标志位
+	branchesCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "flag.format_branches")
+	branchesCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	branchesCmd.Flags().StringVar(&startDate, "since", "", "flag.since")
+	branchesCmd.Flags().StringVar(&endDate, "until", "", "flag.until")
+	branchesCmd.Flags().StringVar(&projectFilter, "project", "", "flag.project")
+	branchesCmd.Flags().StringVar(&sidechainMode, "sidechain", "include", "flag.sidechain")
+	branchesCmd.Flags().StringVar(&redactMode, "redact", "none", "flag.redact")
+	branchesCmd.Flags().BoolVar(&noColor, "no-color", false, "flag.no_color")
+}
+
+func runBranches(cmd *cobra.Command, args []string) error {
+	redactor, err := resolveRedactor(redactMode)
+	if err != nil {
+		return err
+	}
+
+	// 确定要分析的目录列表
+	targetDirs := getTargetDirectories(args)
+
+	if verbose {
+		fmt.Println(redactor.Text(i18n.T("common.analyzing_dirs", strings.Join(targetDirs, ", "))))
+	}
+
+	stats, err := parseDirectories(targetDirs, redactor)
+	if err != nil {
+		return err
+	}
+
+	claudeParser := parser.NewClaudeParser()
+	report := claudeParser.AnalyzeBranches(stats)
+	report = filterBranches(report, branchFilter)
+
+	// --repo 隐含关联提交
+	if branchesCommits || branchesRepo != "" {
+		linkCommits(report, expandHome(branchesRepo), redactor)
+	}
+
+	if err := sortBranches(report.Branches, projectsSort, order); err != nil {
+		return err
+	}
+
+	// 格式化并输出结果
+	formatter := formatter.NewFormatter()
+	formatter.ShowDetails = showDetails
+	formatter.Verbose = verbose
+
+	// 设置颜色选项
+	if noColor {
+		formatter.Colors.Enabled = false
+	}
+
+	var output string
+
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		output, err = formatter.FormatBranchesJSON(report)
+	case "csv":
+		output, err = formatter.FormatBranchesCSV(report)
+	case "markdown":
+		output, err = formatter.FormatBranchesMarkdown(report)
+	case "table", "":
+		output, err = formatter.FormatBranches(report)
+	default:
+		return i18n.Errorf("err.unsupported_format", outputFormat)
+	}
+
+	if err != nil {
+		return i18n.Errorf("err.format_failed", err)
+	}
+
+	return writeOutput(output)
+}
+
+// filterBranches 按分支名过滤（支持通配符），并重新计算汇总
+func filterBranches(report *models.BranchesReport, pattern string) *models.BranchesReport {
+	if pattern == "" {
+		return report
+	}
+
+	filtered := &models.BranchesReport{
+		Type:     report.Type,
+		Branches: []models.BranchStats{},
+		Summary:  models.BranchStats{ProjectName: report.Summary.ProjectName},
+	}
+	for _, branch := range report.Branches {
+		matched, _ := filepath.Match(pattern, branch.Branch)
+		if matched || branch.Branch == pattern {
+			filtered.Branches = append(filtered.Branches, branch)
+			filtered.Summary.Merge(branch)
+		}
+	}
+	return filtered
+}
+
+// linkCommits 用本地git log关联会话时间段内在该分支上产生的提交
+// repo 为空时使用项目路径所在的仓库
+func linkCommits(report *models.BranchesReport, repo string, redactor *redact.Redactor) {
+	repoRoots := make(map[string]string)
+
+	for i := range report.Branches {
+		branch := &report.Branches[i]
+		// 脱敏后的分支名无法在仓库中解析
+		if branch.Branch == "" || branch.Branch == "HEAD" || redact.IsHashedBranch(branch.Branch) {
+			continue
+		}
+
+		root := repo
+		if root == "" {
+			cached, ok := repoRoots[branch.ProjectPath]
+			if !ok {
+				// --redact paths 输出的路径以 ~ 开头，仍可定位到本地仓库
+				resolved, err := vcs.RepoRoot(expandHome(branch.ProjectPath))
+				if err != nil && verbose {
+					fmt.Fprintln(os.Stderr, redactor.Text(i18n.T("branches.repo_failed", branch.ProjectPath, err)))
+				}
+				repoRoots[branch.ProjectPath] = resolved
+				cached = resolved
+			}
+			root = cached
+		}
+		if root == "" {
+			continue
+		}
+		branch.Repository = redactor.Path(root)
+
+		seen := make(map[string]bool)
+		for _, window := range branch.SessionWindows {
+			commits, err := vcs.CommitsInWindow(root, branch.Branch, window.StartTime, window.EndTime.Add(commitGracePeriod))
+			if err != nil {
+				if verbose {
+					fmt.Fprintln(os.Stderr, redactor.Text(i18n.T("branches.log_failed", branch.Branch, err)))
+				}
+				break
+			}
+			for _, commit := range commits {
+				if !seen[commit.Hash] {
+					seen[commit.Hash] = true
+					branch.Commits = append(branch.Commits, commit)
+				}
+			}
+		}
+
+		sort.Slice(branch.Commits, func(a, b int) bool {
+			return branch.Commits[a].Time.Before(branch.Commits[b].Time)
+		})
+		report.Summary.Commits = append(report.Summary.Commits, branch.Commits...)
+	}
+}
+
+// sortBranches 按指定字段排序分支
+func sortBranches(branches []models.BranchStats, field, direction string) error {
+	var less func(a, b models.BranchStats) bool
+
+	switch strings.ToLower(field) {
+	case "cost", "":
+		less = func(a, b models.BranchStats) bool { return a.Cost < b.Cost }
+	case "tokens":
+		less = func(a, b models.BranchStats) bool { return a.Tokens.GetTotalTokens() < b.Tokens.GetTotalTokens() }
+	case "messages":
+		less = func(a, b models.BranchStats) bool { return a.MessageCount < b.MessageCount }
+	case "sessions":
+		less = func(a, b models.BranchStats) bool { return a.SessionCount < b.SessionCount }
+	case "commits":
+		less = func(a, b models.BranchStats) bool { return len(a.Commits) < len(b.Commits) }
+	case "last":
+		less = func(a, b models.BranchStats) bool { return a.LastActivity.Before(b.LastActivity) }
+	case "name":
+		less = func(a, b models.BranchStats) bool {
+			if a.ProjectName != b.ProjectName {
+				return a.ProjectName < b.ProjectName
+			}
+			return a.Branch < b.Branch
+		}
+	default:
+		return i18n.Errorf("err.unsupported_sort", field)
+	}
+
+	sort.SliceStable(branches, func(i, j int) bool {
+		if direction == "asc" {
+			return less(branches[i], branches[j])
+		}
+		return less(branches[j], branches[i])
+	})
+	return nil
+}
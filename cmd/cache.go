@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+	"github.com/zhuiye8/claude-stats/pkg/parser"
+)
+
+// cacheCmd 代表cache命令
+var cacheCmd = &cobra.Command{
+	Use:   "cache [dir]",
+	Short: "cmd.cache.short",
+	Long:  "cmd.cache.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runCache,
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+
+	// cache命令特定的标志位
+	cacheCmd.Flags().StringVar(&cacheBy, "by", "day", "flag.cache_by")
+	cacheCmd.Flags().StringVar(&cacheSort, "sort", "", "flag.cache_sort")
+	cacheCmd.Flags().StringVar(&order, "order", "desc", "flag.order")
+	cacheCmd.Flags().IntVar(&cacheTop, "top", 0, "flag.cache_top")
+
+	// 继承通用标志位
+	cacheCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "flag.format_cache")
+	cacheCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	cacheCmd.Flags().StringVar(&startDate, "since", "", "flag.since")
+	cacheCmd.Flags().StringVar(&endDate, "until", "", "flag.until")
+	cacheCmd.Flags().StringVar(&projectFilter, "project", "", "flag.project")
+	cacheCmd.Flags().StringVar(&sidechainMode, "sidechain", "include", "flag.sidechain")
+	cacheCmd.Flags().StringVar(&redactMode, "redact", "none", "flag.redact")
+	cacheCmd.Flags().BoolVar(&noColor, "no-color", false, "flag.no_color")
+}
+
+func runCache(cmd *cobra.Command, args []string) error {
+	redactor, err := resolveRedactor(redactMode)
+	if err != nil {
+		return err
+	}
+
+	// 确定要分析的目录列表
+	targetDirs := getTargetDirectories(args)
+
+	if verbose {
+		fmt.Println(redactor.Text(i18n.T("common.analyzing_dirs", strings.Join(targetDirs, ", "))))
+	}
+
+	stats, err := parseDirectories(targetDirs, redactor)
+	if err != nil {
+		return err
+	}
+
+	claudeParser := parser.NewClaudeParser()
+	report, err := claudeParser.AnalyzeCache(stats, cacheBy)
+	if err != nil {
+		return err
+	}
+
+	// 按日期查看时默认按日期排序，其他维度默认把浪费最多的排在前面
+	sortField := cacheSort
+	if sortField == "" {
+		sortField = "wasted"
+		if report.Dimension == parser.CacheByDay {
+			sortField = "name"
+		}
+	}
+	if err := sortCache(report.Items, sortField, order); err != nil {
+		return err
+	}
+	if cacheTop > 0 && len(report.Items) > cacheTop {
+		report.Items = report.Items[:cacheTop]
+	}
+
+	// 格式化并输出结果
+	formatter := formatter.NewFormatter()
+	formatter.Verbose = verbose
+
+	// 设置颜色选项
+	if noColor {
+		formatter.Colors.Enabled = false
+	}
+
+	var output string
+
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		output, err = formatter.FormatCacheJSON(report)
+	case "csv":
+		output, err = formatter.FormatCacheCSV(report)
+	case "markdown":
+		output, err = formatter.FormatCacheMarkdown(report)
+	case "table", "":
+		output, err = formatter.FormatCache(report)
+	default:
+		return i18n.Errorf("err.unsupported_format", outputFormat)
+	}
+
+	if err != nil {
+		return i18n.Errorf("err.format_failed", err)
+	}
+
+	return writeOutput(output)
+}
+
+// sortCache 按指定字段排序缓存统计
+func sortCache(items []models.CacheStats, field, direction string) error {
+	var less func(a, b models.CacheStats) bool
+
+	switch strings.ToLower(field) {
+	case "wasted":
+		less = func(a, b models.CacheStats) bool { return a.Wasted5mUSD < b.Wasted5mUSD }
+	case "wasted_1h":
+		less = func(a, b models.CacheStats) bool { return a.Wasted1hUSD < b.Wasted1hUSD }
+	case "savings":
+		less = func(a, b models.CacheStats) bool { return a.NetSavingsUSD < b.NetSavingsUSD }
+	case "hit_ratio":
+		less = func(a, b models.CacheStats) bool { return a.HitRatio < b.HitRatio }
+	case "tokens":
+		less = func(a, b models.CacheStats) bool {
+			return a.CacheCreationTokens+a.CacheReadTokens < b.CacheCreationTokens+b.CacheReadTokens
+		}
+	case "name":
+		less = func(a, b models.CacheStats) bool { return a.Name < b.Name }
+	default:
+		return i18n.Errorf("err.unsupported_sort", field)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if direction == "asc" {
+			return less(items[i], items[j])
+		}
+		return less(items[j], items[i])
+	})
+	return nil
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+	"github.com/zhuiye8/claude-stats/pkg/parser"
+	"github.com/zhuiye8/claude-stats/pkg/redact"
+	"github.com/zhuiye8/claude-stats/pkg/vcs"
+)
+
+// chargebackCmd 代表chargeback命令
+var chargebackCmd = &cobra.Command{
+	Use:   "chargeback [dir]",
+	Short: "cmd.chargeback.short",
+	Long:  "cmd.chargeback.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runChargeback,
+}
+
+func init() {
+	rootCmd.AddCommand(chargebackCmd)
+
+	// chargeback命令特定的标志位
+	chargebackCmd.Flags().BoolVarP(&showDetails, "details", "d", false, "flag.details")
+
+	// 继承通用标志位
+	chargebackCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "flag.format_chargeback")
+	chargebackCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	chargebackCmd.Flags().StringVar(&startDate, "since", "", "flag.since")
+	chargebackCmd.Flags().StringVar(&endDate, "until", "", "flag.until")
+	chargebackCmd.Flags().StringVar(&projectFilter, "project", "", "flag.project")
+	chargebackCmd.Flags().StringVar(&sidechainMode, "sidechain", "include", "flag.sidechain")
+	chargebackCmd.Flags().StringVar(&redactMode, "redact", "none", "flag.redact")
+	chargebackCmd.Flags().BoolVar(&noColor, "no-color", false, "flag.no_color")
+}
+
+func runChargeback(cmd *cobra.Command, args []string) error {
+	rules, err := loadChargebackRules()
+	if err != nil {
+		return err
+	}
+
+	// 分摊规则按原始路径和 git 远程地址匹配：解析日志时只对调试输出脱敏，报告明细在分摊时脱敏
+	redactor, err := resolveRedactor(redactMode)
+	if err != nil {
+		return err
+	}
+
+	targetDirs := getTargetDirectories(args)
+
+	if verbose {
+		fmt.Println(redactor.Text(i18n.T("common.analyzing_dirs", strings.Join(targetDirs, ", "))))
+	}
+
+	stats, err := parseDirectories(targetDirs, redactor.LogsOnly())
+	if err != nil {
+		return err
+	}
+
+	var remotes map[string]string
+	if rules.NeedsRemote() {
+		remotes = projectRemotes(stats, redactor)
+	}
+
+	claudeParser := parser.NewClaudeParser()
+	claudeParser.Redactor = redactor
+	report := claudeParser.AnalyzeChargeback(stats, rules, remotes)
+
+	// 格式化并输出结果
+	formatter := formatter.NewFormatter()
+	formatter.ShowDetails = showDetails
+	formatter.Verbose = verbose
+
+	// 设置颜色选项
+	if noColor {
+		formatter.Colors.Enabled = false
+	}
+
+	var output string
+
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		output, err = formatter.FormatChargebackJSON(report)
+	case "csv":
+		output, err = formatter.FormatChargebackCSV(report)
+	case "markdown":
+		output, err = formatter.FormatChargebackMarkdown(report)
+	case "table", "":
+		output, err = formatter.FormatChargeback(report)
+	default:
+		return i18n.Errorf("err.unsupported_format", outputFormat)
+	}
+
+	if err != nil {
+		return i18n.Errorf("err.format_failed", err)
+	}
+
+	return writeOutput(output)
+}
+
+// loadChargebackRules 从配置文件读取成本分摊规则
+//
+//	chargeback:
+//	  - name: api
+//	    match: ~/work/api
+//	    center: platform
+//	  - name: shared-sdk
+//	    remote: github.com/acme/sdk
+//	    split:
+//	      - center: platform
+//	        percent: 60
+//	      - center: mobile
+//	        percent: 40
+func loadChargebackRules() (parser.ChargebackRules, error) {
+	var rules parser.ChargebackRules
+	if !viper.IsSet("chargeback") {
+		return rules, nil
+	}
+
+	// 分摊结果用于财务核算，配置错误时直接报错而不是忽略
+	if err := viper.UnmarshalKey("chargeback", &rules); err != nil {
+		return nil, i18n.Errorf("err.chargeback_config", err)
+	}
+	for i := range rules {
+		rules[i].Match = expandHome(rules[i].Match)
+	}
+	if err := rules.Compile(); err != nil {
+		return nil, i18n.Errorf("err.chargeback_config", err)
+	}
+	return rules, nil
+}
+
+// projectRemotes 查询各项目所在Git仓库的 origin 远程地址，不是Git仓库的项目没有远程地址
+func projectRemotes(stats *models.UsageStats, redactor *redact.Redactor) map[string]string {
+	remotes := make(map[string]string)
+	for _, project := range stats.ProjectStats {
+		for _, projectPath := range project.AllPaths() {
+			if _, ok := remotes[projectPath]; ok {
+				continue
+			}
+			remote, err := vcs.RemoteURL(projectPath)
+			if err != nil && verbose {
+				fmt.Println(redactor.Text(i18n.T("chargeback.remote_failed", projectPath, err)))
+			}
+			remotes[projectPath] = remote
+		}
+	}
+	return remotes
+}
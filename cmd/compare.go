@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+	"github.com/zhuiye8/claude-stats/pkg/parser"
+)
+
+// compareCmd 代表compare命令
+var compareCmd = &cobra.Command{
+	Use:   "compare [dir]",
+	Short: "cmd.compare.short",
+	Long:  "cmd.compare.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runCompare,
+}
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+
+	// compare命令特定的标志位
+	compareCmd.Flags().StringVar(&compareA, "a", "", "flag.compare_a")
+	compareCmd.Flags().StringVar(&compareB, "b", "", "flag.compare_b")
+	compareCmd.Flags().BoolVar(&compareWeek, "week", false, "flag.compare_week")
+	compareCmd.Flags().BoolVar(&compareMonth, "month", false, "flag.compare_month")
+	compareCmd.Flags().IntVar(&compareTop, "top", 5, "flag.compare_top")
+
+	// 继承通用标志位
+	compareCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "flag.format_compare")
+	compareCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	compareCmd.Flags().StringVar(&projectFilter, "project", "", "flag.project")
+	compareCmd.Flags().StringVar(&sidechainMode, "sidechain", "include", "flag.sidechain")
+	compareCmd.Flags().StringVar(&redactMode, "redact", "none", "flag.redact")
+	compareCmd.Flags().BoolVar(&noColor, "no-color", false, "flag.no_color")
+}
+
+func runCompare(cmd *cobra.Command, args []string) error {
+	periodA, periodB, err := resolveComparePeriods(time.Now())
+	if err != nil {
+		return err
+	}
+
+	redactor, err := resolveRedactor(redactMode)
+	if err != nil {
+		return err
+	}
+
+	// 确定要分析的目录列表
+	targetDirs := getTargetDirectories(args)
+
+	if verbose {
+		fmt.Println(redactor.Text(i18n.T("common.analyzing_dirs", strings.Join(targetDirs, ", "))))
+	}
+
+	// 用两个日期过滤器分别解析
+	statsA, err := parseDirectoriesWithFilter(targetDirs, periodFilter(periodA), redactor)
+	if err != nil {
+		return err
+	}
+	statsB, err := parseDirectoriesWithFilter(targetDirs, periodFilter(periodB), redactor)
+	if err != nil {
+		return err
+	}
+
+	claudeParser := parser.NewClaudeParser()
+	report := claudeParser.ComparePeriods(statsA, statsB, periodA, periodB)
+
+	// 格式化并输出结果
+	formatter := formatter.NewFormatter()
+	formatter.Verbose = verbose
+	formatter.TopN = compareTop
+
+	// 设置颜色选项
+	if noColor {
+		formatter.Colors.Enabled = false
+	}
+
+	var output string
+
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		output, err = formatter.FormatCompareJSON(report)
+	case "csv":
+		output, err = formatter.FormatCompareCSV(report)
+	case "markdown":
+		output, err = formatter.FormatCompareMarkdown(report)
+	case "table", "":
+		output, err = formatter.FormatCompare(report)
+	default:
+		return i18n.Errorf("err.unsupported_format", outputFormat)
+	}
+
+	if err != nil {
+		return i18n.Errorf("err.format_failed", err)
+	}
+
+	return writeOutput(output)
+}
+
+// resolveComparePeriods 根据 --a/--b 或 --week/--month 确定两个时间段（A为基准，B为对比）
+func resolveComparePeriods(now time.Time) (models.Period, models.Period, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch {
+	case compareA != "" || compareB != "":
+		if compareA == "" || compareB == "" {
+			return models.Period{}, models.Period{}, i18n.Errorf("err.compare_periods")
+		}
+		periodA, err := parseDateRange(compareA)
+		if err != nil {
+			return models.Period{}, models.Period{}, err
+		}
+		periodB, err := parseDateRange(compareB)
+		if err != nil {
+			return models.Period{}, models.Period{}, err
+		}
+		return periodA, periodB, nil
+
+	case compareWeek:
+		// 本周一至今天，对比上周同期
+		offset := (int(today.Weekday()) + 6) % 7
+		startB := today.AddDate(0, 0, -offset)
+		startA := startB.AddDate(0, 0, -7)
+		return newPeriod(startA, startA.AddDate(0, 0, offset)), newPeriod(startB, today), nil
+
+	case compareMonth:
+		// 本月1日至今天，对比上月同期（上月天数不足时截止到月末）
+		startB := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+		startA := startB.AddDate(0, -1, 0)
+		endA := startA.AddDate(0, 0, today.Day()-1)
+		if lastDay := startB.AddDate(0, 0, -1); endA.After(lastDay) {
+			endA = lastDay
+		}
+		return newPeriod(startA, endA), newPeriod(startB, today), nil
+	}
+
+	return models.Period{}, models.Period{}, i18n.Errorf("err.compare_periods")
+}
+
+// parseDateRange 解析 起始:结束 形式的日期范围（包含两端）
+func parseDateRange(value string) (models.Period, error) {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 {
+		return models.Period{}, i18n.Errorf("err.compare_range", value)
+	}
+	start, err := parseDate(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return models.Period{}, i18n.Errorf("err.compare_range", value)
+	}
+	end, err := parseDate(strings.TrimSpace(parts[1]))
+	if err != nil || end.Before(start) {
+		return models.Period{}, i18n.Errorf("err.compare_range", value)
+	}
+	return newPeriod(start, end), nil
+}
+
+// newPeriod 创建按天计算的时间段，结束时间为结束日期的最后一刻
+func newPeriod(startDay, endDay time.Time) models.Period {
+	end := endDay.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	days := int(endDay.Sub(startDay).Hours()/24) + 1
+	return models.Period{
+		StartTime: startDay,
+		EndTime:   end,
+		Duration:  i18n.T("fmt.compare.days", days),
+	}
+}
+
+// periodFilter 把时间段转换为解析器的日期过滤器
+func periodFilter(period models.Period) *parser.DateFilter {
+	start, end := period.StartTime, period.EndTime
+	return &parser.DateFilter{StartDate: &start, EndDate: &end}
+}
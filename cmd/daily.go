@@ -8,68 +8,56 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/zhuiye8/claude-stats/pkg/parser"
+	"github.com/zhuiye8/claude-stats/pkg/redact"
 	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
 	"github.com/zhuiye8/claude-stats/pkg/models"
 )
 
 // dailyCmd 代表daily命令
 var dailyCmd = &cobra.Command{
-	Use:   "daily [目录路径]",
-	Short: "按日分析Claude Code使用情况",
-	Long: `按日期分析Claude Code的使用情况，提供精确的每日Token统计和成本分析。
-
-此命令专门优化了按日分组的数据处理逻辑，确保计算精度和性能。
-相比通用分析，daily命令能够：
-• 精确按日期边界分组数据
-• 优化的日期范围处理
-• 专门的日级成本分配算法
-• 更准确的使用模式分析
-
-支持功能：
-• 按日显示Token使用统计
-• 每日成本分析和趋势
-• 模型使用分解（--breakdown）
-• 时间范围过滤（--since, --until）
-• 多种排序方式（--order）
-
-示例：
-  claude-stats daily                           # 显示所有日期的使用情况
-  claude-stats daily --breakdown               # 显示每日的模型使用分解
-  claude-stats daily --since 20241201         # 显示12月1日以来的使用
-  claude-stats daily --since 20241201 --until 20241231  # 显示12月的使用
-  claude-stats daily --order asc              # 按时间正序排列
-  claude-stats daily --json                   # JSON格式输出
-
-日期格式：
-  支持 YYYYMMDD, YYYY-MM-DD, YYYY/MM/DD 等多种格式`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runDaily,
+	Use:   "daily [dir]",
+	Short: "cmd.daily.short",
+	Long:  "cmd.daily.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runDaily,
 }
 
 func init() {
 	rootCmd.AddCommand(dailyCmd)
 
 	// daily命令特定的标志位
-	dailyCmd.Flags().BoolVar(&dailyBreakdown, "breakdown", false, "显示每日按模型分解的详细统计")
-	dailyCmd.Flags().StringVar(&dailyOrder, "order", "desc", "排序顺序: desc(最新优先) 或 asc(最旧优先)")
+	dailyCmd.Flags().BoolVar(&dailyBreakdown, "breakdown", false, "flag.daily_breakdown")
+	dailyCmd.Flags().StringVar(&dailyOrder, "order", "desc", "flag.daily_order")
+	dailyCmd.Flags().BoolVar(&showCharts, "chart", false, "flag.chart")
+	dailyCmd.Flags().StringVar(&templateFile, "template", "", "flag.template")
+	dailyCmd.Flags().StringVar(&templateString, "template-string", "", "flag.template_string")
 	
 	// 继承通用标志位
-	dailyCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "输出格式 (table, json, csv)")
-	dailyCmd.Flags().StringVarP(&outputFile, "output", "o", "", "输出文件路径")
-	dailyCmd.Flags().StringVar(&startDate, "since", "", "开始日期 (YYYYMMDD)")
-	dailyCmd.Flags().StringVar(&endDate, "until", "", "结束日期 (YYYYMMDD)")
-	dailyCmd.Flags().BoolVar(&noColor, "no-color", false, "禁用颜色输出")
-	dailyCmd.Flags().BoolVarP(&offline, "offline", "O", false, "离线模式")
-	dailyCmd.Flags().StringVar(&costMode, "mode", "auto", "成本计算模式 (auto, calculate, display)")
+	dailyCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "flag.format_daily")
+	dailyCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	dailyCmd.Flags().StringVar(&startDate, "since", "", "flag.since")
+	dailyCmd.Flags().StringVar(&endDate, "until", "", "flag.until")
+	dailyCmd.Flags().BoolVar(&noColor, "no-color", false, "flag.no_color")
+	dailyCmd.Flags().BoolVarP(&offline, "offline", "O", false, "flag.offline")
+	dailyCmd.Flags().StringVar(&costMode, "mode", "auto", "flag.mode")
+	dailyCmd.Flags().StringVar(&projectFilter, "project", "", "flag.project")
+	dailyCmd.Flags().StringVar(&sidechainMode, "sidechain", "include", "flag.sidechain")
+	dailyCmd.Flags().StringVar(&redactMode, "redact", "none", "flag.redact")
 }
 
 // runDaily 执行每日分析
 func runDaily(cmd *cobra.Command, args []string) error {
 	// 确定要分析的目录列表
 	targetDirs := getTargetDirectories(args)
-	
+
+	redactor, err := resolveRedactor(redactMode)
+	if err != nil {
+		return err
+	}
+
 	if verbose {
-		fmt.Printf("📅 开始按日分析: %s\n", strings.Join(targetDirs, ", "))
+		fmt.Println(redactor.Text(i18n.T("daily.start", strings.Join(targetDirs, ", "))))
 	}
 
 	// 创建专门的日分析器
@@ -77,12 +65,24 @@ func runDaily(cmd *cobra.Command, args []string) error {
 	dailyAnalyzer.Verbose = verbose
 	dailyAnalyzer.Order = dailyOrder
 	dailyAnalyzer.CostMode = costMode
+	dailyAnalyzer.Projects = loadProjectRules()
+	dailyAnalyzer.ProjectFilter = expandHome(projectFilter)
+	dailyAnalyzer.User = localUserLabel()
+	dailyAnalyzer.UserFilter = userFilter
+
+	mode, err := parser.ParseSidechainMode(sidechainMode)
+	if err != nil {
+		return err
+	}
+	dailyAnalyzer.SidechainMode = mode
+
+	dailyAnalyzer.Redactor = redactor
 
 	// 设置日期过滤器
 	if startDate != "" || endDate != "" {
 		dateFilter, err := createDateFilter(startDate, endDate)
 		if err != nil {
-			return fmt.Errorf("日期格式错误: %w", err)
+			return i18n.Errorf("err.date_format", err)
 		}
 		dailyAnalyzer.DateFilter = dateFilter
 	}
@@ -90,11 +90,11 @@ func runDaily(cmd *cobra.Command, args []string) error {
 	// 执行日分析
 	dailyReport, err := dailyAnalyzer.AnalyzeDirectories(targetDirs)
 	if err != nil {
-		return fmt.Errorf("日分析失败: %w", err)
+		return i18n.Errorf("err.daily_analyze", err)
 	}
 
 	if verbose {
-		fmt.Printf("✅ 分析完成: 共 %d 天的数据\n", len(dailyReport.DailyData))
+		fmt.Println(i18n.T("daily.done", len(dailyReport.DailyData)))
 	}
 
 	// 输出结果
@@ -107,6 +107,14 @@ type DailyAnalyzer struct {
 	Order      string
 	CostMode   string
 	DateFilter *parser.DateFilter
+
+	Projects      parser.ProjectRules
+	ProjectFilter string
+	SidechainMode string
+
+	User       string
+	UserFilter string
+	Redactor   *redact.Redactor
 }
 
 // NewDailyAnalyzer 创建新的日分析器
@@ -125,11 +133,19 @@ func (da *DailyAnalyzer) AnalyzeDirectories(targetDirs []string) (*models.DailyR
 	claudeParser.Verbose = da.Verbose
 	claudeParser.SkipErrors = true
 	claudeParser.DateFilter = da.DateFilter
+	claudeParser.Projects = da.Projects
+	claudeParser.ProjectFilter = da.ProjectFilter
+	claudeParser.SidechainMode = da.SidechainMode
+	if da.User != "" {
+		claudeParser.User = da.User
+	}
+	claudeParser.UserFilter = da.UserFilter
+	claudeParser.Redactor = da.Redactor
 
 	// 按日聚合的数据结构
 	dailyAggregation := make(map[string]*models.DailyDataPoint)
 	totalSummary := &models.DailyDataPoint{
-		Date:      "总计",
+		Date:      "total",
 		Models:    []string{},
 		Breakdown: make(map[string]models.DailyModelData),
 	}
@@ -138,25 +154,34 @@ func (da *DailyAnalyzer) AnalyzeDirectories(targetDirs []string) (*models.DailyR
 	for _, targetDir := range targetDirs {
 		if _, err := os.Stat(targetDir); os.IsNotExist(err) {
 			if da.Verbose {
-				fmt.Printf("⚠️  目录不存在，跳过: %s\n", targetDir)
+				fmt.Println(da.Redactor.Text(i18n.T("common.dir_missing", targetDir)))
 			}
 			continue
 		}
 		
 		if da.Verbose {
-			fmt.Printf("📂 处理目录: %s\n", targetDir)
+			fmt.Println(da.Redactor.Text(i18n.T("common.processing_dir", targetDir)))
 		}
 		
 		// 解析目录（但专注于日级数据处理）
 		err := da.processDirectoryForDaily(claudeParser, targetDir, dailyAggregation, totalSummary)
 		if err != nil {
 			if da.Verbose {
-				fmt.Printf("⚠️  处理目录失败，跳过 %s: %v\n", targetDir, err)
+				fmt.Println(da.Redactor.Text(i18n.T("common.dir_failed", targetDir, err)))
 			}
 			continue
 		}
 	}
 
+	// 合并归档中已被 Claude Code 清理的历史数据
+	archived, err := replayArchive(claudeParser)
+	if err != nil {
+		return nil, err
+	}
+	if archived != nil {
+		da.processStatsForDaily(archived, dailyAggregation, totalSummary)
+	}
+
 	if len(dailyAggregation) == 0 {
 		return &models.DailyReport{
 			Type:      "daily",
@@ -185,6 +210,14 @@ func (da *DailyAnalyzer) processDirectoryForDaily(parser *parser.ClaudeParser, d
 		return err
 	}
 
+	da.processStatsForDaily(stats, dailyAggregation, totalSummary)
+	return nil
+}
+
+// processStatsForDaily 将一份统计数据（单个目录或归档）累加到日聚合中
+func (da *DailyAnalyzer) processStatsForDaily(stats *models.UsageStats,
+	dailyAggregation map[string]*models.DailyDataPoint, totalSummary *models.DailyDataPoint) {
+
 	// 处理每一天的数据
 	for dateStr, dailyUsage := range stats.DailyStats {
 		dayData, exists := dailyAggregation[dateStr]
@@ -238,12 +271,16 @@ func (da *DailyAnalyzer) processDirectoryForDaily(parser *parser.ClaudeParser, d
 		totalSummary.CacheCreationTokens += dailyUsage.CacheCreationTokens
 		totalSummary.CacheReadTokens += dailyUsage.CacheReadTokens
 		totalSummary.TotalTokens += dailyUsage.GetTotalTokens()
+
+		// 累加子代理用量（成本与当日总成本一起计算）
+		if sidechain, ok := stats.SidechainDaily[dateStr]; ok {
+			addSidechainUsage(&dayData.Sidechain, sidechain)
+			addSidechainUsage(&totalSummary.Sidechain, sidechain)
+		}
 	}
 
 	// 计算成本（使用指定的成本模式）
 	da.calculateDailyCosts(stats, dailyAggregation, totalSummary)
-
-	return nil
 }
 
 // processModelBreakdown 处理模型分解数据
@@ -306,6 +343,7 @@ func (da *DailyAnalyzer) calculateCostsFromTokens(dailyAggregation map[string]*m
 		// 假设订阅模式（大多数用户）
 		costBreakdown := costCalculator.Calculate(&usage, nil, true)
 		dayData.CostUSD = costBreakdown.TotalCost
+		dayData.Sidechain.CostUSD = sidechainCost(costCalculator, dayData.Sidechain)
 		
 		// 如果有breakdown，计算模型级成本
 		if len(dayData.Breakdown) > 0 {
@@ -334,6 +372,32 @@ func (da *DailyAnalyzer) calculateCostsFromTokens(dailyAggregation map[string]*m
 	}
 	costBreakdown := costCalculator.Calculate(&usage, nil, true)
 	totalSummary.CostUSD = costBreakdown.TotalCost
+	totalSummary.Sidechain.CostUSD = sidechainCost(costCalculator, totalSummary.Sidechain)
+}
+
+// addSidechainUsage 累加子代理的Token和消息数
+func addSidechainUsage(target *models.DailyModelData, bucket models.UsageBucket) {
+	target.InputTokens += bucket.Tokens.InputTokens
+	target.OutputTokens += bucket.Tokens.OutputTokens
+	target.CacheCreationTokens += bucket.Tokens.CacheCreationTokens
+	target.CacheReadTokens += bucket.Tokens.CacheReadTokens
+	target.TotalTokens += bucket.Tokens.GetTotalTokens()
+	target.MessageCount += bucket.MessageCount
+}
+
+// sidechainCost 用与每日总成本相同的方式计算子代理成本，保证两者可以直接比较
+func sidechainCost(costCalculator *parser.CostCalculator, data models.DailyModelData) float64 {
+	if data.TotalTokens == 0 {
+		return 0
+	}
+	usage := models.TokenUsage{
+		InputTokens:         data.InputTokens,
+		OutputTokens:        data.OutputTokens,
+		CacheCreationTokens: data.CacheCreationTokens,
+		CacheReadTokens:     data.CacheReadTokens,
+		TotalTokens:         data.TotalTokens,
+	}
+	return costCalculator.Calculate(&usage, nil, true).TotalCost
 }
 
 // usePrecalculatedCosts 使用预计算的成本
@@ -374,7 +438,11 @@ func outputDailyReport(report *models.DailyReport) error {
 	// 格式化并输出结果
 	formatter := formatter.NewFormatter()
 	formatter.ShowDetails = dailyBreakdown
+	formatter.ShowCharts = showCharts
 	formatter.Verbose = verbose
+	if err := applyTemplate(formatter); err != nil {
+		return err
+	}
 	
 	// 设置颜色选项
 	if noColor {
@@ -389,23 +457,29 @@ func outputDailyReport(report *models.DailyReport) error {
 		output, err = formatter.FormatDailyJSON(report)
 	case "csv":
 		output, err = formatter.FormatDailyCSV(report)
+	case "html":
+		output, err = formatter.FormatDailyHTML(report)
+	case "markdown":
+		output, err = formatter.FormatDailyMarkdown(report)
+	case "template":
+		output, err = formatter.FormatTemplate(report)
 	case "table", "":
 		output, err = formatter.FormatDaily(report)
 	default:
-		return fmt.Errorf("不支持的格式: %s", outputFormat)
+		return i18n.Errorf("err.unsupported_format", outputFormat)
 	}
 	
 	if err != nil {
-		return fmt.Errorf("格式化失败: %w", err)
+		return i18n.Errorf("err.format_failed", err)
 	}
 
 	// 输出结果
 	if outputFile != "" {
 		err = writeToFile(output, outputFile)
 		if err != nil {
-			return fmt.Errorf("写入文件失败: %w", err)
+			return i18n.Errorf("err.write_failed", err)
 		}
-		fmt.Printf("✅ 报告已保存到: %s\n", outputFile)
+		fmt.Println(i18n.T("common.saved", outputFile))
 	} else {
 		fmt.Print(output)
 	}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zhuiye8/claude-stats/pkg/archive"
+	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+	"github.com/zhuiye8/claude-stats/pkg/parser"
+	"github.com/zhuiye8/claude-stats/pkg/redact"
+)
+
+// exportCmd 代表export命令
+var exportCmd = &cobra.Command{
+	Use:   "export [dir]",
+	Short: "cmd.export.short",
+	Long:  "cmd.export.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runExport,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	// export命令特定的标志位
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "ndjson", "flag.export_format")
+	exportCmd.Flags().StringVar(&exportRollup, "rollup", "", "flag.export_rollup")
+	exportCmd.Flags().BoolVar(&exportSnapshot, "write-snapshot", false, "flag.export_snapshot")
+	exportCmd.Flags().StringVar(&exportLabel, "label", "", "flag.export_label")
+
+	// 继承通用标志位
+	exportCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	exportCmd.Flags().StringVar(&startDate, "since", "", "flag.since")
+	exportCmd.Flags().StringVar(&endDate, "until", "", "flag.until")
+	exportCmd.Flags().StringVar(&projectFilter, "project", "", "flag.project")
+	exportCmd.Flags().StringVar(&sidechainMode, "sidechain", "include", "flag.sidechain")
+	exportCmd.Flags().StringVar(&redactMode, "redact", "none", "flag.redact")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	format := strings.ToLower(exportFormat)
+	if format != "ndjson" && format != "parquet" {
+		return i18n.Errorf("err.unsupported_format", exportFormat)
+	}
+	rollup := strings.ToLower(exportRollup)
+	if rollup != "" && rollup != "daily" {
+		return i18n.Errorf("err.unsupported_rollup", exportRollup)
+	}
+	if exportSnapshot && (cmd.Flags().Changed("format") || rollup != "") {
+		return i18n.Errorf("err.snapshot_flags")
+	}
+
+	redactor, err := resolveRedactor(redactMode)
+	if err != nil {
+		return err
+	}
+	claudeParser, err := newStreamParser(redactor)
+	if err != nil {
+		return err
+	}
+
+	// 输出目标：文件或标准输出，统一经过缓冲写入
+	var out io.Writer = os.Stdout
+	if outputFile != "" {
+		file, err := os.Create(outputFile)
+		if err != nil {
+			return i18n.Errorf("err.write_failed", err)
+		}
+		defer file.Close()
+		out = file
+	}
+	writer := bufio.NewWriter(out)
+
+	if exportSnapshot {
+		err = writeSnapshot(writer, claudeParser, args)
+	} else if rollup == "daily" {
+		// 每日汇总只保留分组累加值，全部记录读完后一次写出
+		daily := parser.NewDailyRollup()
+		if err := streamRecords(claudeParser, args, daily.Add); err != nil {
+			return err
+		}
+		err = writeRollups(writer, format, daily.Rows())
+	} else {
+		err = writeRecords(writer, format, func(emit func(models.UsageRecord) error) error {
+			return streamRecords(claudeParser, args, emit)
+		})
+	}
+	if err != nil {
+		return i18n.Errorf("err.write_failed", err)
+	}
+
+	if err := writer.Flush(); err != nil {
+		return i18n.Errorf("err.write_failed", err)
+	}
+	if outputFile != "" {
+		fmt.Fprintln(os.Stderr, i18n.T("common.saved", outputFile))
+	}
+	return nil
+}
+
+// streamRecords 依次流式读取所有目标目录和 --snapshot 快照，对每条记录调用 emit
+func streamRecords(claudeParser *parser.ClaudeParser, args []string, emit func(models.UsageRecord) error) error {
+	for _, targetDir := range getTargetDirectories(args) {
+		if _, err := os.Stat(targetDir); os.IsNotExist(err) {
+			continue // 跳过不存在的目录
+		}
+		if verbose {
+			fmt.Fprintln(os.Stderr, claudeParser.Redactor.Text(i18n.T("common.analyzing_dirs", targetDir)))
+		}
+		if err := claudeParser.StreamDirectory(targetDir, emit); err != nil {
+			return err
+		}
+	}
+
+	// 合并 --snapshot 指定的团队快照，与实时日志重复的记录只输出一次
+	for _, path := range snapshotFiles {
+		snapshot, err := archive.ReadSnapshot(expandHome(path))
+		if err != nil {
+			return i18n.Errorf("err.snapshot_read", path, err)
+		}
+		merged, err := claudeParser.StreamReplay(func(each func(models.UsageRecord) error) error {
+			for _, record := range snapshot.Entries {
+				if err := each(record); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, emit)
+		if err != nil {
+			return err
+		}
+		if verbose {
+			fmt.Fprintln(os.Stderr, claudeParser.Redactor.Text(i18n.T("snapshot.merged", merged, path)))
+		}
+	}
+	return nil
+}
+
+// writeSnapshot 收集全部明细记录，写出带用户标签和主机名的团队快照
+func writeSnapshot(out io.Writer, claudeParser *parser.ClaudeParser, args []string) error {
+	records := []models.UsageRecord{}
+	err := streamRecords(claudeParser, args, func(record models.UsageRecord) error {
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	label := exportLabel
+	if label == "" {
+		label = claudeParser.User
+	}
+	host := claudeParser.Redactor.Host(claudeParser.Host)
+	return archive.WriteSnapshot(out, archive.NewSnapshot(label, host, records))
+}
+
+// writeRecords 以指定格式逐条写出明细记录
+func writeRecords(out io.Writer, format string, stream func(emit func(models.UsageRecord) error) error) error {
+	if format == "parquet" {
+		parquetWriter := formatter.NewParquetUsageWriter(out)
+		if err := stream(parquetWriter.Write); err != nil {
+			return err
+		}
+		return parquetWriter.Close()
+	}
+
+	encoder := json.NewEncoder(out)
+	return stream(func(record models.UsageRecord) error {
+		return encoder.Encode(models.NewExportRecord(record))
+	})
+}
+
+// writeRollups 以指定格式写出每日汇总
+func writeRollups(out io.Writer, format string, rollups []models.UsageRollup) error {
+	if format == "parquet" {
+		return formatter.WriteParquetRollups(out, rollups)
+	}
+
+	encoder := json.NewEncoder(out)
+	for _, rollup := range rollups {
+		if err := encoder.Encode(models.NewExportRollup(rollup)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newStreamParser 创建用于逐条导出的解析器，应用日期、项目和子代理过滤条件
+func newStreamParser(redactor *redact.Redactor) (*parser.ClaudeParser, error) {
+	claudeParser := parser.NewClaudeParser()
+	claudeParser.Verbose = verbose
+	claudeParser.SkipErrors = true
+
+	if startDate != "" || endDate != "" {
+		filter, err := createDateFilter(startDate, endDate)
+		if err != nil {
+			return nil, i18n.Errorf("err.date_format", err)
+		}
+		claudeParser.DateFilter = filter
+	}
+
+	if err := applyProjectOptions(claudeParser, redactor); err != nil {
+		return nil, err
+	}
+	return claudeParser, nil
+}
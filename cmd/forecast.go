@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/parser"
+)
+
+// forecastCmd 代表forecast命令
+var forecastCmd = &cobra.Command{
+	Use:   "forecast [dir]",
+	Short: "cmd.forecast.short",
+	Long:  "cmd.forecast.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runForecast,
+}
+
+func init() {
+	rootCmd.AddCommand(forecastCmd)
+
+	// forecast命令特定的标志位
+	forecastCmd.Flags().IntVar(&forecastHistory, "history", 56, "flag.forecast_history")
+	forecastCmd.Flags().BoolVar(&forecastQuarter, "quarter", false, "flag.forecast_quarter")
+	forecastCmd.Flags().Float64Var(&forecastBudget, "budget", 0, "flag.forecast_budget")
+	forecastCmd.Flags().Float64Var(&forecastQuarterBudget, "quarter-budget", 0, "flag.forecast_quarter_budget")
+
+	// 继承通用标志位
+	forecastCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "flag.format_forecast")
+	forecastCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	forecastCmd.Flags().StringVar(&projectFilter, "project", "", "flag.project")
+	forecastCmd.Flags().StringVar(&sidechainMode, "sidechain", "include", "flag.sidechain")
+	forecastCmd.Flags().StringVar(&redactMode, "redact", "none", "flag.redact")
+	forecastCmd.Flags().BoolVar(&noColor, "no-color", false, "flag.no_color")
+}
+
+func runForecast(cmd *cobra.Command, args []string) error {
+	if forecastHistory < 1 {
+		return i18n.Errorf("err.forecast_history", forecastHistory)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	// 预算：命令行优先，其次为配置文件中的 budgets.monthly / budgets.quarterly
+	options := parser.ForecastOptions{
+		Today:           today,
+		HistoryDays:     forecastHistory,
+		Quarter:         forecastQuarter || forecastQuarterBudget > 0,
+		MonthlyBudget:   viper.GetFloat64("budgets.monthly"),
+		QuarterlyBudget: viper.GetFloat64("budgets.quarterly"),
+	}
+	if cmd.Flags().Changed("budget") {
+		options.MonthlyBudget = forecastBudget
+	}
+	if cmd.Flags().Changed("quarter-budget") {
+		options.QuarterlyBudget = forecastQuarterBudget
+	}
+
+	// 只需解析历史窗口和目标周期开始以来的数据
+	since := today.AddDate(0, 0, -forecastHistory)
+	periodStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if options.Quarter {
+		periodStart = time.Date(today.Year(), time.Month((int(today.Month())-1)/3*3+1), 1, 0, 0, 0, 0, time.UTC)
+	}
+	if periodStart.Before(since) {
+		since = periodStart
+	}
+
+	redactor, err := resolveRedactor(redactMode)
+	if err != nil {
+		return err
+	}
+
+	// 确定要分析的目录列表
+	targetDirs := getTargetDirectories(args)
+
+	if verbose {
+		fmt.Println(redactor.Text(i18n.T("common.analyzing_dirs", strings.Join(targetDirs, ", "))))
+	}
+
+	// 逐条记录按各自的模型计价，与 compare、projects、chargeback 的成本一致
+	stats, err := parseDirectoriesWithFilter(targetDirs, &parser.DateFilter{StartDate: &since}, redactor)
+	if err != nil {
+		return err
+	}
+
+	claudeParser := parser.NewClaudeParser()
+	report := claudeParser.AnalyzeForecast(stats, options)
+
+	// 格式化并输出结果
+	formatter := formatter.NewFormatter()
+	formatter.Verbose = verbose
+
+	// 设置颜色选项
+	if noColor {
+		formatter.Colors.Enabled = false
+	}
+
+	var output string
+
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		output, err = formatter.FormatForecastJSON(report)
+	case "markdown":
+		output, err = formatter.FormatForecastMarkdown(report)
+	case "table", "":
+		output, err = formatter.FormatForecast(report)
+	default:
+		return i18n.Errorf("err.unsupported_format", outputFormat)
+	}
+
+	if err != nil {
+		return i18n.Errorf("err.format_failed", err)
+	}
+
+	return writeOutput(output)
+}
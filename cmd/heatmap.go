@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/parser"
+)
+
+// heatmapCmd 代表heatmap命令
+var heatmapCmd = &cobra.Command{
+	Use:   "heatmap [dir]",
+	Short: "cmd.heatmap.short",
+	Long:  "cmd.heatmap.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runHeatmap,
+}
+
+func init() {
+	rootCmd.AddCommand(heatmapCmd)
+
+	// heatmap命令特定的标志位
+	heatmapCmd.Flags().StringVar(&heatmapMetric, "metric", "tokens", "flag.heatmap_metric")
+
+	// 继承通用标志位
+	heatmapCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "flag.format_heatmap")
+	heatmapCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	heatmapCmd.Flags().StringVar(&startDate, "since", "", "flag.since")
+	heatmapCmd.Flags().StringVar(&endDate, "until", "", "flag.until")
+	heatmapCmd.Flags().StringVar(&projectFilter, "project", "", "flag.project")
+	heatmapCmd.Flags().StringVar(&sidechainMode, "sidechain", "include", "flag.sidechain")
+	heatmapCmd.Flags().StringVar(&redactMode, "redact", "none", "flag.redact")
+	heatmapCmd.Flags().BoolVar(&noColor, "no-color", false, "flag.no_color")
+}
+
+func runHeatmap(cmd *cobra.Command, args []string) error {
+	redactor, err := resolveRedactor(redactMode)
+	if err != nil {
+		return err
+	}
+
+	// 确定要分析的目录列表
+	targetDirs := getTargetDirectories(args)
+
+	if verbose {
+		fmt.Println(redactor.Text(i18n.T("common.analyzing_dirs", strings.Join(targetDirs, ", "))))
+	}
+
+	stats, err := parseDirectories(targetDirs, redactor)
+	if err != nil {
+		return err
+	}
+
+	claudeParser := parser.NewClaudeParser()
+	report, err := claudeParser.AnalyzeHeatmap(stats, heatmapMetric, time.Local)
+	if err != nil {
+		return err
+	}
+
+	// 格式化并输出结果
+	formatter := formatter.NewFormatter()
+	formatter.Verbose = verbose
+
+	// 设置颜色选项
+	if noColor {
+		formatter.Colors.Enabled = false
+	}
+
+	var output string
+
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		output, err = formatter.FormatHeatmapJSON(report)
+	case "csv":
+		output, err = formatter.FormatHeatmapCSV(report)
+	case "markdown":
+		output, err = formatter.FormatHeatmapMarkdown(report)
+	case "table", "":
+		output, err = formatter.FormatHeatmap(report)
+	default:
+		return i18n.Errorf("err.unsupported_format", outputFormat)
+	}
+
+	if err != nil {
+		return i18n.Errorf("err.format_failed", err)
+	}
+
+	return writeOutput(output)
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/zhuiye8/claude-stats/pkg/archive"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+)
+
+// importCmd 代表import命令
+var importCmd = &cobra.Command{
+	Use:   "import <snapshot>...",
+	Short: "cmd.import.short",
+	Long:  "cmd.import.long",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runImport,
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	arc := archive.New(resolveArchiveDir())
+
+	for _, path := range args {
+		snapshot, err := archive.ReadSnapshot(expandHome(path))
+		if err != nil {
+			return i18n.Errorf("err.snapshot_read", path, err)
+		}
+
+		// 每个用户的数据单独存放，按 requestId 去重，重复导入同一快照不会重复计数
+		userArchive := arc.Imports(snapshot.User)
+		added, err := userArchive.Add(snapshot.Entries)
+		if err != nil {
+			return i18n.Errorf("err.archive_failed", err)
+		}
+		fmt.Println(i18n.T("import.done", path, snapshot.User, snapshot.Hostname, added, len(snapshot.Entries)-added))
+	}
+
+	fmt.Println(i18n.T("import.location", arc.Dir))
+	return nil
+}
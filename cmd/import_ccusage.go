@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zhuiye8/claude-stats/pkg/archive"
+	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+	"github.com/zhuiye8/claude-stats/pkg/parser"
+)
+
+// ccusageMismatchExitCode --verify 发现不一致时的退出码（1 保留给运行错误）
+const ccusageMismatchExitCode = 2
+
+// importCCUsageCmd 代表import-ccusage命令
+var importCCUsageCmd = &cobra.Command{
+	Use:   "import-ccusage <file>...",
+	Short: "cmd.import_ccusage.short",
+	Long:  "cmd.import_ccusage.long",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runImportCCUsage,
+}
+
+func init() {
+	rootCmd.AddCommand(importCCUsageCmd)
+
+	// import-ccusage命令特定的标志位
+	importCCUsageCmd.Flags().BoolVar(&ccusageVerify, "verify", false, "flag.ccusage_verify")
+	importCCUsageCmd.Flags().StringVar(&ccusageLabel, "label", "", "flag.ccusage_label")
+	importCCUsageCmd.Flags().Float64Var(&ccusageTolerance, "tolerance", 1, "flag.ccusage_tolerance")
+	importCCUsageCmd.Flags().StringVar(&ccusageTimezone, "timezone", "", "flag.ccusage_timezone")
+
+	// 继承通用标志位
+	importCCUsageCmd.Flags().StringSliceVar(&configDirs, "config-dirs", []string{}, "flag.config_dirs")
+	importCCUsageCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "flag.format_import_ccusage")
+	importCCUsageCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	importCCUsageCmd.Flags().BoolVar(&noColor, "no-color", false, "flag.no_color")
+}
+
+func runImportCCUsage(cmd *cobra.Command, args []string) error {
+	location, err := ccusageLocation()
+	if err != nil {
+		return err
+	}
+
+	if ccusageVerify {
+		if len(args) != 1 {
+			return i18n.Errorf("err.ccusage_verify_args")
+		}
+		return verifyCCUsage(cmd, args[0], location)
+	}
+
+	arc := archive.New(resolveArchiveDir())
+	target, user := arc, ""
+	if ccusageLabel != "" {
+		target, user = arc.Imports(ccusageLabel), ccusageLabel
+	}
+
+	// 已有数据：导入到本机归档时包括实时日志和归档，导入为其他成员时只看该成员已导入的数据
+	coverage := &parser.CCUsageCoverage{}
+	if ccusageLabel == "" {
+		if err := streamRecords(newCCUsageParser(), nil, coverage.Add); err != nil {
+			return err
+		}
+	}
+	if err := target.ForEach(coverage.Add); err != nil {
+		return i18n.Errorf("err.archive_read", target.Dir, err)
+	}
+
+	for _, path := range args {
+		report, err := parser.ReadCCUsage(expandHome(path), location)
+		if err != nil {
+			return i18n.Errorf("err.ccusage_read", path, err)
+		}
+
+		uncovered := &parser.CCUsageReport{Kind: report.Kind, Location: report.Location}
+		for _, row := range report.Rows {
+			if !coverage.Covers(row) {
+				uncovered.Rows = append(uncovered.Rows, row)
+			}
+		}
+
+		records := uncovered.Records(user)
+		added, err := target.Add(records)
+		if err != nil {
+			return i18n.Errorf("err.archive_failed", err)
+		}
+		// 同一批导入的多个文件之间也不能重叠
+		for _, record := range records {
+			coverage.Add(record)
+		}
+		fmt.Println(i18n.T("ccusage.imported", path, report.Kind, len(uncovered.Rows), added, len(report.Rows)-len(uncovered.Rows)))
+	}
+
+	if ccusageLabel != "" {
+		fmt.Println(i18n.T("import.location", arc.Dir))
+	} else {
+		fmt.Println(i18n.T("ccusage.location", arc.Dir))
+	}
+	return nil
+}
+
+// verifyCCUsage 对比 ccusage 报告与实时日志（及归档）中同一周期的用量
+func verifyCCUsage(cmd *cobra.Command, path string, location *time.Location) error {
+	report, err := parser.ReadCCUsage(expandHome(path), location)
+	if err != nil {
+		return i18n.Errorf("err.ccusage_read", path, err)
+	}
+
+	claudeParser := newCCUsageParser()
+	result, err := claudeParser.VerifyCCUsage(report, func(emit func(models.UsageRecord) error) error {
+		// 归档中已不在实时日志里的记录同样参与对比，按 requestId 去重
+		live := make(map[string]bool)
+		err := streamRecords(claudeParser, nil, func(record models.UsageRecord) error {
+			live[record.Key()] = true
+			return emit(record)
+		})
+		if err != nil || noArchive {
+			return err
+		}
+		return archive.New(resolveArchiveDir()).ForEach(func(record models.UsageRecord) error {
+			if live[record.Key()] {
+				return nil
+			}
+			return emit(record)
+		})
+	}, ccusageTolerance)
+	if err != nil {
+		return err
+	}
+	result.Source = path
+
+	formatter := formatter.NewFormatter()
+	formatter.Verbose = verbose
+	if noColor {
+		formatter.Colors.Enabled = false
+	}
+
+	var output string
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		output, err = formatter.FormatCCUsageVerifyJSON(result)
+	case "csv":
+		output, err = formatter.FormatCCUsageVerifyCSV(result)
+	case "markdown":
+		output, err = formatter.FormatCCUsageVerifyMarkdown(result)
+	case "table", "":
+		output, err = formatter.FormatCCUsageVerify(result)
+	default:
+		return i18n.Errorf("err.unsupported_format", outputFormat)
+	}
+	if err != nil {
+		return i18n.Errorf("err.format_failed", err)
+	}
+	if err := writeOutput(output); err != nil {
+		return err
+	}
+
+	// 存在不一致的周期时以非零退出码结束，便于作为回归检查
+	if result.Mismatches > 0 {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return &ExitError{Code: ccusageMismatchExitCode}
+	}
+	return nil
+}
+
+// newCCUsageParser 创建读取实时日志的解析器；与 ccusage 对比时不应用任何过滤条件
+func newCCUsageParser() *parser.ClaudeParser {
+	claudeParser := parser.NewClaudeParser()
+	claudeParser.Verbose = verbose
+	claudeParser.SkipErrors = true
+	return claudeParser
+}
+
+// ccusageLocation 解析 --timezone：为空时与 ccusage 的默认行为一致，使用本地时区
+func ccusageLocation() (*time.Location, error) {
+	if ccusageTimezone == "" {
+		return time.Local, nil
+	}
+	location, err := time.LoadLocation(ccusageTimezone)
+	if err != nil {
+		return nil, i18n.Errorf("err.unknown_timezone", ccusageTimezone, err)
+	}
+	return location, nil
+}
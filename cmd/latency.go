@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+	"github.com/zhuiye8/claude-stats/pkg/parser"
+)
+
+// latencyCmd 代表latency命令
+var latencyCmd = &cobra.Command{
+	Use:   "latency [dir]",
+	Short: "cmd.latency.short",
+	Long:  "cmd.latency.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runLatency,
+}
+
+func init() {
+	rootCmd.AddCommand(latencyCmd)
+
+	// latency命令特定的标志位
+	latencyCmd.Flags().StringVar(&latencyBy, "by", "model", "flag.latency_by")
+	latencyCmd.Flags().StringVar(&latencySort, "sort", "name", "flag.latency_sort")
+	latencyCmd.Flags().StringVar(&order, "order", "desc", "flag.order")
+
+	// 继承通用标志位
+	latencyCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "flag.format_latency")
+	latencyCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	latencyCmd.Flags().StringVar(&startDate, "since", "", "flag.since")
+	latencyCmd.Flags().StringVar(&endDate, "until", "", "flag.until")
+	latencyCmd.Flags().StringVar(&projectFilter, "project", "", "flag.project")
+	latencyCmd.Flags().StringVar(&sidechainMode, "sidechain", "include", "flag.sidechain")
+	latencyCmd.Flags().StringVar(&redactMode, "redact", "none", "flag.redact")
+	latencyCmd.Flags().BoolVar(&noColor, "no-color", false, "flag.no_color")
+}
+
+func runLatency(cmd *cobra.Command, args []string) error {
+	redactor, err := resolveRedactor(redactMode)
+	if err != nil {
+		return err
+	}
+
+	// 确定要分析的目录列表
+	targetDirs := getTargetDirectories(args)
+
+	if verbose {
+		fmt.Println(redactor.Text(i18n.T("common.analyzing_dirs", strings.Join(targetDirs, ", "))))
+	}
+
+	stats, err := parseDirectories(targetDirs, redactor)
+	if err != nil {
+		return err
+	}
+
+	claudeParser := parser.NewClaudeParser()
+	report, err := claudeParser.AnalyzeLatency(stats, latencyBy)
+	if err != nil {
+		return err
+	}
+
+	if err := sortLatency(report.Items, latencySort, order); err != nil {
+		return err
+	}
+
+	// 格式化并输出结果
+	formatter := formatter.NewFormatter()
+	formatter.Verbose = verbose
+
+	// 设置颜色选项
+	if noColor {
+		formatter.Colors.Enabled = false
+	}
+
+	var output string
+
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		output, err = formatter.FormatLatencyJSON(report)
+	case "csv":
+		output, err = formatter.FormatLatencyCSV(report)
+	case "markdown":
+		output, err = formatter.FormatLatencyMarkdown(report)
+	case "table", "":
+		output, err = formatter.FormatLatency(report)
+	default:
+		return i18n.Errorf("err.unsupported_format", outputFormat)
+	}
+
+	if err != nil {
+		return i18n.Errorf("err.format_failed", err)
+	}
+
+	return writeOutput(output)
+}
+
+// sortLatency 按指定字段排序时延统计
+func sortLatency(items []models.LatencyStats, field, direction string) error {
+	var less func(a, b models.LatencyStats) bool
+
+	switch strings.ToLower(field) {
+	case "name", "":
+		// 版本号按数值段比较，避免 1.0.100 排在 1.0.99 之前
+		less = func(a, b models.LatencyStats) bool { return compareVersions(a.Name, b.Name) < 0 }
+	case "p50":
+		less = func(a, b models.LatencyStats) bool { return a.Response.P50 < b.Response.P50 }
+	case "p90":
+		less = func(a, b models.LatencyStats) bool { return a.Response.P90 < b.Response.P90 }
+	case "p99":
+		less = func(a, b models.LatencyStats) bool { return a.Response.P99 < b.Response.P99 }
+	case "turns":
+		less = func(a, b models.LatencyStats) bool { return a.Response.Count < b.Response.Count }
+	default:
+		return i18n.Errorf("err.unsupported_sort", field)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if direction == "asc" {
+			return less(items[i], items[j])
+		}
+		return less(items[j], items[i])
+	})
+	return nil
+}
+
+// compareVersions 按点分隔的数值段比较版本号，非数字部分按字符串比较
+func compareVersions(a, b string) int {
+	partsA := strings.Split(a, ".")
+	partsB := strings.Split(b, ".")
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		var numA, numB int
+		_, errA := fmt.Sscanf(partsA[i], "%d", &numA)
+		_, errB := fmt.Sscanf(partsB[i], "%d", &numB)
+		if errA == nil && errB == nil && numA != numB {
+			if numA < numB {
+				return -1
+			}
+			return 1
+		}
+		if errA != nil || errB != nil {
+			if c := strings.Compare(partsA[i], partsB[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return len(partsA) - len(partsB)
+}
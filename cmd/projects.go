@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+	"github.com/zhuiye8/claude-stats/pkg/parser"
+	"github.com/zhuiye8/claude-stats/pkg/redact"
+)
+
+// projectsCmd 代表projects命令
+var projectsCmd = &cobra.Command{
+	Use:   "projects [dir]",
+	Short: "cmd.projects.short",
+	Long:  "cmd.projects.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runProjects,
+}
+
+func init() {
+	rootCmd.AddCommand(projectsCmd)
+
+	// projects命令特定的标志位
+	projectsCmd.Flags().BoolVar(&projectsGroup, "group", false, "flag.projects_group")
+	projectsCmd.Flags().StringVar(&projectsSort, "sort", "cost", "flag.projects_sort")
+	projectsCmd.Flags().StringVar(&order, "order", "desc", "flag.order")
+	projectsCmd.Flags().BoolVarP(&showDetails, "details", "d", false, "flag.details")
+
+	// 继承通用标志位
+	projectsCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "flag.format_projects")
+	projectsCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	projectsCmd.Flags().StringVar(&startDate, "since", "", "flag.since")
+	projectsCmd.Flags().StringVar(&endDate, "until", "", "flag.until")
+	projectsCmd.Flags().StringVar(&projectFilter, "project", "", "flag.project")
+	projectsCmd.Flags().StringVar(&sidechainMode, "sidechain", "include", "flag.sidechain")
+	projectsCmd.Flags().StringVar(&redactMode, "redact", "none", "flag.redact")
+	projectsCmd.Flags().BoolVar(&noColor, "no-color", false, "flag.no_color")
+}
+
+func runProjects(cmd *cobra.Command, args []string) error {
+	redactor, err := resolveRedactor(redactMode)
+	if err != nil {
+		return err
+	}
+
+	// 确定要分析的目录列表
+	targetDirs := getTargetDirectories(args)
+
+	if verbose {
+		fmt.Println(redactor.Text(i18n.T("common.analyzing_dirs", strings.Join(targetDirs, ", "))))
+	}
+
+	stats, err := parseDirectories(targetDirs, redactor)
+	if err != nil {
+		return err
+	}
+
+	claudeParser := parser.NewClaudeParser()
+	report := claudeParser.AnalyzeProjects(stats, projectsGroup)
+
+	if err := sortProjects(report.Projects, projectsSort, order); err != nil {
+		return err
+	}
+
+	// 格式化并输出结果
+	formatter := formatter.NewFormatter()
+	formatter.ShowDetails = showDetails
+	formatter.Verbose = verbose
+
+	// 设置颜色选项
+	if noColor {
+		formatter.Colors.Enabled = false
+	}
+
+	var output string
+
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		output, err = formatter.FormatProjectsJSON(report)
+	case "csv":
+		output, err = formatter.FormatProjectsCSV(report)
+	case "html":
+		output, err = formatter.FormatProjectsHTML(report)
+	case "markdown":
+		output, err = formatter.FormatProjectsMarkdown(report)
+	case "table", "":
+		output, err = formatter.FormatProjects(report)
+	default:
+		return i18n.Errorf("err.unsupported_format", outputFormat)
+	}
+
+	if err != nil {
+		return i18n.Errorf("err.format_failed", err)
+	}
+
+	return writeOutput(output)
+}
+
+// sortProjects 按指定字段排序项目
+func sortProjects(projects []models.ProjectStats, field, direction string) error {
+	var less func(a, b models.ProjectStats) bool
+
+	switch strings.ToLower(field) {
+	case "cost", "":
+		less = func(a, b models.ProjectStats) bool { return a.Cost < b.Cost }
+	case "tokens":
+		less = func(a, b models.ProjectStats) bool { return a.Tokens.GetTotalTokens() < b.Tokens.GetTotalTokens() }
+	case "messages":
+		less = func(a, b models.ProjectStats) bool { return a.MessageCount < b.MessageCount }
+	case "sessions":
+		less = func(a, b models.ProjectStats) bool { return a.SessionCount < b.SessionCount }
+	case "last":
+		less = func(a, b models.ProjectStats) bool { return a.LastActivity.Before(b.LastActivity) }
+	case "name":
+		less = func(a, b models.ProjectStats) bool { return a.ProjectName < b.ProjectName }
+	default:
+		return i18n.Errorf("err.unsupported_sort", field)
+	}
+
+	sort.SliceStable(projects, func(i, j int) bool {
+		if direction == "asc" {
+			return less(projects[i], projects[j])
+		}
+		return less(projects[j], projects[i])
+	})
+	return nil
+}
+
+// loadProjectRules 从配置文件读取项目别名/分组规则
+//
+//	projects:
+//	  - match: ~/work/api
+//	    alias: work-api
+//	  - match: ~/oss/*
+//	    group: oss
+func loadProjectRules() parser.ProjectRules {
+	var rules parser.ProjectRules
+	if !viper.IsSet("projects") {
+		return rules
+	}
+
+	if err := viper.UnmarshalKey("projects", &rules); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("root.invalid_projects", err))
+		return nil
+	}
+
+	for i := range rules {
+		rules[i].Match = expandHome(rules[i].Match)
+	}
+	return rules
+}
+
+// applyProjectOptions 将项目规则、用户标签、--project、--user、--sidechain 过滤条件和脱敏器应用到解析器
+func applyProjectOptions(claudeParser *parser.ClaudeParser, redactor *redact.Redactor) error {
+	claudeParser.Projects = loadProjectRules()
+	claudeParser.ProjectFilter = expandHome(projectFilter)
+	claudeParser.User = localUserLabel()
+	claudeParser.UserFilter = userFilter
+
+	mode, err := parser.ParseSidechainMode(sidechainMode)
+	if err != nil {
+		return err
+	}
+	claudeParser.SidechainMode = mode
+	claudeParser.Redactor = redactor
+	return nil
+}
+
+// redactors 按脱敏方式缓存的脱敏器，同一次运行中多次解析（如 compare 的两个时间段）得到一致的哈希
+var redactors = make(map[string]*redact.Redactor)
+
+// resolveRedactor 返回指定脱敏方式的脱敏器
+func resolveRedactor(mode string) (*redact.Redactor, error) {
+	if redactor, ok := redactors[mode]; ok {
+		return redactor, nil
+	}
+	redactor, err := redact.New(mode)
+	if err != nil {
+		return nil, err
+	}
+	redactors[mode] = redactor
+	return redactor, nil
+}
+
+// expandHome 展开路径开头的 ~ 符号
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			return homeDir + path[1:]
+		}
+	}
+	return path
+}
+
+// writeOutput 输出报告到文件或标准输出
+func writeOutput(output string) error {
+	if outputFile != "" {
+		if err := writeToFile(output, outputFile); err != nil {
+			return i18n.Errorf("err.write_failed", err)
+		}
+		fmt.Println(i18n.T("common.saved", outputFile))
+		return nil
+	}
+
+	fmt.Print(output)
+	return nil
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/store"
+)
+
+// queryCmd 代表query命令
+var queryCmd = &cobra.Command{
+	Use:   "query <sql>",
+	Short: "cmd.query.short",
+	Long:  "cmd.query.long",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runQuery,
+}
+
+func init() {
+	rootCmd.AddCommand(queryCmd)
+
+	// query命令特定的标志位
+	queryCmd.Flags().StringVar(&dbPath, "db", "", "flag.db")
+
+	// 继承通用标志位
+	queryCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "flag.format_query")
+	queryCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	queryCmd.Flags().BoolVar(&noColor, "no-color", false, "flag.no_color")
+}
+
+func runQuery(cmd *cobra.Command, args []string) error {
+	db, err := store.OpenReadOnly(resolveDBPath())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	result, err := db.Query(args[0])
+	if err != nil {
+		return i18n.Errorf("err.query_failed", err)
+	}
+
+	// 格式化并输出结果
+	formatter := formatter.NewFormatter()
+	formatter.Verbose = verbose
+
+	// 设置颜色选项
+	if noColor {
+		formatter.Colors.Enabled = false
+	}
+
+	var output string
+
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		output, err = formatter.FormatQueryJSON(result)
+	case "csv":
+		output, err = formatter.FormatQueryCSV(result)
+	case "markdown":
+		output, err = formatter.FormatQueryMarkdown(result)
+	case "table", "":
+		output, err = formatter.FormatQuery(result)
+	default:
+		return i18n.Errorf("err.unsupported_format", outputFormat)
+	}
+
+	if err != nil {
+		return i18n.Errorf("err.format_failed", err)
+	}
+
+	return writeOutput(output)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testLogLine 一条带用量的助手消息，工作目录位于 home 下
+const testLogLine = `{"type": "assistant", "sessionId": "s1", "cwd": "%HOME%/work/api", "gitBranch": "main", "timestamp": "2026-10-18T09:00:00Z", "uuid": "a1", "requestId": "req-1", "message": {"id": "msg-1", "role": "assistant", "model": "claude-sonnet-4-20250514", "content": [{"type": "text", "text": "ok"}], "usage": {"input_tokens": 10, "output_tokens": 20, "cache_creation_input_tokens": 0, "cache_read_input_tokens": 0}}}`
+
+// setupHome 创建临时主目录，在其中写入 Claude Code 风格的项目日志，返回主目录和日志目录
+func setupHome(t *testing.T) (home, logs string) {
+	t.Helper()
+	home = t.TempDir()
+	t.Setenv("HOME", home)
+
+	project := filepath.Join(home, ".claude", "projects", strings.ReplaceAll(home, "/", "-")+"-work-api")
+	if err := os.MkdirAll(project, 0o755); err != nil {
+		t.Fatalf("创建日志目录失败: %v", err)
+	}
+	line := strings.ReplaceAll(testLogLine, "%HOME%", home)
+	if err := os.WriteFile(filepath.Join(project, "s1.jsonl"), []byte(line+"\n"), 0o644); err != nil {
+		t.Fatalf("写入日志失败: %v", err)
+	}
+	return home, filepath.Join(home, ".claude", "projects")
+}
+
+// runCommand 执行命令并返回标准输出和标准错误的全部内容
+func runCommand(t *testing.T, args ...string) string {
+	t.Helper()
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("创建管道失败: %v", err)
+	}
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = writer, writer
+	defer func() { os.Stdout, os.Stderr = stdout, stderr }()
+
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		output <- string(data)
+	}()
+
+	rootCmd.SetArgs(args)
+	runErr := rootCmd.Execute()
+	writer.Close()
+	text := <-output
+	if runErr != nil {
+		t.Fatalf("执行 %v 失败: %v\n%s", args, runErr, text)
+	}
+	return text
+}
+
+// TestVerboseRedactPaths --verbose 的调试输出在 --redact paths 下不包含主目录
+func TestVerboseRedactPaths(t *testing.T) {
+	home, logs := setupHome(t)
+	config := filepath.Join(home, "config.yaml")
+	if err := os.WriteFile(config, nil, 0o644); err != nil {
+		t.Fatalf("写入配置文件失败: %v", err)
+	}
+	archive := filepath.Join(home, "archive")
+
+	for _, command := range [][]string{
+		{"projects", "--format", "json"},
+		{"analyze"},
+		{"export"},
+	} {
+		args := append(command, logs, "--verbose", "--redact", "paths", "--config", config, "--archive-dir", archive)
+		output := runCommand(t, args...)
+
+		if !strings.Contains(output, "~") {
+			t.Errorf("%s 的输出中没有脱敏后的路径:\n%s", command[0], output)
+		}
+		if strings.Contains(output, home) {
+			t.Errorf("%s 的输出包含主目录 %s:\n%s", command[0], home, output)
+		}
+		if encoded := strings.ReplaceAll(home, "/", "-"); strings.Contains(output, encoded) {
+			t.Errorf("%s 的输出包含编码后的主目录 %s:\n%s", command[0], encoded, output)
+		}
+	}
+}
@@ -3,14 +3,20 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
 )
 
 var (
-	cfgFile string
-	verbose bool
+	cfgFile  string
+	verbose  bool
+	language string
 	// 通用命令参数
 	outputFormat string
 	outputFile   string
@@ -19,6 +25,23 @@ var (
 	noColor      bool
 	offline      bool
 	costMode     string
+	// 项目过滤条件（所有命令通用）
+	projectFilter string
+	// 子代理记录过滤方式（所有命令通用）
+	sidechainMode string
+	// 报告、导出和调试输出的脱敏方式：none/paths/hash
+	redactMode string
+	// 表格输出中附加图表（daily、blocks、analyze）
+	showCharts bool
+	// 长期归档目录，以及是否在报告中合并归档数据
+	archiveDir string
+	noArchive  bool
+	// 合并进报告的团队快照文件，以及按用户标签过滤（所有命令通用）
+	snapshotFiles []string
+	userFilter    string
+	// --format template 使用的模板文件（或内置模板名）和内联模板（daily、blocks、analyze）
+	templateFile   string
+	templateString string
 	// daily命令特定参数
 	dailyBreakdown bool
 	dailyOrder     string
@@ -28,44 +51,92 @@ var (
 	configDirs  []string
 	breakdown   bool
 	order       string
+	// 订阅计划（pro/max5x/max20x），覆盖配置文件中的 plan
+	planName string
 	// blocks命令特定参数
 	blocksLive            bool
 	blocksTokenLimit      string
 	blocksRefreshInterval int
 	blocksActive          bool
 	blocksRecent          bool
+	// projects命令特定参数
+	projectsGroup bool
+	projectsSort  string
+	// branches命令特定参数
+	branchFilter    string
+	branchesCommits bool
+	branchesRepo    string
+	// tools命令特定参数
+	toolsTopProjects int
+	// cache命令特定参数
+	cacheBy   string
+	cacheSort string
+	cacheTop  int
+	// latency命令特定参数
+	latencyBy   string
+	latencySort string
+	// heatmap命令特定参数
+	heatmapMetric string
+	// compare命令特定参数
+	compareA     string
+	compareB     string
+	compareWeek  bool
+	compareMonth bool
+	compareTop   int
+	// forecast命令特定参数
+	forecastHistory       int
+	forecastQuarter       bool
+	forecastBudget        float64
+	forecastQuarterBudget float64
+	// anomalies命令特定参数
+	anomaliesMetric    string
+	anomaliesThreshold float64
+	anomaliesWindow    int
+	anomaliesKinds     []string
+	anomaliesLast      string
+	anomaliesCheck     bool
+	// export命令特定参数
+	exportFormat string
+	exportRollup string
+	// export --write-snapshot 生成团队快照，--label 为快照中的用户标签
+	exportSnapshot bool
+	exportLabel    string
+	// import-ccusage命令特定参数
+	ccusageVerify    bool
+	ccusageLabel     string
+	ccusageTolerance float64
+	ccusageTimezone  string
+	// schema命令特定参数
+	schemaWriteDir string
+	schemaCheck    bool
+	// sync/query命令特定参数
+	dbPath string
 )
 
+// ExitError 携带退出码的错误，用于 check 模式等需要通过退出码表达结果的场景
+// Err 为空时只设置退出码，不输出错误信息
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
 // rootCmd 代表基础命令
+// Short/Long 及标志位说明均为消息目录中的key，在Execute时按语言本地化
 var rootCmd = &cobra.Command{
-	Use:   "claude-stats",
-	Short: "完美的Claude Code使用统计工具",
-	Long: `claude-stats - 专业的Claude Code使用统计和分析工具
-
-支持功能：
-• 专门化命令架构（daily, monthly, session, blocks）
-• 智能成本计算和趋势分析
-• 多配置目录支持（CLAUDE_CONFIG_DIR环境变量）
-• 实时监控和Token限制预警
-• 跨平台支持（Windows、Mac、Linux、WSL）
-• 多格式导出（JSON、CSV、表格）
-
-基本命令：
-  claude-stats daily             # 每日使用报告（默认）
-  claude-stats monthly           # 月度使用报告  
-  claude-stats session           # 会话分析
-  claude-stats blocks            # 5小时计费窗口分析
-  claude-stats blocks --live     # 实时监控模式
-  claude-stats analyze           # 通用分析（兼容旧版）
-
-快速开始：
-  claude-stats                   # 显示每日使用情况
-  claude-stats --breakdown       # 显示详细的模型分解
-  claude-stats --json            # JSON格式输出
-
-多配置目录：
-  export CLAUDE_CONFIG_DIR="/path1,/path2"
-  claude-stats daily --breakdown`,
+	Use:     "claude-stats",
+	Short:   "cmd.root.short",
+	Long:    "cmd.root.long",
 	Version: "2.0.0",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// 如果没有指定子命令，默认运行daily
@@ -75,29 +146,53 @@ var rootCmd = &cobra.Command{
 
 // Execute 添加所有子命令到根命令并设置相应的标志位
 func Execute() error {
+	// 帮助信息在解析标志位之前生成，因此需要提前确定配置文件和语言
+	args := os.Args[1:]
+	if value, ok := lookupFlagValue(args, "config"); ok {
+		cfgFile = value
+	}
+	readConfig()
+
+	flagLang, _ := lookupFlagValue(args, "lang")
+	i18n.SetLang(i18n.Resolve(flagLang, viper.GetString("lang")))
+	localizeCommand(rootCmd)
+
 	return rootCmd.Execute()
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
+	// JSON 输出中的 generator 字段
+	models.Generator = "claude-stats " + rootCmd.Version
 
 	// 全局标志位
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "配置文件 (默认: $HOME/.claude-stats.yaml)")
-	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "详细输出")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "flag.config")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "flag.verbose")
+	rootCmd.PersistentFlags().StringVar(&language, "lang", "", "flag.lang")
+	rootCmd.PersistentFlags().StringVar(&archiveDir, "archive-dir", "", "flag.archive_dir")
+	rootCmd.PersistentFlags().BoolVar(&noArchive, "no-archive", false, "flag.no_archive")
+	rootCmd.PersistentFlags().StringSliceVar(&snapshotFiles, "snapshot", nil, "flag.snapshot")
+	rootCmd.PersistentFlags().StringVar(&userFilter, "user", "", "flag.user")
 
 	// 支持默认daily命令的参数
-	rootCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "输出格式 (table, json, csv)")
-	rootCmd.Flags().StringVarP(&outputFile, "output", "o", "", "输出文件路径")
-	rootCmd.Flags().StringVar(&startDate, "since", "", "开始日期 (YYYYMMDD)")
-	rootCmd.Flags().StringVar(&endDate, "until", "", "结束日期 (YYYYMMDD)")
-	rootCmd.Flags().BoolVar(&dailyBreakdown, "breakdown", false, "显示每日按模型分解的详细统计")
-	rootCmd.Flags().StringVar(&dailyOrder, "order", "desc", "排序顺序: desc(最新优先) 或 asc(最旧优先)")
-	rootCmd.Flags().BoolVar(&noColor, "no-color", false, "禁用颜色输出")
-	rootCmd.Flags().BoolVarP(&offline, "offline", "O", false, "离线模式")
-	rootCmd.Flags().StringVar(&costMode, "mode", "auto", "成本计算模式 (auto, calculate, display)")
+	rootCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "flag.format_daily")
+	rootCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	rootCmd.Flags().StringVar(&startDate, "since", "", "flag.since")
+	rootCmd.Flags().StringVar(&endDate, "until", "", "flag.until")
+	rootCmd.Flags().BoolVar(&dailyBreakdown, "breakdown", false, "flag.daily_breakdown")
+	rootCmd.Flags().StringVar(&dailyOrder, "order", "desc", "flag.daily_order")
+	rootCmd.Flags().BoolVar(&noColor, "no-color", false, "flag.no_color")
+	rootCmd.Flags().BoolVarP(&offline, "offline", "O", false, "flag.offline")
+	rootCmd.Flags().StringVar(&costMode, "mode", "auto", "flag.mode")
+	rootCmd.Flags().StringVar(&projectFilter, "project", "", "flag.project")
+	rootCmd.Flags().StringVar(&sidechainMode, "sidechain", "include", "flag.sidechain")
+	rootCmd.Flags().StringVar(&redactMode, "redact", "none", "flag.redact")
+	rootCmd.Flags().BoolVar(&showCharts, "chart", false, "flag.chart")
+	rootCmd.Flags().StringVar(&templateFile, "template", "", "flag.template")
+	rootCmd.Flags().StringVar(&templateString, "template-string", "", "flag.template_string")
 
 	// Cobra也支持本地标志位，只对当前命令运行
-	rootCmd.Flags().BoolP("version", "", false, "显示版本信息")
+	rootCmd.Flags().BoolP("version", "", false, "flag.version")
 
 	// 确保其他文件被包含在编译中
 	// 这些引用会强制Go编译器包含对应的文件
@@ -105,8 +200,10 @@ func init() {
 	_ = blocksCmd
 }
 
-// initConfig 读取配置文件和环境变量
-func initConfig() {
+// readConfig 读取配置文件
+// 不绑定环境变量：配置键（lang、user、plan、archive_dir 等）与 LANG、USER 等常见环境变量同名，
+// 绑定后环境变量会覆盖配置文件；需要读取的环境变量（LANG、CLAUDE_CONFIG_DIR 等）由各处直接读取
+func readConfig() {
 	if cfgFile != "" {
 		// 使用命令行指定的配置文件
 		viper.SetConfigFile(cfgFile)
@@ -121,10 +218,74 @@ func initConfig() {
 		viper.SetConfigName(".claude-stats")
 	}
 
-	viper.AutomaticEnv() // 读取匹配的环境变量
+	// 配置文件不存在时忽略错误
+	_ = viper.ReadInConfig()
+}
+
+// initConfig 在标志位解析完成后应用配置
+func initConfig() {
+	if language != "" && !i18n.SetLang(language) {
+		fmt.Fprintln(os.Stderr, i18n.T("root.unknown_lang", language, strings.Join(i18n.Supported(), ", ")))
+	}
+
+	if verbose && viper.ConfigFileUsed() != "" {
+		redactor, _ := resolveRedactor(redactMode)
+		fmt.Fprintln(os.Stderr, i18n.T("root.using_config"), redactor.File(viper.ConfigFileUsed()))
+	}
+}
+
+// localizeCommand 将命令及其子命令的说明和标志位说明替换为当前语言的文本
+func localizeCommand(cmd *cobra.Command) {
+	cmd.Short = i18n.T(cmd.Short)
+	cmd.Long = i18n.T(cmd.Long)
+
+	localizeFlag := func(flag *pflag.Flag) {
+		flag.Usage = i18n.T(flag.Usage)
+	}
+	cmd.Flags().VisitAll(localizeFlag)
+	cmd.PersistentFlags().VisitAll(localizeFlag)
+
+	for _, child := range cmd.Commands() {
+		localizeCommand(child)
+	}
+}
+
+// lookupFlagValue 在cobra解析之前从参数中查找长标志位的值
+func lookupFlagValue(args []string, name string) (string, bool) {
+	prefix := "--" + name
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if strings.HasPrefix(arg, prefix+"=") {
+			return strings.TrimPrefix(arg, prefix+"="), true
+		}
+		if arg == prefix && i+1 < len(args) {
+			return args[i+1], true
+		}
+	}
+	return "", false
+}
+
+// applyTemplate 在 --format template 时加载 --template 或 --template-string 指定的模板
+func applyTemplate(f *formatter.Formatter) error {
+	if strings.ToLower(outputFormat) != "template" {
+		return nil
+	}
 
-	// 如果配置文件存在，则读取它
-	if err := viper.ReadInConfig(); err == nil && verbose {
-		fmt.Fprintln(os.Stderr, "使用配置文件:", viper.ConfigFileUsed())
+	switch {
+	case templateFile != "" && templateString != "":
+		return i18n.Errorf("err.template_conflict")
+	case templateString != "":
+		f.Template = templateString
+	case templateFile != "":
+		source, err := formatter.LoadTemplate(templateFile)
+		if err != nil {
+			return err
+		}
+		f.Template = source
+	default:
+		return i18n.Errorf("err.template_required", strings.Join(formatter.BundledTemplateNames(), ", "))
 	}
+	return nil
 }
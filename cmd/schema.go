@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+	"github.com/zhuiye8/claude-stats/pkg/schema"
+)
+
+// schemaCheckFailedExitCode --check 发现 schema 与 golden 文件不一致时的退出码（1 保留给运行错误）
+const schemaCheckFailedExitCode = 2
+
+// schemaCmd 代表schema命令
+var schemaCmd = &cobra.Command{
+	Use:   "schema [report]",
+	Short: "cmd.schema.short",
+	Long:  "cmd.schema.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runSchema,
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+
+	// schema命令特定的标志位
+	schemaCmd.Flags().StringVar(&schemaWriteDir, "write", "", "flag.schema_write")
+	schemaCmd.Flags().BoolVar(&schemaCheck, "check", false, "flag.schema_check")
+
+	// 继承通用标志位
+	schemaCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	switch {
+	case schemaCheck:
+		return checkSchemas(cmd)
+	case schemaWriteDir != "":
+		count, err := schema.Write(expandHome(schemaWriteDir))
+		if err != nil {
+			return i18n.Errorf("err.write_failed", err)
+		}
+		fmt.Println(i18n.T("schema.written", count, schemaWriteDir))
+		return nil
+	case len(args) == 0:
+		var output strings.Builder
+		output.WriteString(i18n.T("schema.list_title", models.SchemaVersion) + "\n")
+		for _, report := range schema.Reports {
+			output.WriteString(fmt.Sprintf("  %-16s %s\n", report.Name, report.Command))
+		}
+		fmt.Print(output.String())
+		return nil
+	}
+
+	report, ok := schema.Lookup(args[0])
+	if !ok {
+		return i18n.Errorf("err.schema_unknown", args[0], strings.Join(schema.Names(), ", "))
+	}
+	data, err := schema.Generate(report)
+	if err != nil {
+		return err
+	}
+	return writeOutput(string(data))
+}
+
+// checkSchemas 对比 golden 文件，有问题时以非零退出码结束，便于在 CI 中使用
+func checkSchemas(cmd *cobra.Command) error {
+	problems, err := schema.Check()
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		fmt.Println(i18n.T("schema.check_ok", len(schema.Reports), models.SchemaVersion))
+		return nil
+	}
+
+	for _, problem := range problems {
+		switch problem.Kind {
+		case schema.CheckChanged:
+			fmt.Println(i18n.T("schema.check_changed", problem.Report, models.SchemaVersion))
+		case schema.CheckOutdated:
+			fmt.Println(i18n.T("schema.check_outdated", problem.Report, problem.GoldenVersion))
+		default:
+			fmt.Println(i18n.T("schema.check_missing", problem.Report))
+		}
+	}
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return &ExitError{Code: schemaCheckFailedExitCode}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/parser"
+	"github.com/zhuiye8/claude-stats/pkg/store"
+)
+
+// syncCmd 代表sync命令
+var syncCmd = &cobra.Command{
+	Use:   "sync [dir]",
+	Short: "cmd.sync.short",
+	Long:  "cmd.sync.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runSync,
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+
+	// sync命令特定的标志位
+	syncCmd.Flags().StringVar(&dbPath, "db", "", "flag.db")
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	targetDirs := getTargetDirectories(args)
+	if verbose {
+		fmt.Println(i18n.T("common.analyzing_dirs", strings.Join(targetDirs, ", ")))
+	}
+
+	path := resolveDBPath()
+	db, err := store.Open(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// 数据库保存全部记录，不应用日期、项目和子代理过滤，只使用项目别名/分组规则
+	claudeParser := parser.NewClaudeParser()
+	claudeParser.Verbose = verbose
+	claudeParser.SkipErrors = true
+	claudeParser.Projects = loadProjectRules()
+
+	result, err := db.Sync(claudeParser, targetDirs)
+	if err != nil {
+		return i18n.Errorf("err.sync_failed", err)
+	}
+
+	fmt.Println(i18n.T("sync.done", result.EntriesAdded, result.FilesUpdated, result.FilesScanned, path))
+	if result.FilesReset > 0 {
+		fmt.Println(i18n.T("sync.reset", result.FilesReset))
+	}
+	fmt.Println(i18n.T("sync.totals", result.EntriesTotal, result.SessionsTotal, result.ProjectsTotal, result.BlocksTotal))
+	return nil
+}
+
+// resolveDBPath 确定数据库路径：--db > 配置文件 database > ~/.claude-stats.db
+func resolveDBPath() string {
+	if dbPath != "" {
+		return expandHome(dbPath)
+	}
+	if configured := viper.GetString("database"); configured != "" {
+		return expandHome(configured)
+	}
+	return store.DefaultPath()
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+	"github.com/zhuiye8/claude-stats/pkg/parser"
+)
+
+// toolsCmd 代表tools命令
+var toolsCmd = &cobra.Command{
+	Use:   "tools [dir]",
+	Short: "cmd.tools.short",
+	Long:  "cmd.tools.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runTools,
+}
+
+func init() {
+	rootCmd.AddCommand(toolsCmd)
+
+	// tools命令特定的标志位
+	toolsCmd.Flags().StringVar(&projectsSort, "sort", "cost", "flag.tools_sort")
+	toolsCmd.Flags().StringVar(&order, "order", "desc", "flag.order")
+	toolsCmd.Flags().IntVar(&toolsTopProjects, "top", 3, "flag.tools_top")
+
+	// 继承通用标志位
+	toolsCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "flag.format_tools")
+	toolsCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	toolsCmd.Flags().StringVar(&startDate, "since", "", "flag.since")
+	toolsCmd.Flags().StringVar(&endDate, "until", "", "flag.until")
+	toolsCmd.Flags().StringVar(&projectFilter, "project", "", "flag.project")
+	toolsCmd.Flags().StringVar(&sidechainMode, "sidechain", "include", "flag.sidechain")
+	toolsCmd.Flags().StringVar(&redactMode, "redact", "none", "flag.redact")
+	toolsCmd.Flags().BoolVar(&noColor, "no-color", false, "flag.no_color")
+}
+
+func runTools(cmd *cobra.Command, args []string) error {
+	redactor, err := resolveRedactor(redactMode)
+	if err != nil {
+		return err
+	}
+
+	// 确定要分析的目录列表
+	targetDirs := getTargetDirectories(args)
+
+	if verbose {
+		fmt.Println(redactor.Text(i18n.T("common.analyzing_dirs", strings.Join(targetDirs, ", "))))
+	}
+
+	stats, err := parseDirectories(targetDirs, redactor)
+	if err != nil {
+		return err
+	}
+
+	claudeParser := parser.NewClaudeParser()
+	report := claudeParser.AnalyzeTools(stats)
+
+	if err := sortTools(report.Tools, projectsSort, order); err != nil {
+		return err
+	}
+
+	// 格式化并输出结果
+	formatter := formatter.NewFormatter()
+	formatter.Verbose = verbose
+	formatter.TopN = toolsTopProjects
+
+	// 设置颜色选项
+	if noColor {
+		formatter.Colors.Enabled = false
+	}
+
+	var output string
+
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		output, err = formatter.FormatToolsJSON(report)
+	case "csv":
+		output, err = formatter.FormatToolsCSV(report)
+	case "markdown":
+		output, err = formatter.FormatToolsMarkdown(report)
+	case "table", "":
+		output, err = formatter.FormatTools(report)
+	default:
+		return i18n.Errorf("err.unsupported_format", outputFormat)
+	}
+
+	if err != nil {
+		return i18n.Errorf("err.format_failed", err)
+	}
+
+	return writeOutput(output)
+}
+
+// sortTools 按指定字段排序工具
+func sortTools(tools []models.ToolStats, field, direction string) error {
+	var less func(a, b models.ToolStats) bool
+
+	switch strings.ToLower(field) {
+	case "cost", "":
+		less = func(a, b models.ToolStats) bool { return a.Cost < b.Cost }
+	case "calls":
+		less = func(a, b models.ToolStats) bool { return a.CallCount < b.CallCount }
+	case "errors":
+		less = func(a, b models.ToolStats) bool { return a.ErrorCount < b.ErrorCount }
+	case "error_rate":
+		less = func(a, b models.ToolStats) bool { return a.ErrorRate() < b.ErrorRate() }
+	case "tokens":
+		less = func(a, b models.ToolStats) bool { return a.Tokens.GetTotalTokens() < b.Tokens.GetTotalTokens() }
+	case "name":
+		less = func(a, b models.ToolStats) bool { return a.Name < b.Name }
+	default:
+		return i18n.Errorf("err.unsupported_sort", field)
+	}
+
+	sort.SliceStable(tools, func(i, j int) bool {
+		if direction == "asc" {
+			return less(tools[i], tools[j])
+		}
+		return less(tools[j], tools[i])
+	})
+	return nil
+}
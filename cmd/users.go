@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zhuiye8/claude-stats/pkg/formatter"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/parser"
+)
+
+// usersCmd 代表users命令
+var usersCmd = &cobra.Command{
+	Use:   "users [dir]",
+	Short: "cmd.users.short",
+	Long:  "cmd.users.long",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runUsers,
+}
+
+func init() {
+	rootCmd.AddCommand(usersCmd)
+
+	// users命令特定的标志位
+	usersCmd.Flags().BoolVarP(&showDetails, "details", "d", false, "flag.details")
+
+	// 继承通用标志位
+	usersCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "flag.format_users")
+	usersCmd.Flags().StringVarP(&outputFile, "output", "o", "", "flag.output")
+	usersCmd.Flags().StringVar(&startDate, "since", "", "flag.since")
+	usersCmd.Flags().StringVar(&endDate, "until", "", "flag.until")
+	usersCmd.Flags().StringVar(&projectFilter, "project", "", "flag.project")
+	usersCmd.Flags().StringVar(&sidechainMode, "sidechain", "include", "flag.sidechain")
+	usersCmd.Flags().StringVar(&redactMode, "redact", "none", "flag.redact")
+	usersCmd.Flags().BoolVar(&noColor, "no-color", false, "flag.no_color")
+}
+
+func runUsers(cmd *cobra.Command, args []string) error {
+	redactor, err := resolveRedactor(redactMode)
+	if err != nil {
+		return err
+	}
+
+	targetDirs := getTargetDirectories(args)
+
+	if verbose {
+		fmt.Println(redactor.Text(i18n.T("common.analyzing_dirs", strings.Join(targetDirs, ", "))))
+	}
+
+	stats, err := parseDirectories(targetDirs, redactor)
+	if err != nil {
+		return err
+	}
+
+	claudeParser := parser.NewClaudeParser()
+	report := claudeParser.AnalyzeUsers(stats)
+
+	// 格式化并输出结果
+	formatter := formatter.NewFormatter()
+	formatter.ShowDetails = showDetails
+	formatter.Verbose = verbose
+
+	// 设置颜色选项
+	if noColor {
+		formatter.Colors.Enabled = false
+	}
+
+	var output string
+
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		output, err = formatter.FormatUsersJSON(report)
+	case "csv":
+		output, err = formatter.FormatUsersCSV(report)
+	case "markdown":
+		output, err = formatter.FormatUsersMarkdown(report)
+	case "table", "":
+		output, err = formatter.FormatUsers(report)
+	default:
+		return i18n.Errorf("err.unsupported_format", outputFormat)
+	}
+
+	if err != nil {
+		return i18n.Errorf("err.format_failed", err)
+	}
+
+	return writeOutput(output)
+}
@@ -1,15 +1,24 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/zhuiye8/claude-stats/cmd"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
 )
 
 func main() {
 	if err := cmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "错误: %v\n", err)
+		var exitErr *cmd.ExitError
+		if errors.As(err, &exitErr) {
+			if exitErr.Err != nil {
+				fmt.Fprintln(os.Stderr, i18n.T("main.error", exitErr.Err))
+			}
+			os.Exit(exitErr.Code)
+		}
+		fmt.Fprintln(os.Stderr, i18n.T("main.error", err))
 		os.Exit(1)
 	}
 }
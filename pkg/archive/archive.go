@@ -0,0 +1,202 @@
+// Package archive 维护长期用量归档，避免 Claude Code 清理旧日志后丢失历史数据
+// 归档目录中每月一个 gzip 压缩的 NDJSON 明细文件（entries-YYYY-MM.ndjson.gz），
+// 另有全部归档数据的每日汇总（daily.ndjson.gz）
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+const (
+	// DefaultDirName 默认归档目录名，位于用户主目录下
+	DefaultDirName = ".claude-stats-archive"
+
+	entriesPrefix = "entries-"
+	entriesSuffix = ".ndjson.gz"
+	rollupFile    = "daily.ndjson.gz"
+	monthLayout   = "2006-01"
+)
+
+// Archive 归档目录
+type Archive struct {
+	Dir string
+}
+
+// New 返回指定目录的归档（不会创建目录）
+func New(dir string) *Archive {
+	return &Archive{Dir: dir}
+}
+
+// DefaultDir 返回默认归档目录（~/.claude-stats-archive）
+func DefaultDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return DefaultDirName
+	}
+	return filepath.Join(home, DefaultDirName)
+}
+
+// Exists 检查归档目录是否存在
+func (a *Archive) Exists() bool {
+	info, err := os.Stat(a.Dir)
+	return err == nil && info.IsDir()
+}
+
+// Months 返回归档中已有的月份（YYYY-MM，升序）
+func (a *Archive) Months() ([]string, error) {
+	entries, err := os.ReadDir(a.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var months []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, entriesPrefix) || !strings.HasSuffix(name, entriesSuffix) {
+			continue
+		}
+		months = append(months, strings.TrimSuffix(strings.TrimPrefix(name, entriesPrefix), entriesSuffix))
+	}
+	sort.Strings(months)
+	return months, nil
+}
+
+// ForEach 按月份顺序读取全部归档记录
+func (a *Archive) ForEach(fn func(models.UsageRecord) error) error {
+	months, err := a.Months()
+	if err != nil {
+		return err
+	}
+	for _, month := range months {
+		if err := a.readMonth(month, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Add 将记录合并进对应月份的归档文件，已归档的记录（按 Key 去重）会被跳过
+// 返回新增的记录数；只有出现新记录的月份才会被重写
+func (a *Archive) Add(records []models.UsageRecord) (int, error) {
+	byMonth := make(map[string][]models.UsageRecord)
+	for _, record := range records {
+		month := record.Timestamp.UTC().Format(monthLayout)
+		byMonth[month] = append(byMonth[month], record)
+	}
+
+	if err := os.MkdirAll(a.Dir, 0755); err != nil {
+		return 0, err
+	}
+
+	added := 0
+	for month, incoming := range byMonth {
+		var merged []models.UsageRecord
+		seen := make(map[string]bool)
+		err := a.readMonth(month, func(record models.UsageRecord) error {
+			seen[record.Key()] = true
+			merged = append(merged, record)
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return added, err
+		}
+
+		before := len(merged)
+		for _, record := range incoming {
+			if key := record.Key(); !seen[key] {
+				seen[key] = true
+				merged = append(merged, record)
+			}
+		}
+		if len(merged) == before {
+			continue
+		}
+
+		sort.SliceStable(merged, func(i, j int) bool {
+			return merged[i].Timestamp.Before(merged[j].Timestamp)
+		})
+		if err := writeGzipLines(a.monthPath(month), len(merged), func(i int) interface{} { return merged[i] }); err != nil {
+			return added, err
+		}
+		added += len(merged) - before
+	}
+	return added, nil
+}
+
+// WriteRollups 覆盖写入每日汇总文件
+func (a *Archive) WriteRollups(rollups []models.UsageRollup) error {
+	return writeGzipLines(filepath.Join(a.Dir, rollupFile), len(rollups), func(i int) interface{} { return rollups[i] })
+}
+
+// monthPath 返回某月明细文件的路径
+func (a *Archive) monthPath(month string) string {
+	return filepath.Join(a.Dir, entriesPrefix+month+entriesSuffix)
+}
+
+// readMonth 读取某月的归档记录
+func (a *Archive) readMonth(month string, fn func(models.UsageRecord) error) error {
+	file, err := os.Open(a.monthPath(month))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	decoder := json.NewDecoder(bufio.NewReader(reader))
+	for decoder.More() {
+		var record models.UsageRecord
+		if err := decoder.Decode(&record); err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeGzipLines 先写入临时文件再重命名，避免中断时留下损坏的归档
+func writeGzipLines(path string, count int, item func(i int) interface{}) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	writer := gzip.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+	for i := 0; i < count; i++ {
+		if err := encoder.Encode(item(i)); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,117 @@
+package archive
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+const (
+	// SnapshotType 快照文件的 type 字段
+	SnapshotType = "snapshot"
+	// SnapshotVersion 当前快照格式版本
+	SnapshotVersion = 1
+
+	importsDir = "imports"
+)
+
+// NewSnapshot 创建快照，记录中缺少的用户标签和主机名由快照头部补齐
+func NewSnapshot(user, hostname string, records []models.UsageRecord) *models.Snapshot {
+	for i := range records {
+		if records[i].User == "" {
+			records[i].User = user
+			records[i].Host = hostname
+		}
+	}
+	return &models.Snapshot{
+		Type:      SnapshotType,
+		Version:   SnapshotVersion,
+		User:      user,
+		Hostname:  hostname,
+		CreatedAt: time.Now().UTC(),
+		Entries:   records,
+	}
+}
+
+// WriteSnapshot 以 JSON 格式写出快照
+func WriteSnapshot(out io.Writer, snapshot *models.Snapshot) error {
+	snapshot.Stamp()
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(snapshot)
+}
+
+// ReadSnapshot 读取并校验快照文件，记录中缺少的用户标签和主机名由快照头部补齐
+func ReadSnapshot(path string) (*models.Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var snapshot models.Snapshot
+	if err := json.NewDecoder(file).Decode(&snapshot); err != nil {
+		return nil, err
+	}
+	if snapshot.Type != SnapshotType {
+		return nil, i18n.Errorf("err.snapshot_type", snapshot.Type)
+	}
+	if snapshot.Version > SnapshotVersion {
+		return nil, i18n.Errorf("err.snapshot_version", snapshot.Version)
+	}
+	if snapshot.User == "" {
+		return nil, i18n.Errorf("err.snapshot_user")
+	}
+
+	for i := range snapshot.Entries {
+		if snapshot.Entries[i].User == "" {
+			snapshot.Entries[i].User = snapshot.User
+			snapshot.Entries[i].Host = snapshot.Hostname
+		}
+	}
+	return &snapshot, nil
+}
+
+// unsafeLabel 用户标签中不能直接用作目录名的字符
+var unsafeLabel = regexp.MustCompile(`[^A-Za-z0-9._@-]+`)
+
+// Imports 返回某个用户导入数据所在的归档（<归档目录>/imports/<用户标签>）
+func (a *Archive) Imports(user string) *Archive {
+	name := unsafeLabel.ReplaceAllString(user, "_")
+	if name == "" || name == "." || name == ".." {
+		name = "_"
+	}
+	return New(filepath.Join(a.Dir, importsDir, name))
+}
+
+// ImportedUsers 返回已导入数据的归档，按目录名排序
+func (a *Archive) ImportedUsers() ([]*Archive, error) {
+	entries, err := os.ReadDir(filepath.Join(a.Dir, importsDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	archives := make([]*Archive, 0, len(names))
+	for _, name := range names {
+		archives = append(archives, New(filepath.Join(a.Dir, importsDir, name)))
+	}
+	return archives, nil
+}
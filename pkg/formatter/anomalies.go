@@ -0,0 +1,167 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// FormatAnomalies 格式化异常检测报告为表格
+func (f *Formatter) FormatAnomalies(report *models.AnomalyReport) (string, error) {
+	var output strings.Builder
+
+	// 添加标题
+	output.WriteString(f.Colors.IconHeader("🚨", i18n.T("fmt.anomalies.title", i18n.T("fmt.heatmap.metric_"+report.Metric)), BrightRed))
+	output.WriteString("\n")
+	output.WriteString(f.Colors.Dim("   * " + i18n.T("fmt.anomalies.baseline_hint", report.WindowDays, report.Threshold) + "\n"))
+	output.WriteString(f.Colors.Dim("   * " + i18n.T("fmt.anomalies.checked_hint", report.Checked[models.AnomalyDay],
+		report.Checked[models.AnomalySession], report.Checked[models.AnomalyBlock]) + "\n"))
+
+	if len(report.Anomalies) == 0 {
+		output.WriteString("\n   ✅ " + f.Colors.Success(i18n.T("fmt.anomalies.none")) + "\n")
+		return output.String(), nil
+	}
+	output.WriteString("\n")
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		f.Colors.Header(i18n.T("col.kind")),
+		f.Colors.Header(i18n.T("col.target")),
+		f.Colors.Header(i18n.T("col.observed")),
+		f.Colors.Header(i18n.T("col.baseline")),
+		f.Colors.Header(i18n.T("col.ratio")),
+		f.Colors.Header(i18n.T("col.score")),
+		f.Colors.Header(i18n.T("col.top_session")),
+		f.Colors.Header(i18n.T("col.top_model")),
+	})
+
+	for _, anomaly := range report.Anomalies {
+		ratio := "-"
+		if anomaly.Ratio > 0 {
+			ratio = fmt.Sprintf("×%.1f", anomaly.Ratio)
+		}
+		t.AppendRow(table.Row{
+			i18n.T("fmt.anomalies.kind_" + anomaly.Kind),
+			f.Colors.BrightCyan(anomalyTarget(anomaly)),
+			f.Colors.Error(formatMetricValue(report.Metric, anomaly.Observed)),
+			formatMetricValue(report.Metric, anomaly.Baseline),
+			ratio,
+			f.anomalyScore(anomaly.Score, report.Threshold),
+			formatContributor(anomaly.TopSession, shortSessionID),
+			formatContributor(anomaly.TopModel, func(name string) string { return name }),
+		})
+	}
+
+	t.SetStyle(table.StyleColoredBright)
+	output.WriteString(t.Render())
+	output.WriteString("\n\n")
+
+	return output.String(), nil
+}
+
+// anomalyTarget 异常对象的显示文本
+func anomalyTarget(anomaly models.Anomaly) string {
+	switch anomaly.Kind {
+	case models.AnomalySession:
+		label := shortSessionID(anomaly.ID)
+		if anomaly.Project != "" {
+			label += " (" + projectLabel(anomaly.Project) + ")"
+		}
+		return label
+	case models.AnomalyBlock:
+		return anomaly.Start.Local().Format("2006-01-02 15:04")
+	default:
+		return anomaly.ID
+	}
+}
+
+// shortSessionID 截短会话ID
+func shortSessionID(id string) string {
+	if len(id) > 8 {
+		return id[:8] + "..."
+	}
+	return id
+}
+
+// formatContributor 显示贡献者及其占比
+func formatContributor(contributor *models.AnomalyContributor, label func(string) string) string {
+	if contributor == nil {
+		return "-"
+	}
+	name := contributor.Name
+	if name == "" {
+		name = i18n.T("common.unknown")
+	} else {
+		name = label(name)
+	}
+	return fmt.Sprintf("%s (%.0f%%)", name, contributor.Share*100)
+}
+
+// anomalyScore 按超出阈值的程度着色
+func (f *Formatter) anomalyScore(score, threshold float64) string {
+	text := fmt.Sprintf("%.1f", score)
+	if score >= threshold*2 {
+		return f.Colors.Error(text)
+	}
+	return f.Colors.Warning(text)
+}
+
+// FormatAnomaliesJSON 格式化异常检测报告为JSON
+func (f *Formatter) FormatAnomaliesJSON(report *models.AnomalyReport) (string, error) {
+	return marshalReport(report)
+}
+
+// FormatAnomaliesCSV 格式化异常检测报告为CSV
+func (f *Formatter) FormatAnomaliesCSV(report *models.AnomalyReport) (string, error) {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+
+	// 写入标题行（使用与语言无关的稳定标识，便于脚本处理）
+	headers := []string{
+		"kind", "id", "start", "end", "project", "metric",
+		"observed", "baseline", "mad", "score", "ratio", "baseline_size",
+		"top_session", "top_session_share", "top_model", "top_model_share",
+	}
+	if err := writer.Write(headers); err != nil {
+		return "", err
+	}
+
+	for _, anomaly := range report.Anomalies {
+		topSession, topSessionShare := contributorCSV(anomaly.TopSession)
+		topModel, topModelShare := contributorCSV(anomaly.TopModel)
+		row := []string{
+			anomaly.Kind,
+			anomaly.ID,
+			anomaly.Start.Format("2006-01-02T15:04:05Z07:00"),
+			anomaly.End.Format("2006-01-02T15:04:05Z07:00"),
+			anomaly.Project,
+			report.Metric,
+			formatMetricCSV(report.Metric, anomaly.Observed),
+			formatMetricCSV(report.Metric, anomaly.Baseline),
+			formatMetricCSV(report.Metric, anomaly.MAD),
+			fmt.Sprintf("%.2f", anomaly.Score),
+			fmt.Sprintf("%.2f", anomaly.Ratio),
+			fmt.Sprintf("%d", anomaly.BaselineSize),
+			topSession, topSessionShare,
+			topModel, topModelShare,
+		}
+		if err := writer.Write(row); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return builder.String(), writer.Error()
+}
+
+// contributorCSV 贡献者名称和占比（CSV用）
+func contributorCSV(contributor *models.AnomalyContributor) (string, string) {
+	if contributor == nil {
+		return "", ""
+	}
+	return contributor.Name, fmt.Sprintf("%.4f", contributor.Share)
+}
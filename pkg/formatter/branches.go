@@ -0,0 +1,165 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// FormatBranches 格式化分支报告为表格
+func (f *Formatter) FormatBranches(report *models.BranchesReport) (string, error) {
+	var output strings.Builder
+
+	// 添加标题
+	output.WriteString(f.Colors.IconHeader("🌿", i18n.T("fmt.branches.title"), BrightGreen))
+	output.WriteString("\n\n")
+
+	if len(report.Branches) == 0 {
+		output.WriteString("   📝 " + i18n.T("fmt.branches.empty") + "\n")
+		return output.String(), nil
+	}
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		f.Colors.Header(i18n.T("col.project")),
+		f.Colors.Header(i18n.T("col.branch")),
+		f.Colors.Header(i18n.T("col.sessions")),
+		f.Colors.Header(i18n.T("col.messages")),
+		f.Colors.Header(i18n.T("col.total_tokens")),
+		f.Colors.Header(i18n.T("col.cost_usd")),
+		f.Colors.Header(i18n.T("col.commits")),
+		f.Colors.Header(i18n.T("col.first_activity")),
+		f.Colors.Header(i18n.T("col.last_activity")),
+	})
+
+	for _, branch := range report.Branches {
+		commits := "-"
+		if branch.Repository != "" {
+			commits = formatNumber(len(branch.Commits))
+		}
+
+		t.AppendRow(table.Row{
+			f.Colors.BrightCyan(branch.ProjectName),
+			f.branchName(branch.Branch),
+			formatNumber(branch.SessionCount),
+			formatNumber(branch.MessageCount),
+			formatNumber(branch.Tokens.GetTotalTokens()),
+			fmt.Sprintf("$%.4f", branch.Cost),
+			commits,
+			formatActivity(branch.FirstActivity),
+			formatActivity(branch.LastActivity),
+		})
+
+		// 详细模式下列出关联的提交
+		if f.ShowDetails {
+			for _, commit := range branch.Commits {
+				subject := commit.Subject
+				if len(subject) > 60 {
+					subject = subject[:57] + "..."
+				}
+				t.AppendRow(table.Row{
+					f.Colors.Dim("  └─ " + shortHash(commit.Hash)),
+					f.Colors.Dim(subject),
+					"", "", "", "", "",
+					f.Colors.Dim(formatActivity(commit.Time)),
+					"",
+				})
+			}
+		}
+	}
+
+	// 添加汇总行
+	t.AppendFooter(table.Row{
+		f.Colors.Bold(i18n.T("common.total")),
+		"",
+		f.Colors.Bold(formatNumber(report.Summary.SessionCount)),
+		f.Colors.Bold(formatNumber(report.Summary.MessageCount)),
+		f.Colors.Bold(formatNumber(report.Summary.Tokens.GetTotalTokens())),
+		f.Colors.Bold(fmt.Sprintf("$%.4f", report.Summary.Cost)),
+		f.Colors.Bold(formatNumber(len(report.Summary.Commits))),
+		"",
+		"",
+	})
+
+	t.SetStyle(table.StyleColoredBright)
+	output.WriteString(t.Render())
+	output.WriteString("\n\n")
+
+	return output.String(), nil
+}
+
+// FormatBranchesJSON 格式化分支报告为JSON
+func (f *Formatter) FormatBranchesJSON(report *models.BranchesReport) (string, error) {
+	return marshalReport(report)
+}
+
+// FormatBranchesCSV 格式化分支报告为CSV
+func (f *Formatter) FormatBranchesCSV(report *models.BranchesReport) (string, error) {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+
+	// 写入标题行（使用与语言无关的稳定标识，便于脚本处理）
+	headers := []string{
+		"project", "path", "branch", "repository", "session_count", "message_count",
+		"input_tokens", "output_tokens", "cache_creation_tokens", "cache_read_tokens",
+		"total_tokens", "cost_usd", "commit_count", "commits", "first_activity", "last_activity",
+	}
+	if err := writer.Write(headers); err != nil {
+		return "", err
+	}
+
+	rows := append([]models.BranchStats{}, report.Branches...)
+	rows = append(rows, report.Summary)
+
+	for _, branch := range rows {
+		var hashes []string
+		for _, commit := range branch.Commits {
+			hashes = append(hashes, commit.Hash)
+		}
+
+		row := []string{
+			branch.ProjectName,
+			branch.ProjectPath,
+			branch.Branch,
+			branch.Repository,
+			fmt.Sprintf("%d", branch.SessionCount),
+			fmt.Sprintf("%d", branch.MessageCount),
+			fmt.Sprintf("%d", branch.Tokens.InputTokens),
+			fmt.Sprintf("%d", branch.Tokens.OutputTokens),
+			fmt.Sprintf("%d", branch.Tokens.CacheCreationTokens),
+			fmt.Sprintf("%d", branch.Tokens.CacheReadTokens),
+			fmt.Sprintf("%d", branch.Tokens.GetTotalTokens()),
+			fmt.Sprintf("%.4f", branch.Cost),
+			fmt.Sprintf("%d", len(branch.Commits)),
+			strings.Join(hashes, ";"),
+			formatTimestamp(branch.FirstActivity),
+			formatTimestamp(branch.LastActivity),
+		}
+		if err := writer.Write(row); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return builder.String(), writer.Error()
+}
+
+// branchName 显示分支名，缺失时显示占位文本
+func (f *Formatter) branchName(branch string) string {
+	if branch == "" {
+		return f.Colors.Dim(i18n.T("fmt.branches.no_branch"))
+	}
+	return f.Colors.BrightGreen(branch)
+}
+
+// shortHash 返回提交哈希的前7位
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -0,0 +1,178 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// FormatCache 格式化缓存效率报告为表格
+func (f *Formatter) FormatCache(report *models.CacheReport) (string, error) {
+	var output strings.Builder
+
+	// 添加标题
+	output.WriteString(f.Colors.IconHeader("🧊", i18n.T("fmt.cache.title"), BrightCyan))
+	output.WriteString("\n")
+
+	if len(report.Items) == 0 {
+		output.WriteString("\n   📝 " + i18n.T("fmt.cache.empty") + "\n")
+		return output.String(), nil
+	}
+
+	output.WriteString(f.Colors.Dim("   * " + i18n.T("fmt.cache.savings_hint") + "\n"))
+	output.WriteString(f.Colors.Dim("   * " + i18n.T("fmt.cache.waste_hint") + "\n\n"))
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		f.Colors.Header(i18n.T(cacheDimensionColumn(report.Dimension))),
+		f.Colors.Header(i18n.T("col.messages")),
+		f.Colors.Header(i18n.T("col.input_tokens")),
+		f.Colors.Header(i18n.T("col.cache_creation")),
+		f.Colors.Header(i18n.T("col.cache_read")),
+		f.Colors.Header(i18n.T("col.hit_ratio")),
+		f.Colors.Header(i18n.T("col.cache_savings")),
+		f.Colors.Header(i18n.T("col.cache_net_savings")),
+		f.Colors.Header(i18n.T("col.wasted_5m")),
+		f.Colors.Header(i18n.T("col.wasted_1h")),
+	})
+
+	for _, item := range report.Items {
+		t.AppendRow(table.Row{
+			f.Colors.BrightCyan(cacheItemLabel(report.Dimension, item.Name)),
+			formatNumber(item.MessageCount),
+			formatNumber(item.InputTokens),
+			f.Colors.Success(formatNumber(item.CacheCreationTokens)),
+			f.Colors.Info(formatNumber(item.CacheReadTokens)),
+			f.hitRatio(item.HitRatio),
+			fmt.Sprintf("$%.4f", item.SavingsUSD),
+			fmt.Sprintf("$%.4f", item.NetSavingsUSD),
+			formatCacheWaste(item.Wasted5mUSD, item.Wasted5mTokens, item.CacheCreationTokens),
+			formatCacheWaste(item.Wasted1hUSD, item.Wasted1hTokens, item.CacheCreationTokens),
+		})
+	}
+
+	// 添加汇总行
+	summary := report.Summary
+	t.AppendFooter(table.Row{
+		f.Colors.Bold(i18n.T("common.total")),
+		f.Colors.Bold(formatNumber(summary.MessageCount)),
+		f.Colors.Bold(formatNumber(summary.InputTokens)),
+		f.Colors.Bold(formatNumber(summary.CacheCreationTokens)),
+		f.Colors.Bold(formatNumber(summary.CacheReadTokens)),
+		f.Colors.Bold(fmt.Sprintf("%.1f%%", summary.HitRatio*100)),
+		f.Colors.Bold(fmt.Sprintf("$%.4f", summary.SavingsUSD)),
+		f.Colors.Bold(fmt.Sprintf("$%.4f", summary.NetSavingsUSD)),
+		f.Colors.Bold(formatCacheWaste(summary.Wasted5mUSD, summary.Wasted5mTokens, summary.CacheCreationTokens)),
+		f.Colors.Bold(formatCacheWaste(summary.Wasted1hUSD, summary.Wasted1hTokens, summary.CacheCreationTokens)),
+	})
+
+	t.SetStyle(table.StyleColoredBright)
+	output.WriteString(t.Render())
+	output.WriteString("\n\n")
+
+	return output.String(), nil
+}
+
+// FormatCacheJSON 格式化缓存效率报告为JSON
+func (f *Formatter) FormatCacheJSON(report *models.CacheReport) (string, error) {
+	return marshalReport(report)
+}
+
+// FormatCacheCSV 格式化缓存效率报告为CSV
+func (f *Formatter) FormatCacheCSV(report *models.CacheReport) (string, error) {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+
+	// 写入标题行（使用与语言无关的稳定标识，便于脚本处理）
+	headers := []string{
+		report.Dimension, "message_count", "input_tokens", "cache_creation_tokens", "cache_read_tokens",
+		"hit_ratio", "savings_usd", "write_premium_usd", "net_savings_usd",
+		"wasted_5m_tokens", "wasted_5m_usd", "wasted_1h_tokens", "wasted_1h_usd",
+	}
+	if err := writer.Write(headers); err != nil {
+		return "", err
+	}
+
+	rows := append([]models.CacheStats{}, report.Items...)
+	rows = append(rows, report.Summary)
+
+	for _, item := range rows {
+		row := []string{
+			item.Name,
+			fmt.Sprintf("%d", item.MessageCount),
+			fmt.Sprintf("%d", item.InputTokens),
+			fmt.Sprintf("%d", item.CacheCreationTokens),
+			fmt.Sprintf("%d", item.CacheReadTokens),
+			fmt.Sprintf("%.4f", item.HitRatio),
+			fmt.Sprintf("%.4f", item.SavingsUSD),
+			fmt.Sprintf("%.4f", item.WritePremiumUSD),
+			fmt.Sprintf("%.4f", item.NetSavingsUSD),
+			fmt.Sprintf("%d", item.Wasted5mTokens),
+			fmt.Sprintf("%.4f", item.Wasted5mUSD),
+			fmt.Sprintf("%d", item.Wasted1hTokens),
+			fmt.Sprintf("%.4f", item.Wasted1hUSD),
+		}
+		if err := writer.Write(row); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return builder.String(), writer.Error()
+}
+
+// cacheDimensionColumn 返回分组维度对应的列名key
+func cacheDimensionColumn(dimension string) string {
+	switch dimension {
+	case "project":
+		return "col.project"
+	case "session":
+		return "col.session_id"
+	case "model":
+		return "col.model"
+	default:
+		return "col.date"
+	}
+}
+
+// cacheItemLabel 返回分组名称的显示文本
+func cacheItemLabel(dimension, name string) string {
+	switch {
+	case name == "":
+		return i18n.T("common.unknown")
+	case dimension == "project":
+		return projectLabel(name)
+	case dimension == "session" && len(name) > 8:
+		return name[:8] + "..."
+	default:
+		return name
+	}
+}
+
+// formatCacheWaste 显示浪费的金额及其占缓存写入的比例
+func formatCacheWaste(usd float64, tokens, written int) string {
+	if tokens == 0 {
+		return "-"
+	}
+	if written <= 0 {
+		return fmt.Sprintf("$%.4f", usd)
+	}
+	return fmt.Sprintf("$%.4f (%.0f%%)", usd, float64(tokens)/float64(written)*100)
+}
+
+// hitRatio 按缓存命中率高低着色
+func (f *Formatter) hitRatio(ratio float64) string {
+	text := fmt.Sprintf("%.1f%%", ratio*100)
+	switch {
+	case ratio >= 0.8:
+		return f.Colors.Success(text)
+	case ratio >= 0.5:
+		return f.Colors.Warning(text)
+	default:
+		return f.Colors.Error(text)
+	}
+}
@@ -0,0 +1,174 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// FormatCCUsageVerify 格式化 ccusage 对比报告为表格
+func (f *Formatter) FormatCCUsageVerify(report *models.CCUsageVerifyReport) (string, error) {
+	var output strings.Builder
+
+	output.WriteString(f.Colors.IconHeader("🔎", i18n.T("fmt.ccusage.title", report.Source), BrightBlue))
+	output.WriteString("\n")
+
+	if len(report.Rows) == 0 {
+		output.WriteString("\n   📝 " + i18n.T("fmt.ccusage.empty") + "\n")
+		return output.String(), nil
+	}
+
+	t := table.NewWriter()
+	t.AppendHeader(ccusageVerifyHeader(f.Colors.Header))
+
+	for _, row := range report.Rows {
+		status := f.Colors.Success(i18n.T("fmt.ccusage.ok"))
+		if row.Mismatch {
+			status = f.Colors.Error(i18n.T("fmt.ccusage.mismatch"))
+		}
+		change := row.CostDiffPct
+		t.AppendRow(table.Row{
+			f.Colors.BrightCyan(row.Period),
+			formatNumber(row.CCUsageTokens),
+			formatNumber(row.Tokens),
+			f.signedNumber(row.TokenDiff),
+			fmt.Sprintf("$%.4f", row.CCUsageCost),
+			fmt.Sprintf("$%.4f", row.CostUSD),
+			f.signedCost(row.CostDiff),
+			f.percentChange(&change),
+			status,
+		})
+	}
+
+	summary := report.Summary
+	change := summary.CostDiffPct
+	t.AppendFooter(table.Row{
+		f.Colors.Bold(i18n.T("common.total")),
+		f.Colors.Bold(formatNumber(summary.CCUsageTokens)),
+		f.Colors.Bold(formatNumber(summary.Tokens)),
+		f.signedNumber(summary.TokenDiff),
+		f.Colors.Bold(fmt.Sprintf("$%.4f", summary.CCUsageCost)),
+		f.Colors.Bold(fmt.Sprintf("$%.4f", summary.CostUSD)),
+		f.signedCost(summary.CostDiff),
+		f.percentChange(&change),
+		"",
+	})
+
+	t.SetStyle(table.StyleColoredBright)
+	output.WriteString(t.Render())
+	output.WriteString("\n\n")
+
+	line := i18n.T("fmt.ccusage.summary", len(report.Rows), report.Mismatches, report.Tolerance)
+	if report.Mismatches > 0 {
+		output.WriteString("   " + f.Colors.Warning(line) + "\n")
+	} else {
+		output.WriteString("   " + f.Colors.Success(line) + "\n")
+	}
+	output.WriteString("   " + f.Colors.Dim(i18n.T("fmt.ccusage.price_hint", report.PriceVersion)) + "\n")
+
+	return output.String(), nil
+}
+
+// FormatCCUsageVerifyJSON 格式化 ccusage 对比报告为JSON
+func (f *Formatter) FormatCCUsageVerifyJSON(report *models.CCUsageVerifyReport) (string, error) {
+	return marshalReport(report)
+}
+
+// FormatCCUsageVerifyCSV 格式化 ccusage 对比报告为CSV，每个周期一行，不含合计
+func (f *Formatter) FormatCCUsageVerifyCSV(report *models.CCUsageVerifyReport) (string, error) {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+
+	// 写入标题行（使用与语言无关的稳定标识，便于脚本处理）
+	headers := []string{
+		"period", "start_time", "end_time", "ccusage_tokens", "tokens", "token_diff",
+		"ccusage_cost_usd", "cost_usd", "cost_diff_usd", "cost_diff_pct", "entries", "mismatch",
+	}
+	if err := writer.Write(headers); err != nil {
+		return "", err
+	}
+
+	for _, row := range report.Rows {
+		record := []string{
+			row.Period,
+			formatTimestamp(row.StartTime),
+			formatTimestamp(row.EndTime),
+			fmt.Sprintf("%d", row.CCUsageTokens),
+			fmt.Sprintf("%d", row.Tokens),
+			fmt.Sprintf("%d", row.TokenDiff),
+			fmt.Sprintf("%.4f", row.CCUsageCost),
+			fmt.Sprintf("%.4f", row.CostUSD),
+			fmt.Sprintf("%.4f", row.CostDiff),
+			fmt.Sprintf("%.2f", row.CostDiffPct),
+			fmt.Sprintf("%d", row.Entries),
+			fmt.Sprintf("%t", row.Mismatch),
+		}
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return builder.String(), writer.Error()
+}
+
+// FormatCCUsageVerifyMarkdown 格式化 ccusage 对比报告为Markdown
+func (f *Formatter) FormatCCUsageVerifyMarkdown(report *models.CCUsageVerifyReport) (string, error) {
+	var md markdownWriter
+	md.title(i18n.T("fmt.ccusage.title", report.Source))
+
+	if len(report.Rows) == 0 {
+		md.text(i18n.T("fmt.ccusage.empty"))
+		return md.String(), nil
+	}
+
+	md.note(i18n.T("fmt.ccusage.summary", len(report.Rows), report.Mismatches, report.Tolerance))
+
+	var rows []table.Row
+	for _, row := range report.Rows {
+		rows = append(rows, ccusageVerifyMarkdownRow(row.Period, row))
+	}
+	md.table(ccusageVerifyHeader(func(s string) string { return s }), rows,
+		ccusageVerifyMarkdownRow(i18n.T("common.total"), report.Summary))
+	md.note(i18n.T("fmt.ccusage.price_hint", report.PriceVersion))
+
+	return md.String(), nil
+}
+
+// ccusageVerifyMarkdownRow 对比报告的一行（Markdown 用，不着色）
+func ccusageVerifyMarkdownRow(label string, row models.CCUsageVerifyRow) table.Row {
+	status := i18n.T("fmt.ccusage.ok")
+	if row.Mismatch {
+		status = i18n.T("fmt.ccusage.mismatch")
+	}
+	return table.Row{
+		label,
+		formatNumber(row.CCUsageTokens),
+		formatNumber(row.Tokens),
+		fmt.Sprintf("%+d", row.TokenDiff),
+		fmt.Sprintf("$%.4f", row.CCUsageCost),
+		fmt.Sprintf("$%.4f", row.CostUSD),
+		fmt.Sprintf("%+.4f", row.CostDiff),
+		fmt.Sprintf("%+.1f%%", row.CostDiffPct),
+		status,
+	}
+}
+
+// ccusageVerifyHeader 对比表的表头，header 用于为表格输出着色
+func ccusageVerifyHeader(header func(string) string) table.Row {
+	return table.Row{
+		header(i18n.T("col.period")),
+		header(i18n.T("col.ccusage_tokens")),
+		header(i18n.T("col.total_tokens")),
+		header(i18n.T("col.tokens_delta")),
+		header(i18n.T("col.ccusage_cost")),
+		header(i18n.T("col.cost_usd")),
+		header(i18n.T("col.cost_delta")),
+		header(i18n.T("col.change")),
+		header(i18n.T("col.status")),
+	}
+}
@@ -0,0 +1,231 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// FormatChargeback 格式化成本分摊报告为表格
+func (f *Formatter) FormatChargeback(report *models.ChargebackReport) (string, error) {
+	var output strings.Builder
+
+	output.WriteString(f.Colors.IconHeader("🧾", i18n.T("fmt.chargeback.title"), BrightGreen))
+	output.WriteString("\n")
+
+	if len(report.Months) == 0 {
+		output.WriteString("\n   📝 " + i18n.T("fmt.chargeback.empty") + "\n")
+		return output.String(), nil
+	}
+	if len(report.Rules) == 0 {
+		output.WriteString(f.Colors.Warning("   ⚠️  "+i18n.T("fmt.chargeback.no_rules")) + "\n")
+	}
+	output.WriteString(f.Colors.Dim("   * " + i18n.T("fmt.chargeback.price_hint", report.PriceVersion) + "\n\n"))
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		f.Colors.Header(i18n.T("col.month")),
+		f.Colors.Header(i18n.T("col.cost_center")),
+		f.Colors.Header(i18n.T("col.projects")),
+		f.Colors.Header(i18n.T("col.cost_usd")),
+		f.Colors.Header(i18n.T("col.percentage")),
+	})
+
+	for i, month := range report.Months {
+		if i > 0 {
+			t.AppendSeparator()
+		}
+		for _, center := range month.Centers {
+			t.AppendRow(table.Row{
+				f.Colors.BrightCyan(month.Month),
+				center.CostCenter,
+				formatNumber(center.Projects),
+				fmt.Sprintf("$%.4f", center.Cost),
+				formatShare(center.Cost, month.TotalCost),
+			})
+			if f.ShowDetails {
+				f.appendChargebackLines(t, report.Lines, month.Month, center.CostCenter)
+			}
+		}
+		if month.UnallocatedCost > 0 {
+			t.AppendRow(table.Row{
+				f.Colors.BrightCyan(month.Month),
+				f.Colors.Warning(i18n.T("fmt.chargeback.unallocated")),
+				"",
+				f.Colors.Warning(fmt.Sprintf("$%.4f", month.UnallocatedCost)),
+				formatShare(month.UnallocatedCost, month.TotalCost),
+			})
+			if f.ShowDetails {
+				f.appendChargebackLines(t, report.Lines, month.Month, "")
+			}
+		}
+	}
+
+	summary := report.Summary
+	t.AppendFooter(table.Row{
+		f.Colors.Bold(i18n.T("common.total")),
+		"",
+		"",
+		f.Colors.Bold(fmt.Sprintf("$%.4f", summary.TotalCost)),
+		"",
+	})
+
+	t.SetStyle(table.StyleColoredBright)
+	output.WriteString(t.Render())
+	output.WriteString("\n")
+	output.WriteString("   " + i18n.T("fmt.chargeback.summary",
+		f.Colors.Success(fmt.Sprintf("$%.4f", summary.AllocatedCost)),
+		formatShare(summary.AllocatedCost, summary.TotalCost),
+		f.Colors.Warning(fmt.Sprintf("$%.4f", summary.UnallocatedCost))) + "\n\n")
+
+	// 规则命中情况，未命中任何项目的规则可能已经过期
+	if len(report.Rules) > 0 {
+		output.WriteString("   " + f.Colors.Bold(i18n.T("fmt.chargeback.rules_title")) + "\n")
+		rules := table.NewWriter()
+		rules.AppendHeader(table.Row{
+			f.Colors.Header(i18n.T("col.rule")),
+			f.Colors.Header(i18n.T("col.projects")),
+			f.Colors.Header(i18n.T("col.cost_usd")),
+		})
+		for _, hit := range report.Rules {
+			projects := formatNumber(hit.Projects)
+			if hit.Projects == 0 {
+				projects = f.Colors.Warning(projects)
+			}
+			rules.AppendRow(table.Row{hit.Rule, projects, fmt.Sprintf("$%.4f", hit.Cost)})
+		}
+		rules.SetStyle(table.StyleColoredBright)
+		output.WriteString(rules.Render())
+		output.WriteString("\n\n")
+	}
+
+	return output.String(), nil
+}
+
+// appendChargebackLines 详细模式下在成本中心下方列出分摊到该中心的项目
+func (f *Formatter) appendChargebackLines(t table.Writer, lines []models.ChargebackLine, month, center string) {
+	for _, line := range lines {
+		if line.Month != month || line.CostCenter != center {
+			continue
+		}
+		name := line.ProjectName
+		if line.Rule != "" {
+			name += " (" + line.Rule + ")"
+		}
+		t.AppendRow(table.Row{
+			"",
+			f.Colors.Dim("  └─ " + name),
+			"",
+			f.Colors.Dim(fmt.Sprintf("$%.4f", line.Cost)),
+			f.Colors.Dim(fmt.Sprintf("%.0f%%", line.Percent)),
+		})
+	}
+}
+
+// formatShare 显示占比
+func formatShare(part, total float64) string {
+	if total <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", part/total*100)
+}
+
+// FormatChargebackJSON 格式化成本分摊报告为JSON
+func (f *Formatter) FormatChargebackJSON(report *models.ChargebackReport) (string, error) {
+	return marshalReport(report)
+}
+
+// FormatChargebackCSV 格式化成本分摊明细为CSV，供财务系统导入
+// 每行是某月某项目分到某个成本中心的金额；列顺序固定，cost_center 为空表示未分摊
+func (f *Formatter) FormatChargebackCSV(report *models.ChargebackReport) (string, error) {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+
+	headers := []string{
+		"month", "cost_center", "rule", "project", "project_path", "remote",
+		"percent", "cost_usd", "price_version",
+	}
+	if err := writer.Write(headers); err != nil {
+		return "", err
+	}
+
+	for _, line := range report.Lines {
+		row := []string{
+			line.Month,
+			line.CostCenter,
+			line.Rule,
+			line.ProjectName,
+			line.ProjectPath,
+			line.Remote,
+			fmt.Sprintf("%g", line.Percent),
+			fmt.Sprintf("%.6f", line.Cost),
+			report.PriceVersion,
+		}
+		if err := writer.Write(row); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return builder.String(), writer.Error()
+}
+
+// FormatChargebackMarkdown 格式化成本分摊报告为Markdown
+func (f *Formatter) FormatChargebackMarkdown(report *models.ChargebackReport) (string, error) {
+	var md markdownWriter
+	md.title(i18n.T("fmt.chargeback.title"))
+
+	if len(report.Months) == 0 {
+		md.text(i18n.T("fmt.chargeback.empty"))
+		return md.String(), nil
+	}
+
+	summary := report.Summary
+	md.summary(
+		i18n.T("col.cost_usd"), fmt.Sprintf("$%.4f", summary.TotalCost),
+		i18n.T("fmt.chargeback.allocated_label"), fmt.Sprintf("$%.4f", summary.AllocatedCost),
+		i18n.T("fmt.chargeback.unallocated"), fmt.Sprintf("$%.4f", summary.UnallocatedCost),
+	)
+	if len(report.Rules) == 0 {
+		md.note(i18n.T("fmt.chargeback.no_rules"))
+	}
+
+	var rows []table.Row
+	for _, month := range report.Months {
+		for _, center := range month.Centers {
+			rows = append(rows, table.Row{
+				month.Month, center.CostCenter, formatNumber(center.Projects),
+				fmt.Sprintf("$%.4f", center.Cost), formatShare(center.Cost, month.TotalCost),
+			})
+		}
+		if month.UnallocatedCost > 0 {
+			rows = append(rows, table.Row{
+				month.Month, i18n.T("fmt.chargeback.unallocated"), "",
+				fmt.Sprintf("$%.4f", month.UnallocatedCost), formatShare(month.UnallocatedCost, month.TotalCost),
+			})
+		}
+	}
+	md.table(table.Row{
+		i18n.T("col.month"), i18n.T("col.cost_center"), i18n.T("col.projects"),
+		i18n.T("col.cost_usd"), i18n.T("col.percentage"),
+	}, rows, table.Row{
+		i18n.T("common.total"), "", "",
+		fmt.Sprintf("$%.4f", summary.TotalCost), "",
+	})
+
+	if len(report.Rules) > 0 {
+		md.section(i18n.T("fmt.chargeback.rules_title"))
+		var ruleRows []table.Row
+		for _, hit := range report.Rules {
+			ruleRows = append(ruleRows, table.Row{hit.Rule, formatNumber(hit.Projects), fmt.Sprintf("$%.4f", hit.Cost)})
+		}
+		md.table(table.Row{i18n.T("col.rule"), i18n.T("col.projects"), i18n.T("col.cost_usd")}, ruleRows, nil)
+	}
+	md.note(i18n.T("fmt.chargeback.price_hint", report.PriceVersion))
+
+	return md.String(), nil
+}
@@ -0,0 +1,370 @@
+package formatter
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// barEighths 按八分之一高度递增的方块字符，用于柱顶的部分填充
+var barEighths = []string{" ", "▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+
+// chartColumn 柱状图中的一列
+type chartColumn struct {
+	Value     float64
+	Upper     float64 // 置信区间上限等参考值，0表示不显示
+	Projected bool    // 预测值用浅色阴影显示
+}
+
+// barChart ASCII柱状图，每列一个数据点
+type barChart struct {
+	Height      int
+	Columns     []chartColumn
+	StartLabel  string               // X轴左端标签
+	EndLabel    string               // X轴右端标签
+	FormatValue func(float64) string // Y轴刻度格式
+}
+
+// render 渲染柱状图
+func (c *barChart) render(colors *ColorSettings) string {
+	height := c.Height
+	if height <= 0 {
+		height = 8
+	}
+
+	var max float64
+	for _, column := range c.Columns {
+		max = math.Max(max, math.Max(column.Value, column.Upper))
+	}
+	if max <= 0 {
+		max = 1
+	}
+
+	// Y轴刻度：顶部、中部和零
+	labels := map[int]string{
+		height - 1: c.FormatValue(max),
+		height / 2: c.FormatValue(max / 2),
+		0:          c.FormatValue(0),
+	}
+	labelWidth := 0
+	for _, label := range labels {
+		labelWidth = int(math.Max(float64(labelWidth), float64(text.RuneWidthWithoutEscSequences(label))))
+	}
+
+	var output strings.Builder
+	for row := height - 1; row >= 0; row-- {
+		output.WriteString(padLeft(labels[row], labelWidth) + " ┤")
+		bottom := max * float64(row) / float64(height)
+		step := max / float64(height)
+
+		for _, column := range c.Columns {
+			cell := " "
+			switch {
+			case column.Projected && column.Value >= bottom+step/2:
+				cell = colors.Cyan("░")
+			case !column.Projected && column.Value > bottom:
+				eighths := int(math.Round((column.Value - bottom) / step * 8))
+				if eighths > 8 {
+					eighths = 8
+				}
+				if eighths > 0 {
+					cell = colors.BrightBlue(barEighths[eighths])
+				}
+			}
+			if cell == " " && column.Upper > bottom && column.Upper <= bottom+step {
+				cell = colors.Dim("·")
+			}
+			output.WriteString(cell)
+		}
+		output.WriteString("\n")
+	}
+
+	// X轴
+	output.WriteString(strings.Repeat(" ", labelWidth) + " └" + strings.Repeat("─", len(c.Columns)) + "\n")
+	axis := c.StartLabel
+	gap := len(c.Columns) - text.RuneWidthWithoutEscSequences(c.StartLabel) - text.RuneWidthWithoutEscSequences(c.EndLabel)
+	if gap > 0 {
+		axis += strings.Repeat(" ", gap) + c.EndLabel
+	}
+	output.WriteString(strings.Repeat(" ", labelWidth+2) + axis + "\n")
+
+	return output.String()
+}
+
+// padLeft 按显示宽度左侧补齐空格
+func padLeft(value string, width int) string {
+	if padding := width - text.RuneWidthWithoutEscSequences(value); padding > 0 {
+		return strings.Repeat(" ", padding) + value
+	}
+	return value
+}
+
+// defaultTerminalWidth 无法检测终端宽度时使用的列数
+const defaultTerminalWidth = 100
+
+// 条形图宽度的上下限
+const (
+	minChartBarWidth = 10
+	maxChartBarWidth = 80
+)
+
+// tokenGlyphs Token类型在堆叠条形图中的字符：输入、输出、缓存创建、缓存读取
+var tokenGlyphs = []string{"█", "▓", "▒", "░"}
+
+// chartRow 横向条形图中的一行
+type chartRow struct {
+	Label    string
+	Segments []BarSegment
+	Value    string // 行尾显示的数值
+	Trend    []float64
+}
+
+// terminalWidth 图表可用的宽度：显式设置 > COLUMNS 环境变量 > 终端检测 > 默认值
+func (f *Formatter) terminalWidth() int {
+	if f.Width > 0 {
+		return f.Width
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if width := ttyWidth(); width > 0 {
+		return width
+	}
+	return defaultTerminalWidth
+}
+
+// tokenSegments 将四类Token拆成堆叠条形图的各段
+func tokenSegments(input, output, cacheCreation, cacheRead int) []BarSegment {
+	return []BarSegment{
+		{Value: float64(input), Glyph: tokenGlyphs[0], Color: BrightBlue},
+		{Value: float64(output), Glyph: tokenGlyphs[1], Color: BrightMagenta},
+		{Value: float64(cacheCreation), Glyph: tokenGlyphs[2], Color: BrightGreen},
+		{Value: float64(cacheRead), Glyph: tokenGlyphs[3], Color: BrightCyan},
+	}
+}
+
+// tokenChartRow 创建Token构成的条形图行，返回行和四类Token之和（用作缩放基准）
+// 注意这里的合计包含缓存Token，与表格中的“总Token数”口径不同
+func tokenChartRow(label string, input, output, cacheCreation, cacheRead int) (chartRow, float64) {
+	sum := input + output + cacheCreation + cacheRead
+	return chartRow{
+		Label:    label,
+		Segments: tokenSegments(input, output, cacheCreation, cacheRead),
+		Value:    formatNumber(sum),
+	}, float64(sum)
+}
+
+// tokenLegend Token堆叠条形图的图例
+func (f *Formatter) tokenLegend() string {
+	names := []string{i18n.T("col.input"), i18n.T("col.output"), i18n.T("col.cache_creation"), i18n.T("col.cache_read")}
+	segments := tokenSegments(1, 1, 1, 1)
+	var parts []string
+	for i, segment := range segments {
+		parts = append(parts, f.Colors.Colorize(segment.Glyph, segment.Color)+" "+names[i])
+	}
+	return strings.Join(parts, "  ")
+}
+
+// costSegments 单段的成本条形图
+func costSegments(cost float64) []BarSegment {
+	return []BarSegment{{Value: cost, Glyph: "█", Color: BrightGreen}}
+}
+
+// writeBarRows 写入横向条形图，条形宽度随终端宽度调整
+// max 为所有行共同的缩放基准；行中带有 Trend 时在行尾附加迷你趋势图
+func (f *Formatter) writeBarRows(output *strings.Builder, title, legend string, rows []chartRow, max float64) {
+	if len(rows) == 0 {
+		return
+	}
+
+	output.WriteString("   " + f.Colors.Bold(title))
+	if legend != "" {
+		output.WriteString("   " + legend)
+	}
+	output.WriteString("\n")
+
+	labelWidth, valueWidth, trendWidth := 0, 0, 0
+	for _, row := range rows {
+		labelWidth = intMax(labelWidth, text.RuneWidthWithoutEscSequences(row.Label))
+		valueWidth = intMax(valueWidth, text.RuneWidthWithoutEscSequences(row.Value))
+		trendWidth = intMax(trendWidth, len(row.Trend))
+	}
+	if trendWidth > 0 {
+		trendWidth += 2
+	}
+
+	// 缩进3列、标签后" │"2列、数值前空格1列
+	barWidth := f.terminalWidth() - 3 - labelWidth - 2 - 1 - valueWidth - trendWidth
+	if barWidth > maxChartBarWidth {
+		barWidth = maxChartBarWidth
+	}
+	if barWidth < minChartBarWidth {
+		barWidth = minChartBarWidth
+	}
+
+	for _, row := range rows {
+		output.WriteString("   " + row.Label + strings.Repeat(" ", labelWidth-text.RuneWidthWithoutEscSequences(row.Label)) + " │")
+		output.WriteString(f.Colors.StackedBar(row.Segments, max, barWidth, " "))
+		output.WriteString(" " + padLeft(row.Value, valueWidth))
+		if len(row.Trend) > 0 {
+			output.WriteString("  " + f.Colors.Info(sparkline(row.Trend)))
+		}
+		output.WriteString("\n")
+	}
+	output.WriteString("\n")
+}
+
+// writeSparkline 写入一行迷你趋势图，keys 为各数据点的标签（如日期）
+// 数据点超过可用宽度时只显示最近的部分
+func (f *Formatter) writeSparkline(output *strings.Builder, label string, values []float64, keys []string) {
+	if len(values) == 0 {
+		return
+	}
+	available := f.terminalWidth() - 3 - text.RuneWidthWithoutEscSequences(label) - 2
+	if available < minChartBarWidth {
+		available = minChartBarWidth
+	}
+	if len(values) > available {
+		values = values[len(values)-available:]
+		keys = keys[len(keys)-available:]
+	}
+	output.WriteString("   " + f.Colors.Bold(label) + "  " + f.Colors.Info(sparkline(values)))
+	if len(keys) > 0 {
+		output.WriteString("  " + f.Colors.Dim(keys[0]+" ~ "+keys[len(keys)-1]))
+	}
+	output.WriteString("\n\n")
+}
+
+// intMax 返回两个整数中的较大者
+func intMax(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// writeDailyCharts 写入每日成本条形图、Token构成堆叠条形图和成本趋势
+func (f *Formatter) writeDailyCharts(output *strings.Builder, report *models.DailyReport) {
+	var costRows, tokenRows []chartRow
+	var maxCost, maxTokens float64
+	for _, day := range report.DailyData {
+		maxCost = math.Max(maxCost, day.CostUSD)
+		costRows = append(costRows, chartRow{
+			Label:    day.Date,
+			Segments: costSegments(day.CostUSD),
+			Value:    fmt.Sprintf("$%.2f", day.CostUSD),
+		})
+		row, sum := tokenChartRow(day.Date, day.InputTokens, day.OutputTokens, day.CacheCreationTokens, day.CacheReadTokens)
+		maxTokens = math.Max(maxTokens, sum)
+		tokenRows = append(tokenRows, row)
+	}
+
+	f.writeBarRows(output, "📊 "+i18n.T("fmt.chart.daily_cost"), "", costRows, maxCost)
+	f.writeBarRows(output, "📊 "+i18n.T("fmt.chart.token_mix"), f.tokenLegend(), tokenRows, maxTokens)
+
+	// 趋势按日期升序，与表格的排序方式无关
+	days := append([]models.DailyDataPoint{}, report.DailyData...)
+	sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+	values := make([]float64, len(days))
+	keys := make([]string, len(days))
+	for i, day := range days {
+		values[i] = day.CostUSD
+		keys[i] = day.Date
+	}
+	f.writeSparkline(output, i18n.T("fmt.chart.cost_trend"), values, keys)
+}
+
+// writeBlocksCharts 写入各计费窗口的Token构成和成本趋势
+func (f *Formatter) writeBlocksCharts(output *strings.Builder, report *models.BlocksReport) {
+	var rows []chartRow
+	var maxTokens float64
+	values := make([]float64, 0, len(report.Blocks))
+	keys := make([]string, 0, len(report.Blocks))
+	for _, block := range report.Blocks {
+		label := block.StartTime.Format("01-02 15:04")
+		if block.IsActive {
+			label += " ●"
+		}
+		row, sum := tokenChartRow(label, block.Tokens.InputTokens, block.Tokens.OutputTokens, block.Tokens.CacheCreationTokens, block.Tokens.CacheReadTokens)
+		row.Value += fmt.Sprintf("  $%.2f", block.CostUSD)
+		maxTokens = math.Max(maxTokens, sum)
+		rows = append(rows, row)
+		values = append(values, block.CostUSD)
+		keys = append(keys, block.StartTime.Format("01-02 15:04"))
+	}
+
+	f.writeBarRows(output, "📊 "+i18n.T("fmt.chart.block_tokens"), f.tokenLegend(), rows, maxTokens)
+	f.writeSparkline(output, i18n.T("fmt.chart.cost_trend"), values, keys)
+}
+
+// writeUsageCharts 写入 analyze 报告的图表：各模型Token构成、每日趋势和各项目趋势
+func (f *Formatter) writeUsageCharts(output *strings.Builder, stats *models.UsageStats) {
+	// 各模型的Token构成
+	names := make([]string, 0, len(stats.ModelStats))
+	for name := range stats.ModelStats {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := stats.ModelStats[names[i]], stats.ModelStats[names[j]]
+		return a.GetTotalTokens() > b.GetTotalTokens()
+	})
+
+	var rows []chartRow
+	var maxTokens float64
+	for _, name := range names {
+		usage := stats.ModelStats[name]
+		row, sum := tokenChartRow(name, usage.InputTokens, usage.OutputTokens, usage.CacheCreationTokens, usage.CacheReadTokens)
+		maxTokens = math.Max(maxTokens, sum)
+		rows = append(rows, row)
+	}
+	f.writeBarRows(output, "📊 "+i18n.T("fmt.chart.model_tokens"), f.tokenLegend(), rows, maxTokens)
+
+	// 每日Token趋势
+	dates := make([]string, 0, len(stats.DailyStats))
+	for date := range stats.DailyStats {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	values := make([]float64, len(dates))
+	for i, date := range dates {
+		usage := stats.DailyStats[date]
+		values[i] = float64(usage.GetTotalTokens())
+	}
+	f.writeSparkline(output, i18n.T("fmt.chart.token_trend"), values, dates)
+
+	// 各项目最近的每日趋势（行内迷你图）
+	projects := make([]models.ProjectStats, 0, len(stats.ProjectStats))
+	var trendEnd time.Time
+	for _, project := range stats.ProjectStats {
+		projects = append(projects, project)
+		if project.LastActivity.After(trendEnd) {
+			trendEnd = project.LastActivity
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Tokens.GetTotalTokens() > projects[j].Tokens.GetTotalTokens()
+	})
+
+	rows = rows[:0]
+	maxTokens = 0
+	for _, project := range projects {
+		label := project.ProjectName
+		if project.ProjectPath != "" {
+			label = projectLabel(project.ProjectPath)
+		}
+		tokens := project.Tokens
+		row, sum := tokenChartRow(label, tokens.InputTokens, tokens.OutputTokens, tokens.CacheCreationTokens, tokens.CacheReadTokens)
+		row.Trend = projectTrend(project, trendEnd, projectTrendDays)
+		maxTokens = math.Max(maxTokens, sum)
+		rows = append(rows, row)
+	}
+	f.writeBarRows(output, "📊 "+i18n.T("fmt.chart.project_tokens", projectTrendDays), f.tokenLegend(), rows, maxTokens)
+}
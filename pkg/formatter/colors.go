@@ -144,32 +144,65 @@ func (c *ColorSettings) ProgressBar(current, total int, width int) string {
 		percentage = 1.0
 	}
 	
-	filled := int(percentage * float64(width))
-	// 确保filled不超过width
-	if filled > width {
-		filled = width
-	}
-	if filled < 0 {
-		filled = 0
+	bar := c.StackedBar([]BarSegment{{Value: percentage, Glyph: "█", Color: BrightGreen}}, 1, width, "░")
+	
+	if !c.Enabled {
+		return fmt.Sprintf("[%s] %.1f%%", bar, percentage*100)
 	}
 	
-	empty := width - filled
-	// 确保empty不为负数
-	if empty < 0 {
-		empty = 0
+	return fmt.Sprintf("[%s] %s%.1f%%%s", bar, BrightYellow, percentage*100, Reset)
+}
+
+// BarSegment 堆叠条形图中的一段
+type BarSegment struct {
+	Value float64
+	Glyph string // 每段使用不同字符，关闭颜色后仍可区分
+	Color string
+}
+
+// StackedBar 按最大值缩放绘制堆叠条形图，剩余部分用 fill 填充（以暗色显示）
+func (c *ColorSettings) StackedBar(segments []BarSegment, max float64, width int, fill string) string {
+	if width <= 0 {
+		return ""
 	}
 	
-	if !c.Enabled {
-		return fmt.Sprintf("[%s%s] %.1f%%", 
-			strings.Repeat("█", filled),
-			strings.Repeat("░", empty),
-			percentage*100)
+	var result strings.Builder
+	var cumulative float64
+	filled := 0
+	for _, segment := range segments {
+		if segment.Value <= 0 || max <= 0 {
+			continue
+		}
+		cumulative += segment.Value
+		// 按累计值取整，避免各段分别取整造成总长度偏差
+		end := int(cumulative / max * float64(width))
+		if end > width {
+			end = width
+		}
+		if end > filled {
+			result.WriteString(c.Colorize(strings.Repeat(segment.Glyph, end-filled), segment.Color))
+			filled = end
+		}
 	}
 	
-	return fmt.Sprintf("[%s%s%s%s] %s%.1f%%%s",
-		BrightGreen, strings.Repeat("█", filled),
-		Dim, strings.Repeat("░", empty),
-		BrightYellow, percentage*100, Reset)
+	if filled < width {
+		result.WriteString(c.Dim(strings.Repeat(fill, width-filled)))
+	}
+	return result.String()
 }
 
- 
\ No newline at end of file
+// heatPalette 热力图从低到高使用的颜色
+var heatPalette = []string{Dim, Blue, Cyan, Green, Yellow, BrightRed}
+
+// Heat 按强度（0~1）给文本着色，用于热力图
+func (c *ColorSettings) Heat(text string, intensity float64) string {
+	if intensity <= 0 {
+		return c.Dim(text)
+	}
+	if intensity > 1 {
+		intensity = 1
+	}
+	// 非零值至少使用第二档颜色，以便与空白格区分
+	level := 1 + int(intensity*float64(len(heatPalette)-2)+0.5)
+	return c.Colorize(text, heatPalette[level])
+}
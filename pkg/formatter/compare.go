@@ -0,0 +1,209 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// FormatCompare 格式化时间段对比报告为表格
+func (f *Formatter) FormatCompare(report *models.CompareReport) (string, error) {
+	var output strings.Builder
+
+	// 添加标题
+	output.WriteString(f.Colors.IconHeader("⚖️", i18n.T("fmt.compare.title"), BrightBlue))
+	output.WriteString("\n")
+	output.WriteString(fmt.Sprintf("   A: %s\n", f.Colors.Info(formatPeriod(report.PeriodA))))
+	output.WriteString(fmt.Sprintf("   B: %s\n\n", f.Colors.Info(formatPeriod(report.PeriodB))))
+
+	// 总体对比
+	summary := report.Summary
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		f.Colors.Header(i18n.T("col.metric")),
+		f.Colors.Header("A"),
+		f.Colors.Header("B"),
+		f.Colors.Header(i18n.T("col.delta")),
+		f.Colors.Header(i18n.T("col.change")),
+	})
+	t.AppendRows([]table.Row{
+		{i18n.T("col.total_tokens"), formatNumber(summary.A.Tokens), formatNumber(summary.B.Tokens),
+			f.signedNumber(summary.Delta.Tokens), f.percentChange(summary.Change.Tokens)},
+		{i18n.T("col.cost_usd"), fmt.Sprintf("$%.4f", summary.A.CostUSD), fmt.Sprintf("$%.4f", summary.B.CostUSD),
+			f.signedCost(summary.Delta.CostUSD), f.percentChange(summary.Change.CostUSD)},
+		{i18n.T("col.sessions"), formatNumber(summary.A.Sessions), formatNumber(summary.B.Sessions),
+			f.signedNumber(summary.Delta.Sessions), f.percentChange(summary.Change.Sessions)},
+		{i18n.T("col.messages"), formatNumber(summary.A.Messages), formatNumber(summary.B.Messages),
+			f.signedNumber(summary.Delta.Messages), f.percentChange(summary.Change.Messages)},
+	})
+	t.SetStyle(table.StyleColoredBright)
+	output.WriteString(t.Render())
+	output.WriteString("\n\n")
+
+	// 变化最大的模型和项目
+	f.writeCompareContributors(&output, i18n.T("fmt.compare.by_model"), i18n.T("col.model"), report.Models, false)
+	f.writeCompareContributors(&output, i18n.T("fmt.compare.by_project"), i18n.T("col.project"), report.Projects, true)
+
+	return output.String(), nil
+}
+
+// writeCompareContributors 写入对成本变化贡献最大的条目
+func (f *Formatter) writeCompareContributors(output *strings.Builder, title, nameColumn string, items []models.CompareItem, isProject bool) {
+	output.WriteString(f.Colors.Bold("   "+title) + "\n")
+	if len(items) == 0 {
+		output.WriteString("   " + f.Colors.Dim(i18n.T("common.none")) + "\n\n")
+		return
+	}
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		f.Colors.Header(nameColumn),
+		f.Colors.Header(i18n.T("col.cost_a")),
+		f.Colors.Header(i18n.T("col.cost_b")),
+		f.Colors.Header(i18n.T("col.delta")),
+		f.Colors.Header(i18n.T("col.change")),
+		f.Colors.Header(i18n.T("col.tokens_delta")),
+		f.Colors.Header(i18n.T("col.share_of_change")),
+	})
+
+	limit := f.TopN
+	if limit <= 0 || limit > len(items) {
+		limit = len(items)
+	}
+	for _, item := range items[:limit] {
+		name := item.Name
+		if isProject {
+			name = projectLabel(name)
+		}
+		t.AppendRow(table.Row{
+			f.Colors.BrightCyan(name),
+			fmt.Sprintf("$%.4f", item.A.CostUSD),
+			fmt.Sprintf("$%.4f", item.B.CostUSD),
+			f.signedCost(item.Delta.CostUSD),
+			f.percentChange(item.Change.CostUSD),
+			f.signedNumber(item.Delta.Tokens),
+			fmt.Sprintf("%.0f%%", item.Share*100),
+		})
+	}
+	if limit < len(items) {
+		output.WriteString(f.Colors.Dim("   * "+i18n.T("fmt.compare.more", len(items)-limit)) + "\n")
+	}
+
+	t.SetStyle(table.StyleColoredBright)
+	output.WriteString(t.Render())
+	output.WriteString("\n\n")
+}
+
+// FormatCompareJSON 格式化时间段对比报告为JSON
+func (f *Formatter) FormatCompareJSON(report *models.CompareReport) (string, error) {
+	return marshalReport(report)
+}
+
+// FormatCompareCSV 格式化时间段对比报告为CSV
+func (f *Formatter) FormatCompareCSV(report *models.CompareReport) (string, error) {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+
+	// 写入标题行（使用与语言无关的稳定标识，便于脚本处理）
+	headers := []string{
+		"dimension", "name",
+		"a_tokens", "b_tokens", "delta_tokens", "tokens_pct",
+		"a_cost_usd", "b_cost_usd", "delta_cost_usd", "cost_pct",
+		"a_sessions", "b_sessions", "delta_sessions", "sessions_pct",
+		"a_messages", "b_messages", "delta_messages", "messages_pct",
+		"share_of_cost_change",
+	}
+	if err := writer.Write(headers); err != nil {
+		return "", err
+	}
+
+	write := func(dimension string, item models.CompareItem) error {
+		return writer.Write([]string{
+			dimension, item.Name,
+			fmt.Sprintf("%d", item.A.Tokens), fmt.Sprintf("%d", item.B.Tokens),
+			fmt.Sprintf("%d", item.Delta.Tokens), formatPercentCSV(item.Change.Tokens),
+			fmt.Sprintf("%.4f", item.A.CostUSD), fmt.Sprintf("%.4f", item.B.CostUSD),
+			fmt.Sprintf("%.4f", item.Delta.CostUSD), formatPercentCSV(item.Change.CostUSD),
+			fmt.Sprintf("%d", item.A.Sessions), fmt.Sprintf("%d", item.B.Sessions),
+			fmt.Sprintf("%d", item.Delta.Sessions), formatPercentCSV(item.Change.Sessions),
+			fmt.Sprintf("%d", item.A.Messages), fmt.Sprintf("%d", item.B.Messages),
+			fmt.Sprintf("%d", item.Delta.Messages), formatPercentCSV(item.Change.Messages),
+			fmt.Sprintf("%.4f", item.Share),
+		})
+	}
+
+	for _, item := range report.Models {
+		if err := write("model", item); err != nil {
+			return "", err
+		}
+	}
+	for _, item := range report.Projects {
+		if err := write("project", item); err != nil {
+			return "", err
+		}
+	}
+	if err := write("total", report.Summary); err != nil {
+		return "", err
+	}
+
+	writer.Flush()
+	return builder.String(), writer.Error()
+}
+
+// formatPeriod 显示时间段的起止日期
+func formatPeriod(period models.Period) string {
+	return fmt.Sprintf("%s ~ %s (%s)", period.StartTime.Format("2006-01-02"),
+		period.EndTime.Format("2006-01-02"), period.Duration)
+}
+
+// signedNumber 带符号显示数量变化，增加为红色、减少为绿色
+func (f *Formatter) signedNumber(delta int) string {
+	switch {
+	case delta > 0:
+		return f.Colors.Error("+" + formatNumber(delta))
+	case delta < 0:
+		return f.Colors.Success("-" + formatNumber(-delta))
+	default:
+		return "0"
+	}
+}
+
+// signedCost 带符号显示成本变化
+func (f *Formatter) signedCost(delta float64) string {
+	switch {
+	case delta > 0:
+		return f.Colors.Error(fmt.Sprintf("+$%.4f", delta))
+	case delta < 0:
+		return f.Colors.Success(fmt.Sprintf("-$%.4f", -delta))
+	default:
+		return "$0.0000"
+	}
+}
+
+// percentChange 显示百分比变化，基数为0时显示为新增
+func (f *Formatter) percentChange(change *float64) string {
+	if change == nil {
+		return f.Colors.Dim(i18n.T("fmt.compare.new"))
+	}
+	text := fmt.Sprintf("%+.1f%%", *change)
+	switch {
+	case *change > 0:
+		return f.Colors.Error(text)
+	case *change < 0:
+		return f.Colors.Success(text)
+	default:
+		return text
+	}
+}
+
+// formatPercentCSV 百分比变化（CSV用），基数为0时为空
+func formatPercentCSV(change *float64) string {
+	if change == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.2f", *change)
+}
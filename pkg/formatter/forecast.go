@@ -0,0 +1,146 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// forecastChartHistory 图表中显示的历史天数
+const forecastChartHistory = 28
+
+// FormatForecast 格式化成本预测报告为表格和图表
+func (f *Formatter) FormatForecast(report *models.ForecastReport) (string, error) {
+	var output strings.Builder
+
+	// 添加标题
+	output.WriteString(f.Colors.IconHeader("🔮", i18n.T("fmt.forecast.title", report.AsOf), BrightMagenta))
+	output.WriteString("\n")
+
+	if report.HistoryDays == 0 {
+		output.WriteString("\n   📝 " + i18n.T("fmt.forecast.empty") + "\n")
+		return output.String(), nil
+	}
+
+	output.WriteString(f.Colors.Dim("   * " + i18n.T("fmt.forecast.model_hint", report.HistoryDays, report.Confidence*100) + "\n"))
+	output.WriteString(f.Colors.Dim("   * " + i18n.T("fmt.forecast.trend_hint", fmt.Sprintf("%+.3f", report.Trend)) + "\n\n"))
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		f.Colors.Header(i18n.T("col.period")),
+		f.Colors.Header(i18n.T("col.date_range")),
+		f.Colors.Header(i18n.T("col.actual_to_date")),
+		f.Colors.Header(i18n.T("col.linear")),
+		f.Colors.Header(i18n.T("col.weekday_avg")),
+		f.Colors.Header(i18n.T("col.forecast")),
+		f.Colors.Header(i18n.T("col.forecast_range", report.Confidence*100)),
+		f.Colors.Header(i18n.T("col.budget")),
+		f.Colors.Header(i18n.T("col.status")),
+	})
+
+	for _, target := range report.Targets {
+		budget := "-"
+		if target.BudgetUSD > 0 {
+			budget = fmt.Sprintf("$%.2f", target.BudgetUSD)
+		}
+		t.AppendRow(table.Row{
+			f.Colors.BrightCyan(i18n.T("fmt.forecast.target_" + target.Name)),
+			fmt.Sprintf("%s ~ %s", target.StartDate, target.EndDate),
+			fmt.Sprintf("$%.2f", target.ActualUSD),
+			fmt.Sprintf("$%.2f", target.LinearUSD),
+			fmt.Sprintf("$%.2f", target.SeasonalUSD),
+			f.Colors.Bold(fmt.Sprintf("$%.2f", target.ProjectedUSD)),
+			fmt.Sprintf("$%.2f ~ $%.2f", target.LowerUSD, target.UpperUSD),
+			budget,
+			f.budgetStatus(target),
+		})
+	}
+
+	t.SetStyle(table.StyleColoredBright)
+	output.WriteString(t.Render())
+	output.WriteString("\n\n")
+
+	// 历史与预测图表
+	chart := forecastChart(report)
+	if len(chart.Columns) > 0 {
+		output.WriteString("   " + f.Colors.Bold(i18n.T("fmt.forecast.chart_title")) + "  " +
+			f.Colors.Dim(i18n.T("fmt.forecast.chart_legend")) + "\n\n")
+		for _, line := range strings.Split(strings.TrimRight(chart.render(f.Colors), "\n"), "\n") {
+			output.WriteString("   " + line + "\n")
+		}
+		output.WriteString("\n")
+	}
+
+	return output.String(), nil
+}
+
+// forecastChart 由预测序列构建柱状图：最近的历史实际值加上预测值
+func forecastChart(report *models.ForecastReport) *barChart {
+	series := report.Series
+	historyCount := 0
+	for _, point := range series {
+		if point.ActualUSD != nil && point.Forecast == nil {
+			historyCount++
+		}
+	}
+	if historyCount > forecastChartHistory {
+		series = series[historyCount-forecastChartHistory:]
+	}
+
+	chart := &barChart{
+		Height:      10,
+		FormatValue: func(v float64) string { return fmt.Sprintf("$%.2f", v) },
+	}
+	for _, point := range series {
+		column := chartColumn{}
+		switch {
+		case point.Forecast != nil && point.ActualUSD != nil:
+			// 今天：实际值已经超过预测值时按实际值显示
+			column.Value = *point.ActualUSD
+			if *point.Forecast > column.Value {
+				column.Value = *point.Forecast
+				column.Projected = true
+			}
+			column.Upper = *point.Upper
+		case point.Forecast != nil:
+			column.Value = *point.Forecast
+			column.Upper = *point.Upper
+			column.Projected = true
+		case point.ActualUSD != nil:
+			column.Value = *point.ActualUSD
+		}
+		chart.Columns = append(chart.Columns, column)
+	}
+	if len(series) > 0 {
+		chart.StartLabel = series[0].Date[5:]
+		chart.EndLabel = series[len(series)-1].Date[5:]
+	}
+	return chart
+}
+
+// budgetStatus 显示预算状态
+func (f *Formatter) budgetStatus(target models.ForecastTarget) string {
+	label := i18n.T("fmt.forecast.status_" + target.BudgetStatus)
+	if target.CrossDate != "" {
+		label = i18n.T("fmt.forecast.crosses", label, target.CrossDate)
+	}
+
+	switch target.BudgetStatus {
+	case models.BudgetUnder:
+		return f.Colors.Success(label)
+	case models.BudgetAtRisk:
+		return f.Colors.Warning(label)
+	case models.BudgetProjected, models.BudgetOver:
+		return f.Colors.Error(label)
+	default:
+		return f.Colors.Dim(label)
+	}
+}
+
+// FormatForecastJSON 格式化成本预测报告为JSON
+func (f *Formatter) FormatForecastJSON(report *models.ForecastReport) (string, error) {
+	return marshalReport(report)
+}
@@ -2,7 +2,6 @@ package formatter
 
 import (
 	"encoding/csv"
-	"encoding/json"
 	"fmt"
 	"sort"
 	"strconv"
@@ -10,6 +9,7 @@ import (
 	"time"
 
 	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
 	"github.com/zhuiye8/claude-stats/pkg/models"
 )
 
@@ -17,6 +17,10 @@ import (
 type Formatter struct {
 	ShowDetails bool
 	Verbose     bool
+	TopN        int  // 排行类列表显示的条目数，0表示使用默认值
+	ShowCharts  bool // 在表格后附加图表（--chart）
+	Width       int  // 图表可用的终端宽度，0表示自动检测
+	Template    string // --format template 使用的模板内容
 	Colors      *ColorSettings
 }
 
@@ -36,20 +40,22 @@ func (f *Formatter) Format(stats *models.UsageStats, format string) (string, err
 		return f.formatJSON(stats)
 	case "csv":
 		return f.formatCSV(stats)
+	case "html":
+		return f.formatHTML(stats)
+	case "markdown":
+		return f.formatMarkdown(stats)
+	case "template":
+		return f.FormatTemplate(stats)
 	case "table", "":
 		return f.formatTable(stats)
 	default:
-		return "", fmt.Errorf("不支持的格式: %s", format)
+		return "", i18n.Errorf("err.unsupported_format", format)
 	}
 }
 
 // formatJSON 格式化为JSON
 func (f *Formatter) formatJSON(stats *models.UsageStats) (string, error) {
-	data, err := json.MarshalIndent(stats, "", "  ")
-	if err != nil {
-		return "", err
-	}
-	return string(data), nil
+	return marshalReport(stats)
 }
 
 // formatCSV 格式化为CSV
@@ -57,10 +63,10 @@ func (f *Formatter) formatCSV(stats *models.UsageStats) (string, error) {
 	var builder strings.Builder
 	writer := csv.NewWriter(&builder)
 
-	// 写入标题行
+	// 写入标题行（标题和类型列使用与语言无关的稳定标识，便于脚本处理）
 	headers := []string{
-		"类型", "名称", "输入Token", "输出Token", "缓存创建Token", 
-		"缓存读取Token", "总Token", "估算成本(USD)",
+		"type", "name", "input_tokens", "output_tokens", "cache_creation_tokens",
+		"cache_read_tokens", "total_tokens", "cost_usd",
 	}
 	if err := writer.Write(headers); err != nil {
 		return "", err
@@ -68,7 +74,7 @@ func (f *Formatter) formatCSV(stats *models.UsageStats) (string, error) {
 
 	// 写入总体统计
 	totalRow := []string{
-		"总计", "全部",
+		"total", "all",
 		fmt.Sprintf("%d", stats.TotalTokens.InputTokens),
 		fmt.Sprintf("%d", stats.TotalTokens.OutputTokens),
 		fmt.Sprintf("%d", stats.TotalTokens.CacheCreationTokens),
@@ -83,7 +89,7 @@ func (f *Formatter) formatCSV(stats *models.UsageStats) (string, error) {
 	// 写入模型统计
 	for model, usage := range stats.ModelStats {
 		row := []string{
-			"模型", model,
+			"model", model,
 			fmt.Sprintf("%d", usage.InputTokens),
 			fmt.Sprintf("%d", usage.OutputTokens),
 			fmt.Sprintf("%d", usage.CacheCreationTokens),
@@ -107,7 +113,7 @@ func (f *Formatter) formatCSV(stats *models.UsageStats) (string, error) {
 		for _, date := range dates {
 			usage := stats.DailyStats[date]
 			row := []string{
-				"日期", date,
+				"date", date,
 				fmt.Sprintf("%d", usage.InputTokens),
 				fmt.Sprintf("%d", usage.OutputTokens),
 				fmt.Sprintf("%d", usage.CacheCreationTokens),
@@ -130,24 +136,24 @@ func (f *Formatter) FormatBlocks(report *models.BlocksReport) (string, error) {
 	var output strings.Builder
 
 	// 添加标题
-	output.WriteString(f.Colors.IconHeader("🕐", "5小时计费窗口分析", BrightBlue))
+	output.WriteString(f.Colors.IconHeader("🕐", i18n.T("fmt.blocks.title"), BrightBlue))
 	output.WriteString("\n\n")
 
 	if len(report.Blocks) == 0 {
-		output.WriteString("   📝 暂无活动窗口数据\n")
+		output.WriteString("   📝 " + i18n.T("fmt.blocks.empty") + "\n")
 		return output.String(), nil
 	}
 
 	// 创建表格
 	t := table.NewWriter()
 	t.AppendHeader(table.Row{
-		f.Colors.Header("窗口开始时间"),
-		f.Colors.Header("状态"),
-		f.Colors.Header("模型"),
-		f.Colors.Header("输入Token"),
-		f.Colors.Header("输出Token"),
-		f.Colors.Header("总Token"),
-		f.Colors.Header("成本(USD)"),
+		f.Colors.Header(i18n.T("col.block_start")),
+		f.Colors.Header(i18n.T("col.status")),
+		f.Colors.Header(i18n.T("col.model")),
+		f.Colors.Header(i18n.T("col.input_tokens")),
+		f.Colors.Header(i18n.T("col.output_tokens")),
+		f.Colors.Header(i18n.T("col.total_tokens")),
+		f.Colors.Header(i18n.T("col.cost_usd")),
 	})
 
 	for _, block := range report.Blocks {
@@ -155,15 +161,15 @@ func (f *Formatter) FormatBlocks(report *models.BlocksReport) (string, error) {
 		var models string
 
 		if block.IsActive {
-			status = f.Colors.BrightGreen(fmt.Sprintf("⏰ 活跃 (%s)", block.TimeRemaining))
+			status = f.Colors.BrightGreen("⏰ " + i18n.T("fmt.blocks.active", block.TimeRemaining))
 			if block.BurnRate > 0 {
-				status += f.Colors.Dim(fmt.Sprintf("\n🔥 速率: %s/分钟", formatNumber(block.BurnRate)))
+				status += f.Colors.Dim("\n🔥 " + i18n.T("fmt.blocks.burn_rate", formatNumber(block.BurnRate)))
 			}
 			if block.ProjectedTotal > 0 {
-				status += f.Colors.Dim(fmt.Sprintf("\n📊 预测: %s", formatNumber(block.ProjectedTotal)))
+				status += f.Colors.Dim("\n📊 " + i18n.T("fmt.blocks.projected", formatNumber(block.ProjectedTotal)))
 			}
 		} else {
-			status = f.Colors.Dim("✅ 已完成")
+			status = f.Colors.Dim("✅ " + i18n.T("fmt.blocks.completed"))
 		}
 
 		if len(block.Models) > 0 {
@@ -174,7 +180,7 @@ func (f *Formatter) FormatBlocks(report *models.BlocksReport) (string, error) {
 				models += f.Colors.BrightCyan("• " + model)
 			}
 		} else {
-			models = f.Colors.Dim("无")
+			models = f.Colors.Dim(i18n.T("common.none"))
 		}
 
 		t.AppendRow(table.Row{
@@ -190,7 +196,7 @@ func (f *Formatter) FormatBlocks(report *models.BlocksReport) (string, error) {
 
 	// 添加汇总行
 	t.AppendFooter(table.Row{
-		f.Colors.Bold("总计"),
+		f.Colors.Bold(i18n.T("common.total")),
 		"",
 		"",
 		f.Colors.Bold(formatNumber(report.Summary.InputTokens)),
@@ -203,16 +209,16 @@ func (f *Formatter) FormatBlocks(report *models.BlocksReport) (string, error) {
 	output.WriteString(t.Render())
 	output.WriteString("\n\n")
 
+	if f.ShowCharts {
+		f.writeBlocksCharts(&output, report)
+	}
+
 	return output.String(), nil
 }
 
 // FormatBlocksJSON 格式化blocks报告为JSON
 func (f *Formatter) FormatBlocksJSON(report *models.BlocksReport) (string, error) {
-	data, err := json.MarshalIndent(report, "", "  ")
-	if err != nil {
-		return "", err
-	}
-	return string(data), nil
+	return marshalReport(report)
 }
 
 // FormatDaily 格式化日报告为表格
@@ -220,16 +226,16 @@ func (f *Formatter) FormatDaily(report *models.DailyReport) (string, error) {
 	var output strings.Builder
 
 	// 添加标题
-	output.WriteString(f.Colors.IconHeader("📅", "每日使用统计", BrightGreen))
+	output.WriteString(f.Colors.IconHeader("📅", i18n.T("fmt.daily.title"), BrightGreen))
 	output.WriteString("\n")
 
 	// 添加重要提示
-	output.WriteString(f.Colors.Warning("   * 这是一个本地消费分析工具，显示的成本是基于您本地的Token使用量和Claude API的公开价格估算的等价成本。\n"))
-	output.WriteString(f.Colors.Dim("   * 对于订阅用户（如Pro/Max），您的实际账单是固定的月费，此处的成本估算可帮助您了解使用价值，而非实际应付金额。\n\n"))
+	output.WriteString(f.Colors.Warning("   * " + i18n.T("fmt.daily.cost_notice") + "\n"))
+	output.WriteString(f.Colors.Dim("   * " + i18n.T("fmt.daily.subscription_notice") + "\n\n"))
 
 
 	if len(report.DailyData) == 0 {
-		output.WriteString("   📝 暂无每日数据\n")
+		output.WriteString("   📝 " + i18n.T("fmt.daily.empty") + "\n")
 		return output.String(), nil
 	}
 
@@ -237,20 +243,26 @@ func (f *Formatter) FormatDaily(report *models.DailyReport) (string, error) {
 	t := table.NewWriter()
 	headers := []table.Row{
 		{
-			f.Colors.Header("日期"),
-			f.Colors.Header("模型"),
-			f.Colors.Header("输入Token"),
-			f.Colors.Header("输出Token"),
-			f.Colors.Header("缓存创建"),
-			f.Colors.Header("缓存读取"),
-			f.Colors.Header("总Token"),
-			f.Colors.Header("等价成本(USD)"),
-			f.Colors.Header("消息数"),
+			f.Colors.Header(i18n.T("col.date")),
+			f.Colors.Header(i18n.T("col.model")),
+			f.Colors.Header(i18n.T("col.input_tokens")),
+			f.Colors.Header(i18n.T("col.output_tokens")),
+			f.Colors.Header(i18n.T("col.cache_creation")),
+			f.Colors.Header(i18n.T("col.cache_read")),
+			f.Colors.Header(i18n.T("col.total_tokens")),
+			f.Colors.Header(i18n.T("col.equivalent_cost")),
+			f.Colors.Header(i18n.T("col.messages")),
 		},
 	}
 
+	// 存在子代理记录时单独显示其成本
+	showSidechain := report.Summary.Sidechain.TotalTokens > 0
+	if showSidechain {
+		headers[0] = append(headers[0], f.Colors.Header(i18n.T("col.sidechain_cost")))
+	}
+
 	if f.ShowDetails {
-		headers[0] = append(headers[0], f.Colors.Header("会话数"))
+		headers[0] = append(headers[0], f.Colors.Header(i18n.T("col.sessions")))
 	}
 
 	t.AppendHeader(headers[0])
@@ -265,7 +277,7 @@ func (f *Formatter) FormatDaily(report *models.DailyReport) (string, error) {
 				modelsStr += f.Colors.BrightCyan(model)
 			}
 		} else {
-			modelsStr = f.Colors.Dim("未知")
+			modelsStr = f.Colors.Dim(i18n.T("common.unknown"))
 		}
 
 		row := table.Row{
@@ -280,6 +292,10 @@ func (f *Formatter) FormatDaily(report *models.DailyReport) (string, error) {
 			formatNumber(dayData.MessageCount),
 		}
 
+		if showSidechain {
+			row = append(row, f.Colors.Dim(formatSidechainCost(dayData.Sidechain.CostUSD, dayData.CostUSD)))
+		}
+
 		if f.ShowDetails {
 			row = append(row, formatNumber(dayData.SessionCount))
 		}
@@ -300,6 +316,9 @@ func (f *Formatter) FormatDaily(report *models.DailyReport) (string, error) {
 					fmt.Sprintf("$%.4f", modelData.CostUSD),
 					formatNumber(modelData.MessageCount),
 				}
+				if showSidechain {
+					breakdownRow = append(breakdownRow, "")
+				}
 				if f.ShowDetails {
 					breakdownRow = append(breakdownRow, "")
 				}
@@ -310,7 +329,7 @@ func (f *Formatter) FormatDaily(report *models.DailyReport) (string, error) {
 
 	// 添加汇总行
 	summaryRow := table.Row{
-		f.Colors.Bold("总计"),
+		f.Colors.Bold(i18n.T("common.total")),
 		"",
 		f.Colors.Bold(formatNumber(report.Summary.InputTokens)),
 		f.Colors.Bold(formatNumber(report.Summary.OutputTokens)),
@@ -320,6 +339,9 @@ func (f *Formatter) FormatDaily(report *models.DailyReport) (string, error) {
 		f.Colors.Bold(fmt.Sprintf("$%.4f", report.Summary.CostUSD)),
 		f.Colors.Bold(formatNumber(report.Summary.MessageCount)),
 	}
+	if showSidechain {
+		summaryRow = append(summaryRow, f.Colors.Bold(formatSidechainCost(report.Summary.Sidechain.CostUSD, report.Summary.CostUSD)))
+	}
 	if f.ShowDetails {
 		summaryRow = append(summaryRow, f.Colors.Bold(formatNumber(report.Summary.SessionCount)))
 	}
@@ -329,16 +351,16 @@ func (f *Formatter) FormatDaily(report *models.DailyReport) (string, error) {
 	output.WriteString(t.Render())
 	output.WriteString("\n\n")
 
+	if f.ShowCharts {
+		f.writeDailyCharts(&output, report)
+	}
+
 	return output.String(), nil
 }
 
 // FormatDailyJSON 格式化日报告为JSON
 func (f *Formatter) FormatDailyJSON(report *models.DailyReport) (string, error) {
-	data, err := json.MarshalIndent(report, "", "  ")
-	if err != nil {
-		return "", err
-	}
-	return string(data), nil
+	return marshalReport(report)
 }
 
 // FormatDailyCSV 格式化日报告为CSV
@@ -346,10 +368,11 @@ func (f *Formatter) FormatDailyCSV(report *models.DailyReport) (string, error) {
 	var builder strings.Builder
 	writer := csv.NewWriter(&builder)
 
-	// 写入标题行
+	// 写入标题行（使用与语言无关的稳定标识，便于脚本处理）
 	headers := []string{
-		"日期", "模型", "输入Token", "输出Token", "缓存创建Token", 
-		"缓存读取Token", "总Token", "成本(USD)", "消息数", "会话数",
+		"date", "models", "input_tokens", "output_tokens", "cache_creation_tokens",
+		"cache_read_tokens", "total_tokens", "cost_usd", "message_count", "session_count",
+		"sidechain_tokens", "sidechain_cost_usd",
 	}
 	if err := writer.Write(headers); err != nil {
 		return "", err
@@ -370,6 +393,8 @@ func (f *Formatter) FormatDailyCSV(report *models.DailyReport) (string, error) {
 			fmt.Sprintf("%.4f", dayData.CostUSD),
 			fmt.Sprintf("%d", dayData.MessageCount),
 			fmt.Sprintf("%d", dayData.SessionCount),
+			fmt.Sprintf("%d", dayData.Sidechain.TotalTokens),
+			fmt.Sprintf("%.4f", dayData.Sidechain.CostUSD),
 		}
 		if err := writer.Write(row); err != nil {
 			return "", err
@@ -378,7 +403,7 @@ func (f *Formatter) FormatDailyCSV(report *models.DailyReport) (string, error) {
 
 	// 写入汇总行
 	summaryRow := []string{
-		"总计",
+		"total",
 		"",
 		fmt.Sprintf("%d", report.Summary.InputTokens),
 		fmt.Sprintf("%d", report.Summary.OutputTokens),
@@ -388,6 +413,8 @@ func (f *Formatter) FormatDailyCSV(report *models.DailyReport) (string, error) {
 		fmt.Sprintf("%.4f", report.Summary.CostUSD),
 		fmt.Sprintf("%d", report.Summary.MessageCount),
 		fmt.Sprintf("%d", report.Summary.SessionCount),
+		fmt.Sprintf("%d", report.Summary.Sidechain.TotalTokens),
+		fmt.Sprintf("%.4f", report.Summary.Sidechain.CostUSD),
 	}
 	if err := writer.Write(summaryRow); err != nil {
 		return "", err
@@ -397,6 +424,17 @@ func (f *Formatter) FormatDailyCSV(report *models.DailyReport) (string, error) {
 	return builder.String(), writer.Error()
 }
 
+// formatSidechainCost 显示子代理成本及其占总成本的比例
+func formatSidechainCost(sidechainCost, totalCost float64) string {
+	if sidechainCost == 0 {
+		return "-"
+	}
+	if totalCost <= 0 {
+		return fmt.Sprintf("$%.4f", sidechainCost)
+	}
+	return fmt.Sprintf("$%.4f (%.0f%%)", sidechainCost, sidechainCost/totalCost*100)
+}
+
 // formatTable 格式化为表格
 func (f *Formatter) formatTable(stats *models.UsageStats) (string, error) {
 	var output strings.Builder
@@ -415,6 +453,11 @@ func (f *Formatter) formatTable(stats *models.UsageStats) (string, error) {
 		f.writeModelStats(&output, stats)
 	}
 
+	// 图表
+	if f.ShowCharts {
+		f.writeUsageCharts(&output, stats)
+	}
+
 	// 成本分析
 	f.writeCostAnalysis(&output, stats)
 
@@ -455,11 +498,11 @@ func (f *Formatter) writeHeader(output *strings.Builder, stats *models.UsageStat
 	// 右下角作者信息
 	authorInfo := fmt.Sprintf("%s%s", 
 		strings.Repeat(" ", 45), 
-		f.Colors.Dim("作者: zhuiye"))
+		f.Colors.Dim(i18n.T("fmt.header.author")))
 	output.WriteString(authorInfo + "\n\n")
 	
 	// 副标题信息
-	subtitle := f.Colors.Info(fmt.Sprintf("🎯 Claude Code 使用统计分析工具  •  生成时间: %s", 
+	subtitle := f.Colors.Info("🎯 " + i18n.T("fmt.header.subtitle",
 		time.Now().Format("2006-01-02 15:04:05")))
 	output.WriteString(fmt.Sprintf("%s\n\n", subtitle))
 	
@@ -471,7 +514,7 @@ func (f *Formatter) writeHeader(output *strings.Builder, stats *models.UsageStat
 
 // writeBasicInfo 写入基本信息
 func (f *Formatter) writeBasicInfo(output *strings.Builder, stats *models.UsageStats) {
-	sectionTitle := f.Colors.IconHeader("📋", "基本信息", BrightBlue)
+	sectionTitle := f.Colors.IconHeader("📋", i18n.T("fmt.basic.title"), BrightBlue)
 	output.WriteString(fmt.Sprintf("%s\n", sectionTitle))
 	
 	mode := getModeDisplay(stats.DetectedMode)
@@ -482,36 +525,49 @@ func (f *Formatter) writeBasicInfo(output *strings.Builder, stats *models.UsageS
 		modeIcon = "💎"
 	}
 	
-	output.WriteString(fmt.Sprintf("   %s 检测模式: %s\n", modeIcon, f.Colors.Colorize(mode, modeColor)))
-	output.WriteString(fmt.Sprintf("   📊 总会话数: %s\n", f.Colors.BrightYellow(formatNumber(stats.TotalSessions))))
-	output.WriteString(fmt.Sprintf("   💬 总消息数: %s\n", f.Colors.BrightCyan(formatNumber(stats.TotalMessages))))
+	output.WriteString(fmt.Sprintf("   %s %s: %s\n", modeIcon, i18n.T("fmt.basic.mode"), f.Colors.Colorize(mode, modeColor)))
+	output.WriteString(fmt.Sprintf("   📊 %s: %s\n", i18n.T("fmt.basic.total_sessions"), f.Colors.BrightYellow(formatNumber(stats.TotalSessions))))
+	output.WriteString(fmt.Sprintf("   💬 %s: %s\n", i18n.T("fmt.basic.total_messages"), f.Colors.BrightCyan(formatNumber(stats.TotalMessages))))
 	
 	// Claude Code 特定信息
 	if stats.ParsedMessages > 0 {
 		parseRate := float64(stats.ParsedMessages) * 100 / float64(stats.TotalMessages)
-		output.WriteString(fmt.Sprintf("   ✅ 解析成功: %s (%s)\n", 
+		output.WriteString(fmt.Sprintf("   ✅ %s: %s (%s)\n", i18n.T("fmt.basic.parsed"),
 			f.Colors.BrightGreen(formatNumber(stats.ParsedMessages)),
 			f.Colors.Cyan(fmt.Sprintf("%.1f%%", parseRate))))
 	}
 	
 	if stats.ExtractedTokens > 0 {
 		extractRate := float64(stats.ExtractedTokens) * 100 / float64(stats.TotalMessages)
-		output.WriteString(fmt.Sprintf("   🎯 Token提取: %s (%s)\n", 
+		output.WriteString(fmt.Sprintf("   🎯 %s: %s (%s)\n", i18n.T("fmt.basic.extracted"),
 			f.Colors.BrightGreen(formatNumber(stats.ExtractedTokens)),
 			f.Colors.Cyan(fmt.Sprintf("%.1f%%", extractRate))))
 	}
+
+	// 子代理（sidechain）用量
+	if stats.Sidechain.MessageCount > 0 {
+		sidechainTokens := stats.Sidechain.Tokens.GetTotalTokens()
+		share := 0.0
+		if total := stats.TotalTokens.GetTotalTokens(); total > 0 {
+			share = float64(sidechainTokens) * 100 / float64(total)
+		}
+		output.WriteString(fmt.Sprintf("   🤖 %s: %s (%s, $%.4f)\n", i18n.T("fmt.basic.sidechain"),
+			f.Colors.BrightGreen(formatNumber(sidechainTokens)),
+			f.Colors.Cyan(fmt.Sprintf("%.1f%%", share)),
+			stats.Sidechain.CostUSD))
+	}
 	
 	if !stats.AnalysisPeriod.StartTime.IsZero() {
-		timeRange := fmt.Sprintf("%s 至 %s", 
+		timeRange := i18n.T("fmt.basic.time_range",
 			stats.AnalysisPeriod.StartTime.Format("2006-01-02 15:04"),
 			stats.AnalysisPeriod.EndTime.Format("2006-01-02 15:04"))
-		output.WriteString(fmt.Sprintf("   📅 分析时段: %s\n", f.Colors.Info(timeRange)))
-		output.WriteString(fmt.Sprintf("   ⏰ 持续时间: %s\n", f.Colors.Info(stats.AnalysisPeriod.Duration)))
+		output.WriteString(fmt.Sprintf("   📅 %s: %s\n", i18n.T("fmt.basic.period"), f.Colors.Info(timeRange)))
+		output.WriteString(fmt.Sprintf("   ⏰ %s: %s\n", i18n.T("fmt.basic.duration"), f.Colors.Info(stats.AnalysisPeriod.Duration)))
 	}
 	
 	// 显示消息类型分布
 	if len(stats.MessageTypes) > 0 {
-		output.WriteString(fmt.Sprintf("   🏷️  消息类型: %s\n", f.formatMessageTypes(stats.MessageTypes)))
+		output.WriteString(fmt.Sprintf("   🏷️  %s: %s\n", i18n.T("fmt.basic.message_types"), f.formatMessageTypes(stats.MessageTypes)))
 	}
 	
 	output.WriteString("\n")
@@ -537,28 +593,28 @@ func (f *Formatter) formatMessageTypes(messageTypes map[string]int) string {
 
 // writeTotalStats 写入总体统计
 func (f *Formatter) writeTotalStats(output *strings.Builder, stats *models.UsageStats) {
-	sectionTitle := f.Colors.IconHeader("📈", "Token 使用统计", BrightGreen)
+	sectionTitle := f.Colors.IconHeader("📈", i18n.T("fmt.tokens.title"), BrightGreen)
 	output.WriteString(fmt.Sprintf("%s\n", sectionTitle))
 	
 	// 添加百分比计算说明
 	baseTokens := stats.TotalTokens.InputTokens + stats.TotalTokens.OutputTokens
 	output.WriteString(fmt.Sprintf("   💡 %s\n\n", 
-		f.Colors.Dim(fmt.Sprintf("百分比基准: 基础Token(%s) = 输入Token + 输出Token", formatNumber(baseTokens)))))
+		f.Colors.Dim(i18n.T("fmt.tokens.base_hint", formatNumber(baseTokens)))))
 	
 	t := table.NewWriter()
 	t.AppendHeader(table.Row{
-		f.Colors.Header("类型"), 
-		f.Colors.Header("数量"), 
-		f.Colors.Header("百分比"),
-		f.Colors.Header("说明"),
+		f.Colors.Header(i18n.T("col.type")),
+		f.Colors.Header(i18n.T("col.amount")),
+		f.Colors.Header(i18n.T("col.percentage")),
+		f.Colors.Header(i18n.T("col.description")),
 	})
 
 	if baseTokens > 0 {
 		// 输入Token - 基于基础Token计算百分比
 		inputPct := float64(stats.TotalTokens.InputTokens) * 100 / float64(baseTokens)
-		inputDesc := fmt.Sprintf("📥 %s", f.Colors.Info("用户提问成本"))
+		inputDesc := fmt.Sprintf("📥 %s", f.Colors.Info(i18n.T("fmt.tokens.input_desc")))
 		t.AppendRow(table.Row{
-			f.Colors.BrightBlue("输入Token"), 
+			f.Colors.BrightBlue(i18n.T("col.input_tokens")),
 			f.Colors.BrightYellow(formatNumber(stats.TotalTokens.InputTokens)),
 			f.Colors.BrightGreen(fmt.Sprintf("%.1f%%", inputPct)),
 			inputDesc,
@@ -566,9 +622,9 @@ func (f *Formatter) writeTotalStats(output *strings.Builder, stats *models.Usage
 		
 		// 输出Token - 基于基础Token计算百分比
 		outputPct := float64(stats.TotalTokens.OutputTokens) * 100 / float64(baseTokens)
-		outputDesc := fmt.Sprintf("📤 %s", f.Colors.Info("AI回复成本"))
+		outputDesc := fmt.Sprintf("📤 %s", f.Colors.Info(i18n.T("fmt.tokens.output_desc")))
 		t.AppendRow(table.Row{
-			f.Colors.BrightGreen("输出Token"), 
+			f.Colors.BrightGreen(i18n.T("col.output_tokens")),
 			f.Colors.BrightYellow(formatNumber(stats.TotalTokens.OutputTokens)),
 			f.Colors.BrightGreen(fmt.Sprintf("%.1f%%", outputPct)),
 			outputDesc,
@@ -579,29 +635,29 @@ func (f *Formatter) writeTotalStats(output *strings.Builder, stats *models.Usage
 		
 		// 缓存Token单独显示，不参与百分比计算
 		if stats.TotalTokens.CacheCreationTokens > 0 {
-			cacheDesc := fmt.Sprintf("📦 %s", f.Colors.Success("上下文缓存"))
+			cacheDesc := fmt.Sprintf("📦 %s", f.Colors.Success(i18n.T("fmt.tokens.cache_creation_desc")))
 			t.AppendRow(table.Row{
-				f.Colors.BrightMagenta("缓存创建Token"), 
+				f.Colors.BrightMagenta(i18n.T("col.cache_creation_tokens")),
 				f.Colors.BrightYellow(formatNumber(stats.TotalTokens.CacheCreationTokens)),
-				f.Colors.Dim("不计入%"),
+				f.Colors.Dim(i18n.T("fmt.tokens.excluded")),
 				cacheDesc,
 			})
 		}
 		
 		if stats.TotalTokens.CacheReadTokens > 0 {
-			readDesc := fmt.Sprintf("⚡ %s", f.Colors.Success("缓存加速"))
+			readDesc := fmt.Sprintf("⚡ %s", f.Colors.Success(i18n.T("fmt.tokens.cache_read_desc")))
 			t.AppendRow(table.Row{
-				f.Colors.BrightCyan("缓存读取Token"), 
+				f.Colors.BrightCyan(i18n.T("col.cache_read_tokens")),
 				f.Colors.BrightYellow(formatNumber(stats.TotalTokens.CacheReadTokens)),
-				f.Colors.Dim("不计入%"),
+				f.Colors.Dim(i18n.T("fmt.tokens.excluded")),
 				readDesc,
 			})
 		}
 	} else {
 		// 如果没有token数据，显示提示信息
 		t.AppendRow(table.Row{
-			f.Colors.Warning("⚠️ 暂无Token数据"), 
-			f.Colors.Dim("请检查JSONL格式"),
+			f.Colors.Warning("⚠️ " + i18n.T("fmt.tokens.empty")),
+			f.Colors.Dim(i18n.T("fmt.tokens.check_jsonl")),
 			f.Colors.Dim("--"),
 			f.Colors.Dim("--"),
 		})
@@ -609,10 +665,10 @@ func (f *Formatter) writeTotalStats(output *strings.Builder, stats *models.Usage
 
 	// 总计行显示基础Token
 	t.AppendFooter(table.Row{
-		f.Colors.Bold("💰 基础Token总计"), 
+		f.Colors.Bold("💰 " + i18n.T("fmt.tokens.base_total")),
 		f.Colors.Bold(f.Colors.BrightYellow(formatNumber(baseTokens))), 
 		f.Colors.Bold("100.0%"),
-		f.Colors.Bold("💡 真实使用成本"),
+		f.Colors.Bold("💡 " + i18n.T("fmt.tokens.real_cost")),
 	})
 	
 	// 使用更美观的表格样式
@@ -626,8 +682,11 @@ func (f *Formatter) writeTotalStats(output *strings.Builder, stats *models.Usage
 // writeModelStats 写入模型统计
 func (f *Formatter) writeModelStats(output *strings.Builder, stats *models.UsageStats) {
 	t := table.NewWriter()
-	t.SetTitle("🤖 按模型统计")
-	t.AppendHeader(table.Row{"模型", "输入", "输出", "缓存", "总计", "成本(USD)"})
+	t.SetTitle("🤖 " + i18n.T("fmt.models.title"))
+	t.AppendHeader(table.Row{
+		i18n.T("col.model"), i18n.T("col.input"), i18n.T("col.output"),
+		i18n.T("col.cache"), i18n.T("col.total"), i18n.T("col.cost_usd"),
+	})
 
 	// 按总token数排序
 	type modelStat struct {
@@ -675,62 +734,88 @@ func createProgressBar(percentage, length int) string {
 	return strings.Repeat("█", filledLength) + strings.Repeat("░", length-filledLength)
 }
 
+// sparkline 将一组数值渲染为迷你趋势图
+func sparkline(values []float64) string {
+	levels := []rune("▁▂▃▄▅▆▇█")
+
+	max := 0.0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+
+	var result strings.Builder
+	for _, v := range values {
+		switch {
+		case v <= 0:
+			result.WriteRune(' ')
+		case max <= 0:
+			result.WriteRune(levels[0])
+		default:
+			idx := int(v / max * float64(len(levels)-1))
+			result.WriteRune(levels[idx])
+		}
+	}
+	return result.String()
+}
+
 // getModelDescription 获取模型描述
 func getModelDescription(modelName string) string {
 	switch modelName {
 	case "Claude 4 Opus":
-		return "高性能模型"
+		return i18n.T("fmt.quota.model_high")
 	case "Claude 4 Sonnet":
-		return "标准模型"
+		return i18n.T("fmt.quota.model_standard")
 	default:
-		return "未知模型"
+		return i18n.T("fmt.quota.model_unknown")
 	}
 }
 
 // writeCostAnalysis 写入成本分析
 func (f *Formatter) writeCostAnalysis(output *strings.Builder, stats *models.UsageStats) {
-	sectionTitle := f.Colors.IconHeader("💰", "成本分析", BrightCyan)
+	sectionTitle := f.Colors.IconHeader("💰", i18n.T("fmt.cost.title"), BrightCyan)
 	output.WriteString(fmt.Sprintf("%s\n", sectionTitle))
 	
 	if stats.DetectedMode == "subscription" {
-		output.WriteString(fmt.Sprintf("   %s\n", f.Colors.Dim("(基于订阅模式的API等价成本估算)")))
+		output.WriteString(fmt.Sprintf("   %s\n", f.Colors.Dim(i18n.T("fmt.cost.subscription_hint"))))
 	}
 	
 	// 区分显示输入输出成本，突出差异
 	inputRatio := stats.EstimatedCost.InputCost / stats.EstimatedCost.TotalCost * 100
 	outputRatio := stats.EstimatedCost.OutputCost / stats.EstimatedCost.TotalCost * 100
 	
-	output.WriteString(fmt.Sprintf("   📥 输入成本:     %s (%.1f%% 📉 低成本)\n", 
-		f.Colors.BrightGreen(fmt.Sprintf("$%.4f", stats.EstimatedCost.InputCost)), inputRatio))
-	output.WriteString(fmt.Sprintf("   📤 输出成本:     %s (%.1f%% 📈 主要成本)\n", 
-		f.Colors.BrightYellow(fmt.Sprintf("$%.4f", stats.EstimatedCost.OutputCost)), outputRatio))
+	output.WriteString("   📥 " + i18n.T("fmt.cost.input",
+		f.Colors.BrightGreen(fmt.Sprintf("$%.4f", stats.EstimatedCost.InputCost)), inputRatio) + "\n")
+	output.WriteString("   📤 " + i18n.T("fmt.cost.output",
+		f.Colors.BrightYellow(fmt.Sprintf("$%.4f", stats.EstimatedCost.OutputCost)), outputRatio) + "\n")
 	
 	// 显示输出/输入成本比例
 	if stats.EstimatedCost.InputCost > 0 {
 		costRatio := stats.EstimatedCost.OutputCost / stats.EstimatedCost.InputCost
-		output.WriteString(fmt.Sprintf("   🔍 成本比例:     输出成本是输入成本的 %s 倍\n", 
-			f.Colors.BrightMagenta(fmt.Sprintf("%.1f", costRatio))))
+		output.WriteString("   🔍 " + i18n.T("fmt.cost.ratio",
+			f.Colors.BrightMagenta(fmt.Sprintf("%.1f", costRatio))) + "\n")
 	}
 	
 	if stats.EstimatedCost.CacheCreationCost > 0 {
-		output.WriteString(fmt.Sprintf("   📦 缓存创建成本: %s\n", 
-			f.Colors.BrightBlue(fmt.Sprintf("$%.4f", stats.EstimatedCost.CacheCreationCost))))
+		output.WriteString("   📦 " + i18n.T("fmt.cost.cache_creation",
+			f.Colors.BrightBlue(fmt.Sprintf("$%.4f", stats.EstimatedCost.CacheCreationCost))) + "\n")
 	}
 	if stats.EstimatedCost.CacheReadCost > 0 {
-		output.WriteString(fmt.Sprintf("   ⚡ 缓存读取成本: %s\n", 
-			f.Colors.BrightCyan(fmt.Sprintf("$%.4f", stats.EstimatedCost.CacheReadCost))))
+		output.WriteString("   ⚡ " + i18n.T("fmt.cost.cache_read",
+			f.Colors.BrightCyan(fmt.Sprintf("$%.4f", stats.EstimatedCost.CacheReadCost))) + "\n")
 	}
 	
-	output.WriteString(fmt.Sprintf("   💎 总成本:       %s\n", 
-		f.Colors.BrightCyan(fmt.Sprintf("$%.4f", stats.EstimatedCost.TotalCost))))
+	output.WriteString("   💎 " + i18n.T("fmt.cost.total",
+		f.Colors.BrightCyan(fmt.Sprintf("$%.4f", stats.EstimatedCost.TotalCost))) + "\n")
 
 	// 显示订阅模式成本节省信息
 	if stats.DetectedMode == "subscription" && stats.SubscriptionQuota != nil {
 		planCost := float64(getPlanPrice(stats.SubscriptionQuota.Plan))
 		if stats.EstimatedCost.TotalCost > planCost {
 			savings := stats.EstimatedCost.TotalCost - planCost
-			output.WriteString(fmt.Sprintf("   💰 成本节省:     订阅模式相比API节省 %s\n", 
-				f.Colors.BrightGreen(fmt.Sprintf("$%.2f", savings))))
+			output.WriteString("   💰 " + i18n.T("fmt.cost.savings",
+				f.Colors.BrightGreen(fmt.Sprintf("$%.2f", savings))) + "\n")
 		}
 	}
 }
@@ -738,14 +823,16 @@ func (f *Formatter) writeCostAnalysis(output *strings.Builder, stats *models.Usa
 // writeSubscriptionQuota 写入订阅限额信息
 func (f *Formatter) writeSubscriptionQuota(output *strings.Builder, stats *models.UsageStats) {
 	quota := stats.SubscriptionQuota
-	sectionTitle := f.Colors.IconHeader("⚙️", "订阅限额状态", BrightMagenta)
+	sectionTitle := f.Colors.IconHeader("⚙️", i18n.T("fmt.quota.title"), BrightMagenta)
 	output.WriteString(fmt.Sprintf("%s\n", sectionTitle))
 	
-	// 数据准确性警告
-	output.WriteString(fmt.Sprintf("   ⚠️  %s\n", 
-		f.Colors.Warning("注意：以下数据为估算值，实际限额请使用Claude Code的 /status 命令查看")))
+	// 计划是推测出来的时，额度也只是推测值，提示如何指定计划
+	if quota.PlanSource == "estimated" {
+		output.WriteString(fmt.Sprintf("   ⚠️  %s\n", 
+			f.Colors.Warning(i18n.T("fmt.quota.estimate_warning"))))
+	}
 	output.WriteString(fmt.Sprintf("   💡 %s\n\n", 
-		f.Colors.Dim("在Claude Code中运行 /status 可获取准确的当前窗口使用情况")))
+		f.Colors.Dim(i18n.T("fmt.quota.status_hint"))))
 	
 	// 计划信息卡片
 	planColor := BrightBlue
@@ -758,16 +845,31 @@ func (f *Formatter) writeSubscriptionQuota(output *strings.Builder, stats *model
 		planEmoji = "🥈"
 	}
 	
-	output.WriteString(fmt.Sprintf("   %s 推测计划: %s (%s)\n", 
-		planEmoji, 
+	planSource := i18n.T("fmt.quota.source_configured")
+	if quota.PlanSource == "estimated" {
+		planSource = i18n.T("fmt.quota.source_estimated")
+	}
+	output.WriteString(fmt.Sprintf("   %s %s: %s (%s, %s)\n",
+		planEmoji, i18n.T("fmt.quota.plan"),
 		f.Colors.Colorize(quota.Plan, planColor),
-		f.Colors.Dim(fmt.Sprintf("$%d/月", getPlanPrice(quota.Plan)))))
+		f.Colors.Dim(i18n.T("fmt.quota.per_month", getPlanPrice(quota.Plan))),
+		f.Colors.Dim(planSource)))
 		
-	output.WriteString(fmt.Sprintf("   🕐 限额机制: %s\n", 
-		f.Colors.Info("5小时窗口 (每天4个窗口，可能基于UTC时区)")))
+	output.WriteString(fmt.Sprintf("   🕐 %s: %s\n", i18n.T("fmt.quota.mechanism"),
+		f.Colors.Info(i18n.T("fmt.quota.mechanism_desc"))))
+	
+	// 当前窗口及重置时间
+	if quota.WindowStart != nil && quota.WindowEnd != nil {
+		output.WriteString("   🪟 " + i18n.T("fmt.quota.window",
+			f.Colors.BrightCyan(quota.WindowStart.Local().Format("15:04")),
+			f.Colors.BrightCyan(quota.WindowEnd.Local().Format("15:04")),
+			formatDuration(time.Until(*quota.WindowEnd))) + "\n")
+	} else {
+		output.WriteString("   🪟 " + f.Colors.Dim(i18n.T("fmt.quota.no_window")) + "\n")
+	}
 	
 	// 使用进度 - 重点显示剩余用量
-	usagePercentage := float64(quota.EstimatedUsed) / float64(quota.MessagesPerWindow) * 100
+	usagePercentage := quotaPercentage(quota.MessagesUsed, quota.MessagesPerWindow)
 	remainingPercentage := 100 - usagePercentage
 	
 	// 进度条颜色
@@ -778,83 +880,86 @@ func (f *Formatter) writeSubscriptionQuota(output *strings.Builder, stats *model
 		progressColor = BrightYellow
 	}
 	
-	output.WriteString(fmt.Sprintf("   🟢 估算使用:  %s / %d 消息\n", 
-		f.Colors.Colorize(fmt.Sprintf("%d", quota.EstimatedUsed), progressColor),
-		quota.MessagesPerWindow))
+	output.WriteString("   🟢 " + i18n.T("fmt.quota.used",
+		f.Colors.Colorize(fmt.Sprintf("%d", quota.MessagesUsed), progressColor),
+		quota.MessagesPerWindow) + "\n")
 	
 	// 创建进度条
 	progressBar := createProgressBar(int(usagePercentage), 20)
-	output.WriteString(fmt.Sprintf("   📊 估算进度: %s %.1f%%\n", 
+	output.WriteString(fmt.Sprintf("   📊 %s: %s %.1f%%\n", i18n.T("fmt.quota.progress"),
 		f.Colors.Colorize(progressBar, progressColor), usagePercentage))
 	
-	// 重点显示剩余用量
-	remainingColor := BrightGreen
-	if quota.Remaining < 100 {
-		remainingColor = BrightRed
-	} else if quota.Remaining < 200 {
-		remainingColor = BrightYellow
-	}
+	output.WriteString("   ✨ " + i18n.T("fmt.quota.remaining",
+		f.Colors.Colorize(fmt.Sprintf("%d", quota.Remaining), progressColor),
+		remainingPercentage) + "\n")
 	
-	output.WriteString(fmt.Sprintf("   ✨ 估算剩余: %s (还可以进行 %.1f%% 的对话)\n", 
-		f.Colors.Colorize(fmt.Sprintf("%d", quota.Remaining), remainingColor),
-		remainingPercentage))
+	// Token 额度
+	tokenPercentage := quotaPercentage(quota.TokensUsed, quota.TokensPerWindow)
+	tokenColor := BrightGreen
+	if tokenPercentage > 80 {
+		tokenColor = BrightRed
+	} else if tokenPercentage > 60 {
+		tokenColor = BrightYellow
+	}
+	output.WriteString("   🔢 " + i18n.T("fmt.quota.tokens",
+		f.Colors.Colorize(formatNumber(quota.TokensUsed), tokenColor),
+		formatNumber(quota.TokensPerWindow), tokenPercentage) + "\n")
 	
 	// 模型信息
 	modelEmoji := "⚡"
 	modelName := "Claude 4 Sonnet"
-	if quota.EstimatedUsed < quota.ModelSwitchPoint {
+	if quota.MessagesUsed < quota.ModelSwitchPoint {
 		modelEmoji = "🔥"
 		modelName = "Claude 4 Opus"
 	}
 	
-	output.WriteString(fmt.Sprintf("   %s 推测模型: %s (%s)\n", 
-		modelEmoji, 
+	output.WriteString(fmt.Sprintf("   %s %s: %s (%s)\n",
+		modelEmoji, i18n.T("fmt.quota.model"),
 		f.Colors.BrightCyan(modelName),
 		f.Colors.Dim(getModelDescription(modelName))))
 	
-	// 显示调试信息（如果有）
+	// 显示计划推测的依据（仅在推测计划时存在）
 	if quota.DebugInfo != nil {
 		output.WriteString("\n")
-		output.WriteString(fmt.Sprintf("   🔍 %s\n", f.Colors.Dim("估算详情:")))
+		output.WriteString(fmt.Sprintf("   🔍 %s\n", f.Colors.Dim(i18n.T("fmt.quota.details"))))
 		if userMsgs, ok := quota.DebugInfo["total_user_messages"].(int); ok {
-			output.WriteString(fmt.Sprintf("      • 分析期间用户消息: %d 条\n", userMsgs))
+			output.WriteString("      • " + i18n.T("fmt.quota.detail_user_messages", userMsgs) + "\n")
 		}
 		if windowCount, ok := quota.DebugInfo["window_count"].(int); ok {
-			output.WriteString(fmt.Sprintf("      • 跨越窗口数量: %d 个 (5小时/窗口)\n", windowCount))
+			output.WriteString("      • " + i18n.T("fmt.quota.detail_windows", windowCount) + "\n")
 		}
 		if avgPerWindow, ok := quota.DebugInfo["avg_per_window"].(float64); ok {
-			output.WriteString(fmt.Sprintf("      • 平均每窗口: %.1f 条消息\n", avgPerWindow))
+			output.WriteString("      • " + i18n.T("fmt.quota.detail_avg", avgPerWindow) + "\n")
 		}
 		if durationHours, ok := quota.DebugInfo["duration_hours"].(float64); ok {
-			output.WriteString(fmt.Sprintf("      • 分析时长: %.1f 小时\n", durationHours))
+			output.WriteString("      • " + i18n.T("fmt.quota.detail_hours", durationHours) + "\n")
 		}
 	}
 
-	// 重置时间提醒（基于亚洲/上海时区）
-	output.WriteString("\n")
-	output.WriteString(fmt.Sprintf("   🕐 %s\n", 
-		f.Colors.Info("重置时间参考: 限额可能在每天的特定时间重置")))
-	output.WriteString(fmt.Sprintf("      • 常见重置时间: %s\n", 
-		f.Colors.BrightCyan("19:00 (Asia/Shanghai), 03:00 (UTC)")))
-	output.WriteString(fmt.Sprintf("      • 具体时间请以Claude Code的 %s 显示为准\n", 
-		f.Colors.BrightCyan("/status")))
-
 	// 使用建议
 	output.WriteString("\n")
 	output.WriteString(fmt.Sprintf("   💡 %s\n", 
-		f.Colors.Info("获取准确信息：在Claude Code中运行 /status 命令")))
+		f.Colors.Info(i18n.T("fmt.quota.accurate_hint"))))
 	
 	// 根据剩余量给出不同提示
-	if quota.Remaining == 0 {
+	if quota.Remaining == 0 || (quota.TokensPerWindow > 0 && quota.TokensRemaining == 0) {
 		output.WriteString(fmt.Sprintf("   ⚠️  %s\n", 
-			f.Colors.BrightRed("估算显示可能已达到当前窗口限额")))
-	} else if quota.Remaining < 50 {
+			f.Colors.BrightRed(i18n.T("fmt.quota.limit_reached"))))
+	} else if usagePercentage > 80 {
 		output.WriteString(fmt.Sprintf("   ⚠️  %s\n", 
-			f.Colors.BrightYellow(fmt.Sprintf("估算剩余 %d 轮对话，请注意用量", quota.Remaining))))
+			f.Colors.BrightYellow(i18n.T("fmt.quota.low_remaining", quota.Remaining))))
 	} else {
 		output.WriteString(fmt.Sprintf("   🎯 %s\n", 
-			f.Colors.BrightGreen(fmt.Sprintf("预计还可以进行 %d 轮对话", quota.Remaining))))
+			f.Colors.BrightGreen(i18n.T("fmt.quota.ok_remaining", quota.Remaining))))
+	}
+}
+
+// quotaPercentage 计算额度使用百分比，额度为0时视为未使用
+func quotaPercentage(used, limit int) float64 {
+	if limit <= 0 {
+		return 0
 	}
+	return float64(used) / float64(limit) * 100
 }
 
 // getPlanPrice 获取计划价格（字符串）
@@ -888,16 +993,16 @@ func getPlanCostFloat(plan string) float64 {
 // formatDuration 格式化时间间隔
 func formatDuration(d time.Duration) string {
 	if d < 0 {
-		return "已过期"
+		return i18n.T("fmt.duration.expired")
 	}
 	
 	hours := int(d.Hours())
 	minutes := int(d.Minutes()) % 60
 	
 	if hours > 0 {
-		return fmt.Sprintf("%d小时%d分钟后", hours, minutes)
+		return i18n.T("fmt.duration.hours_minutes", hours, minutes)
 	}
-	return fmt.Sprintf("%d分钟后", minutes)
+	return i18n.T("fmt.duration.minutes", minutes)
 }
 
 // writeDetailedStats 写入详细统计
@@ -921,8 +1026,8 @@ func (f *Formatter) writeDetailedStats(output *strings.Builder, stats *models.Us
 // writeProjectStats 写入项目统计
 func (f *Formatter) writeProjectStats(output *strings.Builder, stats *models.UsageStats) {
 	t := table.NewWriter()
-	t.SetTitle("📁 项目统计")
-	t.AppendHeader(table.Row{"项目", "路径", "Token数", "最后活动"})
+	t.SetTitle("📁 " + i18n.T("fmt.projects.title"))
+	t.AppendHeader(table.Row{i18n.T("col.project"), i18n.T("col.path"), i18n.T("col.tokens"), i18n.T("col.last_activity")})
 
 	// 按Token数排序
 	type projectStat struct {
@@ -960,8 +1065,8 @@ func (f *Formatter) writeProjectStats(output *strings.Builder, stats *models.Usa
 // writeDailyStats 写入每日统计
 func (f *Formatter) writeDailyStats(output *strings.Builder, stats *models.UsageStats) {
 	t := table.NewWriter()
-	t.SetTitle("📅 每日使用统计")
-	t.AppendHeader(table.Row{"日期", "输入", "输出", "缓存", "总计"})
+	t.SetTitle("📅 " + i18n.T("fmt.daily.title"))
+	t.AppendHeader(table.Row{i18n.T("col.date"), i18n.T("col.input"), i18n.T("col.output"), i18n.T("col.cache"), i18n.T("col.total")})
 
 	// 按日期排序
 	var dates []string
@@ -989,8 +1094,8 @@ func (f *Formatter) writeDailyStats(output *strings.Builder, stats *models.Usage
 // writeSessionStats 写入会话统计
 func (f *Formatter) writeSessionStats(output *strings.Builder, stats *models.UsageStats) {
 	t := table.NewWriter()
-	t.SetTitle("💬 会话统计 (最近20个)")
-	t.AppendHeader(table.Row{"会话ID", "开始时间", "消息数", "Token数", "模型"})
+	t.SetTitle("💬 " + i18n.T("fmt.sessions.title"))
+	t.AppendHeader(table.Row{i18n.T("col.session_id"), i18n.T("col.start_time"), i18n.T("col.messages"), i18n.T("col.tokens"), i18n.T("col.sidechain_tokens"), i18n.T("col.model")})
 
 	// 按开始时间排序
 	type sessionInfo struct {
@@ -1015,11 +1120,17 @@ func (f *Formatter) writeSessionStats(output *strings.Builder, stats *models.Usa
 
 	for i := 0; i < maxSessions; i++ {
 		s := sessions[i]
+		// 显示会话ID的前8位
+		shortID := s.id
+		if len(shortID) > 8 {
+			shortID = shortID[:8] + "..."
+		}
 		t.AppendRow(table.Row{
-			s.id[:8] + "...", // 显示会话ID的前8位
+			shortID,
 			s.info.StartTime.Format("01-02 15:04"),
 			s.info.MessageCount,
 			formatNumber(s.info.Tokens.GetTotalTokens()),
+			formatNumber(s.info.Sidechain.Tokens.GetTotalTokens()),
 			s.info.Model,
 		})
 	}
@@ -1035,45 +1146,45 @@ func (f *Formatter) writeSubscriptionSuggestions(output *strings.Builder, stats
 	}
 	
 	output.WriteString("\n")
-	suggestionTitle := f.Colors.IconHeader("🎯", "订阅使用建议", BrightMagenta)
+	suggestionTitle := f.Colors.IconHeader("🎯", i18n.T("fmt.suggest.title"), BrightMagenta)
 	output.WriteString(fmt.Sprintf("%s\n", suggestionTitle))
 	
 	output.WriteString(fmt.Sprintf("   ⚠️  %s\n", 
-		f.Colors.Warning("以下建议基于估算数据，请结合实际使用情况判断")))
+		f.Colors.Warning(i18n.T("fmt.suggest.estimate_warning"))))
 	output.WriteString("\n")
 	
 	// 通用使用建议
 	output.WriteString(fmt.Sprintf("   💡 %s\n", 
-		f.Colors.Info("效率提升技巧：")))
-	output.WriteString(fmt.Sprintf("      • 使用 %s 清理上下文\n", f.Colors.BrightCyan("/compact")))
-	output.WriteString(fmt.Sprintf("      • 使用 %s 重置对话\n", f.Colors.BrightCyan("/clear")))
-	output.WriteString(fmt.Sprintf("      • 使用 %s 查看实时限额\n", f.Colors.BrightCyan("/status")))
+		f.Colors.Info(i18n.T("fmt.suggest.tips"))))
+	output.WriteString("      • " + i18n.T("fmt.suggest.tip_compact", f.Colors.BrightCyan("/compact")) + "\n")
+	output.WriteString("      • " + i18n.T("fmt.suggest.tip_clear", f.Colors.BrightCyan("/clear")) + "\n")
+	output.WriteString("      • " + i18n.T("fmt.suggest.tip_status", f.Colors.BrightCyan("/status")) + "\n")
 	
 	if stats.SubscriptionQuota != nil {
 		quota := stats.SubscriptionQuota
-		usagePercentage := float64(quota.EstimatedUsed) / float64(quota.MessagesPerWindow) * 100
+		usagePercentage := quotaPercentage(quota.MessagesUsed, quota.MessagesPerWindow)
 		
 		// 基于使用率的建议
 		if usagePercentage > 80 {
 			output.WriteString(fmt.Sprintf("\n   ⚠️  %s\n", 
-				f.Colors.Warning("当前窗口使用率较高")))
-			output.WriteString(fmt.Sprintf("      • 考虑使用 %s 减少上下文\n", f.Colors.BrightCyan("/compact")))
-			output.WriteString(fmt.Sprintf("      • 避免长时间连续对话\n"))
+				f.Colors.Warning(i18n.T("fmt.suggest.high_usage"))))
+			output.WriteString("      • " + i18n.T("fmt.suggest.high_compact", f.Colors.BrightCyan("/compact")) + "\n")
+			output.WriteString("      • " + i18n.T("fmt.suggest.high_avoid_long") + "\n")
 			if quota.Plan == "Pro" {
-				output.WriteString(fmt.Sprintf("      • 如经常遇到限制，可考虑升级计划\n"))
+				output.WriteString("      • " + i18n.T("fmt.suggest.high_upgrade") + "\n")
 			}
 		} else if usagePercentage < 20 {
 			output.WriteString(fmt.Sprintf("\n   ✅ %s\n", 
-				f.Colors.Success("当前窗口使用充裕")))
-			output.WriteString(fmt.Sprintf("      • 当前计划适合您的使用模式\n"))
+				f.Colors.Success(i18n.T("fmt.suggest.low_usage"))))
+			output.WriteString("      • " + i18n.T("fmt.suggest.low_plan_fits") + "\n")
 		}
 		
 		// 时区相关建议
 		output.WriteString(fmt.Sprintf("\n   🌍 %s\n", 
-			f.Colors.Info("时区注意事项：")))
-		output.WriteString(fmt.Sprintf("      • Claude Code限额可能基于UTC时区\n"))
-		output.WriteString(fmt.Sprintf("      • 重置时间可能与您的本地时间不同\n"))
-		output.WriteString(fmt.Sprintf("      • 建议使用 %s 确认准确时间\n", f.Colors.BrightCyan("/status")))
+			f.Colors.Info(i18n.T("fmt.suggest.timezone"))))
+		output.WriteString("      • " + i18n.T("fmt.suggest.timezone_utc") + "\n")
+		output.WriteString("      • " + i18n.T("fmt.suggest.timezone_local") + "\n")
+		output.WriteString("      • " + i18n.T("fmt.suggest.timezone_status", f.Colors.BrightCyan("/status")) + "\n")
 		
 		// 成本效益信息
 		apiEquivalentCost := stats.EstimatedCost.TotalCost
@@ -1081,12 +1192,12 @@ func (f *Formatter) writeSubscriptionSuggestions(output *strings.Builder, stats
 		if apiEquivalentCost > planCost {
 			savings := apiEquivalentCost - planCost
 			output.WriteString(fmt.Sprintf("\n   💰 %s\n", 
-				f.Colors.Success(fmt.Sprintf("订阅模式相比API节省约 $%.2f/月", savings))))
+				f.Colors.Success(i18n.T("fmt.suggest.savings", savings))))
 		}
 	} else {
 		// 兜底建议
-		output.WriteString(fmt.Sprintf("   💡 %s\n", f.Colors.Info("建议在Claude Code中使用 /status 查看详细限额信息")))
-		output.WriteString(fmt.Sprintf("   📚 %s\n", f.Colors.Dim("参考官方文档了解订阅计划详情")))
+		output.WriteString(fmt.Sprintf("   💡 %s\n", f.Colors.Info(i18n.T("fmt.suggest.fallback_status"))))
+		output.WriteString(fmt.Sprintf("   📚 %s\n", f.Colors.Dim(i18n.T("fmt.suggest.fallback_docs"))))
 	}
 }
 
@@ -1096,9 +1207,9 @@ func (f *Formatter) writeSubscriptionSuggestions(output *strings.Builder, stats
 func getModeDisplay(mode string) string {
 	switch mode {
 	case "api":
-		return "API模式 (按token计费)"
+		return i18n.T("fmt.mode.api")
 	case "subscription":
-		return "订阅模式 (按请求限制)"
+		return i18n.T("fmt.mode.subscription")
 	default:
 		return mode
 	}
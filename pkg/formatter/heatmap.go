@@ -0,0 +1,153 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// heatShades 热力图从低到高使用的字符（无颜色时也能区分强度）
+var heatShades = []string{"·", "░", "▒", "▓", "█"}
+
+// FormatHeatmap 格式化热力图为终端网格
+func (f *Formatter) FormatHeatmap(report *models.HeatmapReport) (string, error) {
+	var output strings.Builder
+
+	// 添加标题
+	output.WriteString(f.Colors.IconHeader("🔥", i18n.T("fmt.heatmap.title", i18n.T("fmt.heatmap.metric_"+report.Metric)), BrightRed))
+	output.WriteString("\n")
+
+	if report.Total == 0 {
+		output.WriteString("\n   📝 " + i18n.T("fmt.heatmap.empty") + "\n")
+		return output.String(), nil
+	}
+
+	output.WriteString(f.Colors.Dim("   * " + i18n.T("fmt.heatmap.timezone_hint", report.Timezone) + "\n\n"))
+
+	labels := strings.Split(i18n.T("fmt.heatmap.weekdays"), ",")
+	labelWidth := 0
+	for _, label := range labels {
+		if w := text.RuneWidthWithoutEscSequences(label); w > labelWidth {
+			labelWidth = w
+		}
+	}
+
+	// 小时刻度
+	output.WriteString("   " + strings.Repeat(" ", labelWidth+1))
+	for hour := 0; hour < 24; hour++ {
+		if hour%3 == 0 {
+			output.WriteString(f.Colors.Dim(fmt.Sprintf("%-2d", hour)))
+		} else {
+			output.WriteString("  ")
+		}
+	}
+	output.WriteString("  " + f.Colors.Dim(i18n.T("common.total")) + "\n")
+
+	var hourTotals [24]float64
+	for day, hours := range report.Matrix {
+		label := report.Weekdays[day]
+		if day < len(labels) {
+			label = labels[day]
+		}
+		output.WriteString("   " + label + strings.Repeat(" ", labelWidth-text.RuneWidthWithoutEscSequences(label)+1))
+
+		var rowTotal float64
+		for hour, value := range hours {
+			rowTotal += value
+			hourTotals[hour] += value
+			output.WriteString(f.heatCell(value, report.Max))
+		}
+		output.WriteString("  " + formatMetricValue(report.Metric, rowTotal) + "\n")
+	}
+
+	// 每小时合计的走势
+	hourValues := make([]float64, 24)
+	copy(hourValues, hourTotals[:])
+	output.WriteString("   " + strings.Repeat(" ", labelWidth+1))
+	for _, r := range sparkline(hourValues) {
+		output.WriteString(f.Colors.Info(string(r) + string(r)))
+	}
+	output.WriteString("  " + f.Colors.Bold(formatMetricValue(report.Metric, report.Total)) + "\n\n")
+
+	// 图例
+	legend := []string{f.heatCell(0, report.Max)}
+	for i := 1; i < len(heatShades); i++ {
+		intensity := (float64(i) - 0.5) / float64(len(heatShades)-1)
+		legend = append(legend, f.heatCell(report.Max*intensity, report.Max))
+	}
+	output.WriteString("   " + i18n.T("fmt.heatmap.legend", strings.Join(legend, " "),
+		formatMetricValue(report.Metric, report.Max)) + "\n\n")
+
+	return output.String(), nil
+}
+
+// heatCell 绘制热力图的一个格子（两个字符宽）
+func (f *Formatter) heatCell(value, max float64) string {
+	if value <= 0 || max <= 0 {
+		return f.Colors.Dim(heatShades[0] + " ")
+	}
+	intensity := value / max
+	level := 1 + int(intensity*float64(len(heatShades)-1))
+	if level >= len(heatShades) {
+		level = len(heatShades) - 1
+	}
+	shade := heatShades[level]
+	return f.Colors.Heat(shade+shade, intensity)
+}
+
+// FormatHeatmapJSON 格式化热力图为JSON
+func (f *Formatter) FormatHeatmapJSON(report *models.HeatmapReport) (string, error) {
+	return marshalReport(report)
+}
+
+// FormatHeatmapCSV 格式化热力图矩阵为CSV（每个星期一行，每小时一列）
+func (f *Formatter) FormatHeatmapCSV(report *models.HeatmapReport) (string, error) {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+
+	// 写入标题行（使用与语言无关的稳定标识，便于脚本处理）
+	headers := []string{"weekday"}
+	for hour := 0; hour < 24; hour++ {
+		headers = append(headers, fmt.Sprintf("h%02d", hour))
+	}
+	headers = append(headers, "total")
+	if err := writer.Write(headers); err != nil {
+		return "", err
+	}
+
+	for day, hours := range report.Matrix {
+		row := []string{report.Weekdays[day]}
+		var rowTotal float64
+		for _, value := range hours {
+			rowTotal += value
+			row = append(row, formatMetricCSV(report.Metric, value))
+		}
+		row = append(row, formatMetricCSV(report.Metric, rowTotal))
+		if err := writer.Write(row); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return builder.String(), writer.Error()
+}
+
+// formatMetricValue 按指标类型格式化数值（表格用）
+func formatMetricValue(metric string, value float64) string {
+	if metric == "cost" {
+		return fmt.Sprintf("$%.2f", value)
+	}
+	return formatNumber(int(value + 0.5))
+}
+
+// formatMetricCSV 按指标类型格式化数值（CSV用）
+func formatMetricCSV(metric string, value float64) string {
+	if metric == "cost" {
+		return fmt.Sprintf("%.4f", value)
+	}
+	return fmt.Sprintf("%.0f", value)
+}
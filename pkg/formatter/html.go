@@ -0,0 +1,737 @@
+package formatter
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// HTML报告的图表尺寸（SVG viewBox 坐标，实际显示随页面宽度缩放）
+const (
+	svgWidth        = 720
+	svgHeight       = 240
+	svgPadLeft      = 64
+	svgPadRight     = 16
+	svgPadTop       = 16
+	svgPadBottom    = 32
+	htmlPieSlices   = 8  // 饼图最多显示的扇区数，其余合并为“其他”
+	htmlBarProjects = 15 // 项目条形图最多显示的项目数
+)
+
+// htmlPalette 图表配色
+var htmlPalette = []string{
+	"#4e79a7", "#f28e2b", "#59a14f", "#e15759", "#76b7b2",
+	"#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac",
+}
+
+// htmlPage 一个完整的HTML报告页面
+type htmlPage struct {
+	Lang     string
+	Title    string
+	Subtitle string
+	Notes    []string
+	Cards    []htmlCard
+	Charts   []htmlChart
+	Tables   []htmlTable
+	SortHint string
+}
+
+// htmlCard 页面顶部的汇总指标
+type htmlCard struct {
+	Label string
+	Value string
+}
+
+// htmlChart 一张内联SVG图表
+type htmlChart struct {
+	Title string
+	SVG   template.HTML
+}
+
+// htmlTable 一张可排序的表格
+type htmlTable struct {
+	Title   string
+	Columns []string
+	Rows    [][]htmlCell
+	Footer  []htmlCell
+}
+
+// htmlCell 表格单元格；Sort 为排序键，数值列按数字排序
+type htmlCell struct {
+	Text    string
+	Sort    string
+	Numeric bool
+}
+
+// chartPoint 折线图、饼图和条形图共用的数据点
+type chartPoint struct {
+	Label string
+	Value float64
+}
+
+// FormatDailyHTML 格式化日报告为独立的HTML页面
+func (f *Formatter) FormatDailyHTML(report *models.DailyReport) (string, error) {
+	page := newHTMLPage(i18n.T("fmt.daily.title"))
+	page.Notes = []string{i18n.T("fmt.daily.cost_notice"), i18n.T("fmt.daily.subscription_notice")}
+	page.Cards = []htmlCard{
+		{Label: i18n.T("col.equivalent_cost"), Value: fmt.Sprintf("$%.2f", report.Summary.CostUSD)},
+		{Label: i18n.T("col.total_tokens"), Value: formatNumber(report.Summary.TotalTokens)},
+		{Label: i18n.T("col.messages"), Value: formatNumber(report.Summary.MessageCount)},
+		{Label: i18n.T("fmt.report.days"), Value: formatNumber(len(report.DailyData))},
+	}
+
+	days := make([]models.DailyDataPoint, len(report.DailyData))
+	copy(days, report.DailyData)
+	sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+
+	points := make([]chartPoint, 0, len(days))
+	modelCosts := make(map[string]float64)
+	for _, day := range days {
+		points = append(points, chartPoint{Label: day.Date, Value: day.CostUSD})
+		for model, data := range day.Breakdown {
+			modelCosts[model] += data.CostUSD
+		}
+	}
+	page.addChart(i18n.T("fmt.chart.daily_cost"), svgLineChart(points))
+	page.addChart(i18n.T("fmt.html.model_share"), svgPieChart(sortedPoints(modelCosts)))
+
+	dailyTable := htmlTable{
+		Title: i18n.T("fmt.daily.title"),
+		Columns: []string{
+			i18n.T("col.date"), i18n.T("col.model"), i18n.T("col.input_tokens"), i18n.T("col.output_tokens"),
+			i18n.T("col.cache_creation"), i18n.T("col.cache_read"), i18n.T("col.total_tokens"),
+			i18n.T("col.equivalent_cost"), i18n.T("col.messages"), i18n.T("col.sessions"),
+		},
+	}
+	for _, day := range report.DailyData {
+		dailyTable.Rows = append(dailyTable.Rows, []htmlCell{
+			textCell(day.Date),
+			textCell(strings.Join(day.Models, ", ")),
+			intCell(day.InputTokens),
+			intCell(day.OutputTokens),
+			intCell(day.CacheCreationTokens),
+			intCell(day.CacheReadTokens),
+			intCell(day.TotalTokens),
+			costCell(day.CostUSD),
+			intCell(day.MessageCount),
+			intCell(day.SessionCount),
+		})
+	}
+	summary := report.Summary
+	dailyTable.Footer = []htmlCell{
+		textCell(i18n.T("common.total")), textCell(""),
+		intCell(summary.InputTokens), intCell(summary.OutputTokens),
+		intCell(summary.CacheCreationTokens), intCell(summary.CacheReadTokens),
+		intCell(summary.TotalTokens), costCell(summary.CostUSD),
+		intCell(summary.MessageCount), intCell(summary.SessionCount),
+	}
+	page.Tables = append(page.Tables, dailyTable)
+
+	return renderHTMLPage(page)
+}
+
+// FormatBlocksHTML 格式化blocks报告为独立的HTML页面
+func (f *Formatter) FormatBlocksHTML(report *models.BlocksReport) (string, error) {
+	page := newHTMLPage(i18n.T("fmt.blocks.title"))
+	page.Cards = []htmlCard{
+		{Label: i18n.T("col.cost_usd"), Value: fmt.Sprintf("$%.2f", report.TotalCost)},
+		{Label: i18n.T("col.total_tokens"), Value: formatNumber(report.Summary.GetTotalTokens())},
+		{Label: i18n.T("fmt.report.blocks"), Value: formatNumber(len(report.Blocks))},
+	}
+	page.addChart(i18n.T("fmt.html.block_timeline"), svgBlockTimeline(report.Blocks))
+
+	blocksTable := htmlTable{
+		Title: i18n.T("fmt.blocks.title"),
+		Columns: []string{
+			i18n.T("col.block_start"), i18n.T("col.status"), i18n.T("col.model"),
+			i18n.T("col.input_tokens"), i18n.T("col.output_tokens"), i18n.T("col.total_tokens"),
+			i18n.T("col.cost_usd"), i18n.T("col.messages"),
+		},
+	}
+	for _, block := range report.Blocks {
+		status := i18n.T("fmt.blocks.completed")
+		if block.IsActive {
+			status = i18n.T("fmt.blocks.active", block.TimeRemaining)
+		}
+		blocksTable.Rows = append(blocksTable.Rows, []htmlCell{
+			{Text: block.StartTime.Format("2006-01-02 15:04:05"), Sort: block.StartTime.Format(time.RFC3339)},
+			textCell(status),
+			textCell(strings.Join(block.Models, ", ")),
+			intCell(block.Tokens.InputTokens),
+			intCell(block.Tokens.OutputTokens),
+			intCell(block.Tokens.GetTotalTokens()),
+			costCell(block.CostUSD),
+			intCell(block.MessageCount),
+		})
+	}
+	blocksTable.Footer = []htmlCell{
+		textCell(i18n.T("common.total")), textCell(""), textCell(""),
+		intCell(report.Summary.InputTokens), intCell(report.Summary.OutputTokens),
+		intCell(report.Summary.GetTotalTokens()), costCell(report.TotalCost), textCell(""),
+	}
+	page.Tables = append(page.Tables, blocksTable)
+
+	return renderHTMLPage(page)
+}
+
+// FormatProjectsHTML 格式化项目报告为独立的HTML页面
+func (f *Formatter) FormatProjectsHTML(report *models.ProjectsReport) (string, error) {
+	page := newHTMLPage(i18n.T("fmt.projects.title"))
+	page.Cards = []htmlCard{
+		{Label: i18n.T("col.cost_usd"), Value: fmt.Sprintf("$%.2f", report.Summary.Cost)},
+		{Label: i18n.T("col.total_tokens"), Value: formatNumber(report.Summary.Tokens.GetTotalTokens())},
+		{Label: i18n.T("col.sessions"), Value: formatNumber(report.Summary.SessionCount)},
+		{Label: i18n.T("fmt.projects.title"), Value: formatNumber(len(report.Projects))},
+	}
+
+	modelCosts := make(map[string]float64)
+	projectCosts := make(map[string]float64)
+	for _, project := range report.Projects {
+		for model, bucket := range project.Models {
+			modelCosts[model] += bucket.CostUSD
+		}
+		projectCosts[project.ProjectName] += project.Cost
+	}
+	page.addChart(i18n.T("fmt.chart.daily_cost"), svgLineChart(datePoints(projectDailyCosts(report.Projects))))
+	page.addChart(i18n.T("fmt.html.model_share"), svgPieChart(sortedPoints(modelCosts)))
+	page.addChart(i18n.T("fmt.html.project_cost", htmlBarProjects), svgBarChart(sortedPoints(projectCosts), htmlBarProjects))
+
+	page.Tables = append(page.Tables, projectsHTMLTable(report.Projects))
+
+	return renderHTMLPage(page)
+}
+
+// formatHTML 格式化综合分析结果为独立的HTML页面
+func (f *Formatter) formatHTML(stats *models.UsageStats) (string, error) {
+	page := newHTMLPage(i18n.T("fmt.report.title"))
+	if !stats.AnalysisPeriod.StartTime.IsZero() {
+		page.Subtitle = i18n.T("fmt.basic.time_range",
+			stats.AnalysisPeriod.StartTime.Format("2006-01-02 15:04"),
+			stats.AnalysisPeriod.EndTime.Format("2006-01-02 15:04")) + "  •  " + page.Subtitle
+	}
+	page.Cards = []htmlCard{
+		{Label: i18n.T("col.cost_usd"), Value: fmt.Sprintf("$%.2f", stats.EstimatedCost.TotalCost)},
+		{Label: i18n.T("col.total_tokens"), Value: formatNumber(stats.TotalTokens.GetTotalTokens())},
+		{Label: i18n.T("fmt.basic.total_sessions"), Value: formatNumber(stats.TotalSessions)},
+		{Label: i18n.T("fmt.basic.total_messages"), Value: formatNumber(stats.TotalMessages)},
+	}
+
+	projectCosts := make(map[string]float64)
+	projects := make([]models.ProjectStats, 0, len(stats.ProjectStats))
+	for _, project := range stats.ProjectStats {
+		projectCosts[project.ProjectName] += project.Cost
+		projects = append(projects, project)
+	}
+	// 每日成本来自各项目的每日明细，与 JSON 中的 project_stats 一致
+	page.addChart(i18n.T("fmt.chart.daily_cost"), svgLineChart(datePoints(projectDailyCosts(projects))))
+	page.addChart(i18n.T("fmt.html.model_share"), svgPieChart(sortedPoints(stats.EstimatedCost.ModelCosts)))
+	page.addChart(i18n.T("fmt.html.project_cost", htmlBarProjects), svgBarChart(sortedPoints(projectCosts), htmlBarProjects))
+
+	// 模型表
+	modelTable := htmlTable{
+		Title: i18n.T("fmt.models.title"),
+		Columns: []string{
+			i18n.T("col.model"), i18n.T("col.input"), i18n.T("col.output"),
+			i18n.T("col.cache_creation"), i18n.T("col.cache_read"), i18n.T("col.total"), i18n.T("col.cost_usd"),
+		},
+	}
+	for model, usage := range stats.ModelStats {
+		modelTable.Rows = append(modelTable.Rows, []htmlCell{
+			textCell(model),
+			intCell(usage.InputTokens),
+			intCell(usage.OutputTokens),
+			intCell(usage.CacheCreationTokens),
+			intCell(usage.CacheReadTokens),
+			intCell(usage.GetTotalTokens()),
+			costCell(stats.EstimatedCost.ModelCosts[model]),
+		})
+	}
+	sortCellRows(modelTable.Rows, len(modelTable.Columns)-1)
+	page.Tables = append(page.Tables, modelTable)
+
+	// 项目表
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Cost > projects[j].Cost })
+	page.Tables = append(page.Tables, projectsHTMLTable(projects))
+
+	// 会话表
+	sessionTable := htmlTable{
+		Title: i18n.T("fmt.html.sessions"),
+		Columns: []string{
+			i18n.T("col.session_id"), i18n.T("col.start_time"), i18n.T("col.project"), i18n.T("col.model"),
+			i18n.T("col.messages"), i18n.T("col.tokens"), i18n.T("col.cost_usd"),
+		},
+	}
+	sessions := make([]models.SessionInfo, 0, len(stats.SessionStats))
+	for _, session := range stats.SessionStats {
+		sessions = append(sessions, session)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].StartTime.After(sessions[j].StartTime) })
+	for _, session := range sessions {
+		sessionTable.Rows = append(sessionTable.Rows, []htmlCell{
+			textCell(session.ID),
+			{Text: session.StartTime.Format("2006-01-02 15:04"), Sort: session.StartTime.Format(time.RFC3339)},
+			textCell(session.ProjectPath),
+			textCell(session.Model),
+			intCell(session.MessageCount),
+			intCell(session.Tokens.GetTotalTokens()),
+			costCell(session.Cost),
+		})
+	}
+	page.Tables = append(page.Tables, sessionTable)
+
+	return renderHTMLPage(page)
+}
+
+// projectsHTMLTable 创建项目表（projects 和 analyze 页面共用）
+func projectsHTMLTable(projects []models.ProjectStats) htmlTable {
+	projectTable := htmlTable{
+		Title: i18n.T("fmt.projects.title"),
+		Columns: []string{
+			i18n.T("col.project"), i18n.T("col.path"), i18n.T("col.sessions"), i18n.T("col.messages"),
+			i18n.T("col.total_tokens"), i18n.T("col.cost_usd"), i18n.T("col.sidechain_cost"),
+			i18n.T("col.last_activity"),
+		},
+	}
+	for _, project := range projects {
+		path := project.ProjectPath
+		if len(project.Paths) > 0 {
+			path = strings.Join(project.Paths, ", ")
+		}
+		projectTable.Rows = append(projectTable.Rows, []htmlCell{
+			textCell(project.ProjectName),
+			textCell(path),
+			intCell(project.SessionCount),
+			intCell(project.MessageCount),
+			intCell(project.Tokens.GetTotalTokens()),
+			costCell(project.Cost),
+			costCell(project.Sidechain.CostUSD),
+			{Text: project.LastActivity.Format("2006-01-02 15:04"), Sort: project.LastActivity.Format(time.RFC3339)},
+		})
+	}
+	return projectTable
+}
+
+// projectDailyCosts 汇总各项目的每日成本
+func projectDailyCosts(projects []models.ProjectStats) map[string]float64 {
+	costs := make(map[string]float64)
+	for _, project := range projects {
+		for date, bucket := range project.Daily {
+			costs[date] += bucket.CostUSD
+		}
+	}
+	return costs
+}
+
+// newHTMLPage 创建带通用标题信息的页面
+func newHTMLPage(title string) *htmlPage {
+	return &htmlPage{
+		Lang:     i18n.Lang(),
+		Title:    title,
+		Subtitle: i18n.T("fmt.html.generated", time.Now().Format("2006-01-02 15:04:05")),
+		SortHint: i18n.T("fmt.html.sort_hint"),
+	}
+}
+
+// addChart 添加图表；没有数据的图表（SVG为空）不显示
+func (p *htmlPage) addChart(title string, svg template.HTML) {
+	if svg == "" {
+		return
+	}
+	p.Charts = append(p.Charts, htmlChart{Title: title, SVG: svg})
+}
+
+// renderHTMLPage 渲染页面模板
+func renderHTMLPage(page *htmlPage) (string, error) {
+	var output strings.Builder
+	if err := htmlReportTemplate.Execute(&output, page); err != nil {
+		return "", err
+	}
+	return output.String(), nil
+}
+
+func textCell(text string) htmlCell {
+	return htmlCell{Text: text, Sort: strings.ToLower(text)}
+}
+
+func intCell(value int) htmlCell {
+	return htmlCell{Text: formatNumber(value), Sort: fmt.Sprintf("%d", value), Numeric: true}
+}
+
+func costCell(value float64) htmlCell {
+	return htmlCell{Text: fmt.Sprintf("$%.4f", value), Sort: fmt.Sprintf("%.6f", value), Numeric: true}
+}
+
+// sortCellRows 按某个数值列降序排列表格行
+func sortCellRows(rows [][]htmlCell, column int) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		var a, b float64
+		fmt.Sscan(rows[i][column].Sort, &a)
+		fmt.Sscan(rows[j][column].Sort, &b)
+		return a > b
+	})
+}
+
+// sortedPoints 将 名称->数值 转为按数值降序排列的数据点（忽略非正值）
+func sortedPoints(values map[string]float64) []chartPoint {
+	points := make([]chartPoint, 0, len(values))
+	for label, value := range values {
+		if value > 0 {
+			points = append(points, chartPoint{Label: label, Value: value})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].Value != points[j].Value {
+			return points[i].Value > points[j].Value
+		}
+		return points[i].Label < points[j].Label
+	})
+	return points
+}
+
+// datePoints 将 日期->数值 转为按日期升序排列的数据点
+func datePoints(values map[string]float64) []chartPoint {
+	points := make([]chartPoint, 0, len(values))
+	for date, value := range values {
+		points = append(points, chartPoint{Label: date, Value: value})
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Label < points[j].Label })
+	return points
+}
+
+// svgLineChart 绘制折线图（用于每日成本）
+func svgLineChart(points []chartPoint) template.HTML {
+	if len(points) == 0 {
+		return ""
+	}
+
+	maxValue := 0.0
+	for _, point := range points {
+		maxValue = math.Max(maxValue, point.Value)
+	}
+	if maxValue <= 0 {
+		maxValue = 1
+	}
+
+	plotWidth := float64(svgWidth - svgPadLeft - svgPadRight)
+	plotHeight := float64(svgHeight - svgPadTop - svgPadBottom)
+	x := func(i int) float64 {
+		if len(points) == 1 {
+			return svgPadLeft + plotWidth/2
+		}
+		return svgPadLeft + plotWidth*float64(i)/float64(len(points)-1)
+	}
+	y := func(value float64) float64 {
+		return svgPadTop + plotHeight*(1-value/maxValue)
+	}
+
+	var svg strings.Builder
+	svgOpen(&svg, svgHeight)
+	writeValueAxis(&svg, maxValue, plotHeight)
+
+	var line, area strings.Builder
+	fmt.Fprintf(&area, "%.1f,%.1f ", x(0), y(0))
+	for i, point := range points {
+		fmt.Fprintf(&line, "%.1f,%.1f ", x(i), y(point.Value))
+		fmt.Fprintf(&area, "%.1f,%.1f ", x(i), y(point.Value))
+	}
+	fmt.Fprintf(&area, "%.1f,%.1f", x(len(points)-1), y(0))
+	fmt.Fprintf(&svg, `<polygon points="%s" fill="%s" fill-opacity="0.15"/>`, area.String(), htmlPalette[0])
+	fmt.Fprintf(&svg, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`, strings.TrimSpace(line.String()), htmlPalette[0])
+	for i, point := range points {
+		fmt.Fprintf(&svg, `<circle cx="%.1f" cy="%.1f" r="3" fill="%s"><title>%s: $%.2f</title></circle>`,
+			x(i), y(point.Value), htmlPalette[0], template.HTMLEscapeString(point.Label), point.Value)
+	}
+
+	// X轴标签：首、中、尾
+	labelIndexes := []int{0, len(points) / 2, len(points) - 1}
+	seen := make(map[int]bool)
+	for _, i := range labelIndexes {
+		if seen[i] {
+			continue
+		}
+		seen[i] = true
+		anchor := "middle"
+		if i == 0 && len(points) > 1 {
+			anchor = "start"
+		} else if i == len(points)-1 && len(points) > 1 {
+			anchor = "end"
+		}
+		fmt.Fprintf(&svg, `<text x="%.1f" y="%d" text-anchor="%s" class="axis">%s</text>`,
+			x(i), svgHeight-10, anchor, template.HTMLEscapeString(points[i].Label))
+	}
+
+	svg.WriteString("</svg>")
+	return template.HTML(svg.String())
+}
+
+// svgPieChart 绘制饼图和图例（用于模型成本占比）
+func svgPieChart(points []chartPoint) template.HTML {
+	if len(points) == 0 {
+		return ""
+	}
+
+	// 超出的部分合并为“其他”
+	if len(points) > htmlPieSlices {
+		other := 0.0
+		for _, point := range points[htmlPieSlices-1:] {
+			other += point.Value
+		}
+		points = append(points[:htmlPieSlices-1:htmlPieSlices-1], chartPoint{Label: i18n.T("fmt.html.other"), Value: other})
+	}
+
+	total := 0.0
+	for _, point := range points {
+		total += point.Value
+	}
+
+	const cx, cy, r = 120.0, 120.0, 100.0
+	var svg strings.Builder
+	svgOpen(&svg, svgHeight)
+
+	angle := -math.Pi / 2
+	for i, point := range points {
+		color := htmlPalette[i%len(htmlPalette)]
+		share := point.Value / total
+		tooltip := fmt.Sprintf("%s: $%.2f (%.1f%%)", template.HTMLEscapeString(point.Label), point.Value, share*100)
+
+		if len(points) == 1 {
+			fmt.Fprintf(&svg, `<circle cx="%.0f" cy="%.0f" r="%.0f" fill="%s"><title>%s</title></circle>`, cx, cy, r, color, tooltip)
+		} else {
+			end := angle + share*2*math.Pi
+			largeArc := 0
+			if share > 0.5 {
+				largeArc = 1
+			}
+			fmt.Fprintf(&svg, `<path d="M%.0f,%.0f L%.2f,%.2f A%.0f,%.0f 0 %d 1 %.2f,%.2f Z" fill="%s" stroke="#fff" stroke-width="1"><title>%s</title></path>`,
+				cx, cy, cx+r*math.Cos(angle), cy+r*math.Sin(angle), r, r, largeArc,
+				cx+r*math.Cos(end), cy+r*math.Sin(end), color, tooltip)
+			angle = end
+		}
+
+		// 图例
+		legendY := 28 + i*24
+		fmt.Fprintf(&svg, `<rect x="260" y="%d" width="12" height="12" fill="%s"/>`, legendY-10, color)
+		fmt.Fprintf(&svg, `<text x="280" y="%d" class="label">%s  $%.2f (%.1f%%)</text>`,
+			legendY, template.HTMLEscapeString(point.Label), point.Value, share*100)
+	}
+
+	svg.WriteString("</svg>")
+	return template.HTML(svg.String())
+}
+
+// svgBarChart 绘制横向条形图（用于项目成本）
+func svgBarChart(points []chartPoint, limit int) template.HTML {
+	if len(points) == 0 {
+		return ""
+	}
+	if len(points) > limit {
+		points = points[:limit]
+	}
+
+	const rowHeight, labelWidth, valueWidth = 22, 200, 80
+	height := svgPadTop + len(points)*rowHeight + 8
+	maxValue := points[0].Value
+	barSpace := float64(svgWidth - labelWidth - valueWidth - svgPadRight)
+
+	var svg strings.Builder
+	svgOpen(&svg, height)
+	for i, point := range points {
+		rowY := svgPadTop + i*rowHeight
+		barWidth := math.Max(1, barSpace*point.Value/maxValue)
+		fmt.Fprintf(&svg, `<text x="%d" y="%d" text-anchor="end" class="label">%s</text>`,
+			labelWidth-8, rowY+13, template.HTMLEscapeString(truncateString(point.Label, 28)))
+		fmt.Fprintf(&svg, `<rect x="%d" y="%d" width="%.1f" height="%d" fill="%s"><title>%s: $%.2f</title></rect>`,
+			labelWidth, rowY+2, barWidth, rowHeight-6, htmlPalette[i%len(htmlPalette)],
+			template.HTMLEscapeString(point.Label), point.Value)
+		fmt.Fprintf(&svg, `<text x="%.1f" y="%d" class="label">$%.2f</text>`,
+			float64(labelWidth)+barWidth+6, rowY+13, point.Value)
+	}
+
+	svg.WriteString("</svg>")
+	return template.HTML(svg.String())
+}
+
+// svgBlockTimeline 绘制计费窗口时间线：横向位置为窗口起止时间，高度为成本
+func svgBlockTimeline(blocks []models.BillingBlock) template.HTML {
+	if len(blocks) == 0 {
+		return ""
+	}
+
+	start, end := blocks[0].StartTime, blocks[0].EndTime
+	maxCost := 0.0
+	for _, block := range blocks {
+		if block.StartTime.Before(start) {
+			start = block.StartTime
+		}
+		if block.EndTime.After(end) {
+			end = block.EndTime
+		}
+		maxCost = math.Max(maxCost, block.CostUSD)
+	}
+	if maxCost <= 0 {
+		maxCost = 1
+	}
+	span := end.Sub(start).Seconds()
+	if span <= 0 {
+		span = 1
+	}
+
+	plotWidth := float64(svgWidth - svgPadLeft - svgPadRight)
+	plotHeight := float64(svgHeight - svgPadTop - svgPadBottom)
+	x := func(t time.Time) float64 {
+		return svgPadLeft + plotWidth*t.Sub(start).Seconds()/span
+	}
+
+	var svg strings.Builder
+	svgOpen(&svg, svgHeight)
+	writeValueAxis(&svg, maxCost, plotHeight)
+
+	baseline := float64(svgPadTop) + plotHeight
+	for _, block := range blocks {
+		color := htmlPalette[0]
+		if block.IsActive {
+			color = htmlPalette[1]
+		}
+		barHeight := math.Max(2, plotHeight*block.CostUSD/maxCost)
+		width := math.Max(1, x(block.EndTime)-x(block.StartTime))
+		tooltip := fmt.Sprintf("%s ~ %s: $%.2f, %s tokens", block.StartTime.Format("2006-01-02 15:04"),
+			block.EndTime.Format("15:04"), block.CostUSD, formatNumber(block.Tokens.GetTotalTokens()))
+		fmt.Fprintf(&svg, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s" fill-opacity="0.85"><title>%s</title></rect>`,
+			x(block.StartTime), baseline-barHeight, width, barHeight, color, template.HTMLEscapeString(tooltip))
+	}
+
+	// 时间轴标签
+	const ticks = 4
+	layout := "01-02 15:04"
+	for i := 0; i <= ticks; i++ {
+		t := start.Add(time.Duration(float64(end.Sub(start)) * float64(i) / ticks))
+		anchor := "middle"
+		if i == 0 {
+			anchor = "start"
+		} else if i == ticks {
+			anchor = "end"
+		}
+		fmt.Fprintf(&svg, `<text x="%.1f" y="%d" text-anchor="%s" class="axis">%s</text>`, x(t), svgHeight-10, anchor, t.Format(layout))
+	}
+
+	svg.WriteString("</svg>")
+	return template.HTML(svg.String())
+}
+
+// svgOpen 写入SVG开始标签
+func svgOpen(svg *strings.Builder, height int) {
+	fmt.Fprintf(svg, `<svg viewBox="0 0 %d %d" xmlns="http://www.w3.org/2000/svg" role="img">`, svgWidth, height)
+}
+
+// writeValueAxis 写入纵轴网格线和金额刻度
+func writeValueAxis(svg *strings.Builder, maxValue, plotHeight float64) {
+	const gridLines = 4
+	for i := 0; i <= gridLines; i++ {
+		value := maxValue * float64(i) / gridLines
+		lineY := float64(svgPadTop) + plotHeight*(1-float64(i)/gridLines)
+		fmt.Fprintf(svg, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" class="grid"/>`, svgPadLeft, lineY, svgWidth-svgPadRight, lineY)
+		fmt.Fprintf(svg, `<text x="%d" y="%.1f" text-anchor="end" class="axis">$%.2f</text>`, svgPadLeft-6, lineY+4, value)
+	}
+}
+
+// truncateString 按字符数截断过长的标签
+func truncateString(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-1]) + "…"
+}
+
+// htmlReportTemplate 报告页面模板：样式和排序脚本均内联，不引用任何外部资源
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", "PingFang SC", "Microsoft YaHei", sans-serif; margin: 0; padding: 24px 32px; color: #222; background: #f6f7f9; }
+h1 { margin: 0 0 4px; font-size: 24px; }
+h2 { font-size: 16px; margin: 0 0 12px; }
+.subtitle, .note, .hint { color: #666; font-size: 13px; }
+.note { margin: 4px 0; }
+.cards { display: flex; flex-wrap: wrap; gap: 12px; margin: 20px 0; }
+.card { background: #fff; border-radius: 8px; padding: 12px 18px; min-width: 150px; box-shadow: 0 1px 2px rgba(0,0,0,.08); }
+.card .label { color: #666; font-size: 12px; }
+.card .value { font-size: 22px; font-weight: 600; margin-top: 4px; }
+.charts { display: grid; grid-template-columns: repeat(auto-fit, minmax(480px, 1fr)); gap: 16px; }
+.panel { background: #fff; border-radius: 8px; padding: 16px; box-shadow: 0 1px 2px rgba(0,0,0,.08); margin-bottom: 16px; overflow-x: auto; }
+svg { width: 100%; height: auto; }
+svg .axis { font-size: 11px; fill: #777; }
+svg .label { font-size: 12px; fill: #333; }
+svg .grid { stroke: #e5e5e5; stroke-width: 1; }
+table { border-collapse: collapse; width: 100%; font-size: 13px; }
+th, td { padding: 6px 10px; border-bottom: 1px solid #eee; text-align: left; white-space: nowrap; }
+th { background: #fafafa; cursor: pointer; user-select: none; position: sticky; top: 0; }
+th.asc::after { content: " ▲"; }
+th.desc::after { content: " ▼"; }
+td.num, th.num { text-align: right; font-variant-numeric: tabular-nums; }
+tfoot td { font-weight: 600; border-top: 2px solid #ddd; }
+tbody tr:hover { background: #f3f7ff; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="subtitle">{{.Subtitle}}</div>
+{{range .Notes}}<p class="note">* {{.}}</p>
+{{end}}
+<div class="cards">
+{{range .Cards}}<div class="card"><div class="label">{{.Label}}</div><div class="value">{{.Value}}</div></div>
+{{end}}</div>
+{{if .Charts}}<div class="charts">
+{{range .Charts}}<div class="panel"><h2>{{.Title}}</h2>{{.SVG}}</div>
+{{end}}</div>
+{{end}}
+{{range .Tables}}<div class="panel">
+<h2>{{.Title}}</h2>
+<p class="hint">{{$.SortHint}}</p>
+<table class="sortable">
+<thead><tr>{{range $i, $column := .Columns}}<th data-index="{{$i}}">{{$column}}</th>{{end}}</tr></thead>
+<tbody>
+{{range .Rows}}<tr>{{range .}}<td{{if .Numeric}} class="num"{{end}} data-sort="{{.Sort}}">{{.Text}}</td>{{end}}</tr>
+{{end}}</tbody>
+{{if .Footer}}<tfoot><tr>{{range .Footer}}<td{{if .Numeric}} class="num"{{end}}>{{.Text}}</td>{{end}}</tr></tfoot>{{end}}
+</table>
+</div>
+{{end}}
+<script>
+document.querySelectorAll("table.sortable").forEach(function (table) {
+  var headers = table.querySelectorAll("th");
+  var firstRow = table.tBodies[0].rows[0];
+  headers.forEach(function (th, index) {
+    if (firstRow && firstRow.cells[index] && firstRow.cells[index].classList.contains("num")) {
+      th.classList.add("num");
+    }
+    th.addEventListener("click", function () {
+      var body = table.tBodies[0];
+      var rows = Array.prototype.slice.call(body.rows);
+      var numeric = th.classList.contains("num");
+      var ascending = !th.classList.contains("asc");
+      headers.forEach(function (h) { h.classList.remove("asc", "desc"); });
+      th.classList.add(ascending ? "asc" : "desc");
+      rows.sort(function (a, b) {
+        var x = a.cells[index].getAttribute("data-sort");
+        var y = b.cells[index].getAttribute("data-sort");
+        var result = numeric ? parseFloat(x) - parseFloat(y) : x.localeCompare(y);
+        return ascending ? result : -result;
+      });
+      rows.forEach(function (row) { body.appendChild(row); });
+    });
+  });
+});
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,20 @@
+package formatter
+
+import (
+	"encoding/json"
+)
+
+// stampedReport 嵌入了 models.SchemaInfo、可写入格式版本信息的报告
+type stampedReport interface {
+	Stamp()
+}
+
+// marshalReport 写入格式版本信息后将报告序列化为带缩进的JSON
+func marshalReport(report stampedReport) (string, error) {
+	report.Stamp()
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,149 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// FormatLatency 格式化时延报告为表格
+func (f *Formatter) FormatLatency(report *models.LatencyReport) (string, error) {
+	var output strings.Builder
+
+	// 添加标题
+	output.WriteString(f.Colors.IconHeader("⏱️", i18n.T("fmt.latency.title"), BrightYellow))
+	output.WriteString("\n")
+
+	if len(report.Items) == 0 {
+		output.WriteString("\n   📝 " + i18n.T("fmt.latency.empty") + "\n")
+		return output.String(), nil
+	}
+
+	output.WriteString(f.Colors.Dim("   * " + i18n.T("fmt.latency.hint") + "\n\n"))
+
+	nameColumn := "col.model"
+	if report.Dimension == "version" {
+		nameColumn = "col.version"
+	}
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		f.Colors.Header(i18n.T(nameColumn)),
+		f.Colors.Header(i18n.T("col.turns")),
+		f.Colors.Header(i18n.T("col.response_p50")),
+		f.Colors.Header(i18n.T("col.response_p90")),
+		f.Colors.Header(i18n.T("col.response_p99")),
+		f.Colors.Header(i18n.T("col.tool_loop_p50")),
+		f.Colors.Header(i18n.T("col.tool_loop_p90")),
+		f.Colors.Header(i18n.T("col.idle_p50")),
+		f.Colors.Header(i18n.T("col.idle_p90")),
+	})
+
+	for _, item := range report.Items {
+		name := item.Name
+		if name == "" {
+			name = i18n.T("common.unknown")
+		}
+		t.AppendRow(f.latencyRow(f.Colors.BrightCyan(name), item))
+	}
+
+	// 添加汇总行（关闭页脚的大写转换，避免时长单位变成 S/M/H）
+	t.AppendFooter(f.latencyRow(f.Colors.Bold(strings.ToUpper(i18n.T("common.total"))), report.Summary))
+
+	t.SetStyle(table.StyleColoredBright)
+	t.Style().Format.Footer = text.FormatDefault
+	output.WriteString(t.Render())
+	output.WriteString("\n\n")
+
+	return output.String(), nil
+}
+
+// latencyRow 生成时延表格的一行
+func (f *Formatter) latencyRow(name string, item models.LatencyStats) table.Row {
+	return table.Row{
+		name,
+		formatNumber(item.Response.Count),
+		formatSeconds(item.Response.P50, item.Response.Count),
+		formatSeconds(item.Response.P90, item.Response.Count),
+		formatSeconds(item.Response.P99, item.Response.Count),
+		formatSeconds(item.ToolLoop.P50, item.ToolLoop.Count),
+		formatSeconds(item.ToolLoop.P90, item.ToolLoop.Count),
+		f.Colors.Dim(formatSeconds(item.Idle.P50, item.Idle.Count)),
+		f.Colors.Dim(formatSeconds(item.Idle.P90, item.Idle.Count)),
+	}
+}
+
+// FormatLatencyJSON 格式化时延报告为JSON
+func (f *Formatter) FormatLatencyJSON(report *models.LatencyReport) (string, error) {
+	return marshalReport(report)
+}
+
+// FormatLatencyCSV 格式化时延报告为CSV（每个分组的每类时延一行）
+func (f *Formatter) FormatLatencyCSV(report *models.LatencyReport) (string, error) {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+
+	// 写入标题行（使用与语言无关的稳定标识，便于脚本处理）
+	headers := []string{
+		report.Dimension, "kind", "count", "mean_seconds", "p50_seconds", "p90_seconds",
+		"p95_seconds", "p99_seconds", "max_seconds",
+	}
+	if err := writer.Write(headers); err != nil {
+		return "", err
+	}
+
+	rows := append([]models.LatencyStats{}, report.Items...)
+	rows = append(rows, report.Summary)
+
+	for _, item := range rows {
+		kinds := []struct {
+			kind  string
+			stats models.DurationStats
+		}{
+			{models.LatencyResponse, item.Response},
+			{models.LatencyToolLoop, item.ToolLoop},
+			{models.LatencyIdle, item.Idle},
+		}
+		for _, k := range kinds {
+			row := []string{
+				item.Name,
+				k.kind,
+				fmt.Sprintf("%d", k.stats.Count),
+				fmt.Sprintf("%.3f", k.stats.Mean),
+				fmt.Sprintf("%.3f", k.stats.P50),
+				fmt.Sprintf("%.3f", k.stats.P90),
+				fmt.Sprintf("%.3f", k.stats.P95),
+				fmt.Sprintf("%.3f", k.stats.P99),
+				fmt.Sprintf("%.3f", k.stats.Max),
+			}
+			if err := writer.Write(row); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	writer.Flush()
+	return builder.String(), writer.Error()
+}
+
+// formatSeconds 以紧凑形式显示秒数（没有样本时显示 -）
+func formatSeconds(seconds float64, count int) string {
+	if count == 0 {
+		return "-"
+	}
+	switch {
+	case seconds < 60:
+		return fmt.Sprintf("%.1fs", seconds)
+	case seconds < 3600:
+		total := int(seconds + 0.5)
+		return fmt.Sprintf("%dm%02ds", total/60, total%60)
+	default:
+		total := int(seconds/60 + 0.5)
+		return fmt.Sprintf("%dh%02dm", total/60, total%60)
+	}
+}
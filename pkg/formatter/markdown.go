@@ -0,0 +1,831 @@
+package formatter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// markdownSessions analyze 报告中列出的最近会话数（与表格输出一致）
+const markdownSessions = 20
+
+// markdownWriter 生成 GitHub 风格的 Markdown 文档（无颜色、无 emoji 装饰）
+type markdownWriter struct {
+	output strings.Builder
+}
+
+// title 写入一级标题
+func (m *markdownWriter) title(title string) {
+	m.output.WriteString("## " + title + "\n\n")
+}
+
+// section 写入小节标题
+func (m *markdownWriter) section(title string) {
+	m.output.WriteString("### " + title + "\n\n")
+}
+
+// summary 以列表形式写入汇总指标，每项为 标签/值 成对出现
+func (m *markdownWriter) summary(pairs ...string) {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		m.output.WriteString(fmt.Sprintf("- **%s**: %s\n", pairs[i], pairs[i+1]))
+	}
+	m.output.WriteString("\n")
+}
+
+// note 写入说明文字
+func (m *markdownWriter) note(lines ...string) {
+	for _, line := range lines {
+		m.output.WriteString("> " + line + "\n")
+	}
+	m.output.WriteString("\n")
+}
+
+// text 写入普通段落
+func (m *markdownWriter) text(line string) {
+	m.output.WriteString(line + "\n\n")
+}
+
+// table 写入表格；数值列右对齐，汇总行的首列加粗
+func (m *markdownWriter) table(header table.Row, rows []table.Row, footer table.Row) {
+	t := table.NewWriter()
+	t.AppendHeader(header)
+	t.AppendRows(rows)
+	if len(footer) > 0 {
+		footer[0] = fmt.Sprintf("**%v**", footer[0])
+		t.AppendFooter(footer)
+	}
+
+	var configs []table.ColumnConfig
+	for i := range header {
+		if numericColumn(rows, i) {
+			configs = append(configs, table.ColumnConfig{Number: i + 1, Align: text.AlignRight})
+		}
+	}
+	t.SetColumnConfigs(configs)
+	t.Style().Format.Header = text.FormatDefault
+	t.Style().Format.Footer = text.FormatDefault
+
+	m.output.WriteString(t.RenderMarkdown())
+	m.output.WriteString("\n\n")
+}
+
+func (m *markdownWriter) String() string {
+	return m.output.String()
+}
+
+// numericColumn 判断某列是否全部为数值（空值和 "-" 不参与判断）
+func numericColumn(rows []table.Row, column int) bool {
+	found := false
+	for _, row := range rows {
+		if column >= len(row) {
+			continue
+		}
+		fields := strings.Fields(fmt.Sprint(row[column]))
+		if len(fields) == 0 || fields[0] == "-" {
+			continue
+		}
+		// 只看第一段，如 "$0.40 (15%)"、"+1,234"、"×2.5"、"12.5%"
+		value := strings.TrimSuffix(strings.TrimLeft(fields[0], "+-$×*"), "%")
+		value = strings.TrimRight(strings.ReplaceAll(value, ",", ""), "*")
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// plain 返回关闭颜色的格式化器副本，使共用的单元格辅助函数输出纯文本
+func (f *Formatter) plain() *Formatter {
+	plain := *f
+	plain.Colors = &ColorSettings{Enabled: false}
+	return &plain
+}
+
+// formatMarkdown 格式化综合分析结果为Markdown
+func (f *Formatter) formatMarkdown(stats *models.UsageStats) (string, error) {
+	var md markdownWriter
+	md.title(i18n.T("fmt.report.title"))
+
+	period := "-"
+	if !stats.AnalysisPeriod.StartTime.IsZero() {
+		period = i18n.T("fmt.basic.time_range",
+			stats.AnalysisPeriod.StartTime.Format("2006-01-02 15:04"),
+			stats.AnalysisPeriod.EndTime.Format("2006-01-02 15:04"))
+	}
+	md.summary(
+		i18n.T("fmt.basic.period"), period,
+		i18n.T("fmt.basic.mode"), getModeDisplay(stats.DetectedMode),
+		i18n.T("fmt.basic.total_sessions"), formatNumber(stats.TotalSessions),
+		i18n.T("fmt.basic.total_messages"), formatNumber(stats.TotalMessages),
+		i18n.T("col.total_tokens"), formatNumber(stats.TotalTokens.GetTotalTokens()),
+		i18n.T("col.cost_usd"), fmt.Sprintf("$%.4f", stats.EstimatedCost.TotalCost),
+	)
+
+	// 模型
+	if len(stats.ModelStats) > 0 {
+		md.section(i18n.T("fmt.models.title"))
+		modelNames := make([]string, 0, len(stats.ModelStats))
+		for name := range stats.ModelStats {
+			modelNames = append(modelNames, name)
+		}
+		sort.Slice(modelNames, func(i, j int) bool {
+			return stats.EstimatedCost.ModelCosts[modelNames[i]] > stats.EstimatedCost.ModelCosts[modelNames[j]]
+		})
+		var rows []table.Row
+		for _, name := range modelNames {
+			usage := stats.ModelStats[name]
+			rows = append(rows, table.Row{
+				name,
+				formatNumber(usage.InputTokens),
+				formatNumber(usage.OutputTokens),
+				formatNumber(usage.CacheCreationTokens),
+				formatNumber(usage.CacheReadTokens),
+				formatNumber(usage.GetTotalTokens()),
+				fmt.Sprintf("$%.4f", stats.EstimatedCost.ModelCosts[name]),
+			})
+		}
+		md.table(table.Row{
+			i18n.T("col.model"), i18n.T("col.input"), i18n.T("col.output"), i18n.T("col.cache_creation"),
+			i18n.T("col.cache_read"), i18n.T("col.total"), i18n.T("col.cost_usd"),
+		}, rows, nil)
+	}
+
+	// 项目
+	projects := make([]models.ProjectStats, 0, len(stats.ProjectStats))
+	for _, project := range stats.ProjectStats {
+		projects = append(projects, project)
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Cost > projects[j].Cost })
+	if len(projects) > 0 {
+		md.section(i18n.T("fmt.projects.title"))
+		md.table(projectsMarkdownHeader(), projectsMarkdownRows(projects), nil)
+	}
+
+	// 每日（成本来自各项目的每日明细）
+	if len(stats.DailyStats) > 0 {
+		costs := projectDailyCosts(projects)
+		dates := make([]string, 0, len(stats.DailyStats))
+		for date := range stats.DailyStats {
+			dates = append(dates, date)
+		}
+		sort.Strings(dates)
+		var rows []table.Row
+		for _, date := range dates {
+			usage := stats.DailyStats[date]
+			rows = append(rows, table.Row{
+				date,
+				formatNumber(usage.InputTokens),
+				formatNumber(usage.OutputTokens),
+				formatNumber(usage.CacheCreationTokens),
+				formatNumber(usage.CacheReadTokens),
+				formatNumber(usage.GetTotalTokens()),
+				fmt.Sprintf("$%.4f", costs[date]),
+			})
+		}
+		md.section(i18n.T("fmt.daily.title"))
+		md.table(table.Row{
+			i18n.T("col.date"), i18n.T("col.input"), i18n.T("col.output"), i18n.T("col.cache_creation"),
+			i18n.T("col.cache_read"), i18n.T("col.total"), i18n.T("col.cost_usd"),
+		}, rows, nil)
+	}
+
+	// 最近的会话
+	if len(stats.SessionStats) > 0 {
+		sessions := make([]models.SessionInfo, 0, len(stats.SessionStats))
+		for _, session := range stats.SessionStats {
+			sessions = append(sessions, session)
+		}
+		sort.Slice(sessions, func(i, j int) bool { return sessions[i].StartTime.After(sessions[j].StartTime) })
+		if len(sessions) > markdownSessions {
+			sessions = sessions[:markdownSessions]
+		}
+		var rows []table.Row
+		for _, session := range sessions {
+			rows = append(rows, table.Row{
+				shortSessionID(session.ID),
+				formatActivity(session.StartTime),
+				projectLabel(session.ProjectPath),
+				session.Model,
+				formatNumber(session.MessageCount),
+				formatNumber(session.Tokens.GetTotalTokens()),
+				fmt.Sprintf("$%.4f", session.Cost),
+			})
+		}
+		md.section(i18n.T("fmt.sessions.title"))
+		md.table(table.Row{
+			i18n.T("col.session_id"), i18n.T("col.start_time"), i18n.T("col.project"), i18n.T("col.model"),
+			i18n.T("col.messages"), i18n.T("col.tokens"), i18n.T("col.cost_usd"),
+		}, rows, nil)
+	}
+
+	return md.String(), nil
+}
+
+// FormatDailyMarkdown 格式化日报告为Markdown
+func (f *Formatter) FormatDailyMarkdown(report *models.DailyReport) (string, error) {
+	var md markdownWriter
+	md.title(i18n.T("fmt.daily.title"))
+
+	if len(report.DailyData) == 0 {
+		md.text(i18n.T("fmt.daily.empty"))
+		return md.String(), nil
+	}
+
+	first, last := report.DailyData[0].Date, report.DailyData[len(report.DailyData)-1].Date
+	if first > last {
+		first, last = last, first
+	}
+	md.summary(
+		i18n.T("fmt.basic.period"), first+" ~ "+last,
+		i18n.T("col.equivalent_cost"), fmt.Sprintf("$%.4f", report.Summary.CostUSD),
+		i18n.T("col.total_tokens"), formatNumber(report.Summary.TotalTokens),
+		i18n.T("fmt.report.days"), formatNumber(len(report.DailyData)),
+	)
+	md.note(i18n.T("fmt.daily.cost_notice"))
+
+	header := table.Row{
+		i18n.T("col.date"), i18n.T("col.model"), i18n.T("col.input_tokens"), i18n.T("col.output_tokens"),
+		i18n.T("col.cache_creation"), i18n.T("col.cache_read"), i18n.T("col.total_tokens"),
+		i18n.T("col.equivalent_cost"), i18n.T("col.messages"),
+	}
+	showSidechain := report.Summary.Sidechain.TotalTokens > 0
+	if showSidechain {
+		header = append(header, i18n.T("col.sidechain_cost"))
+	}
+	if f.ShowDetails {
+		header = append(header, i18n.T("col.sessions"))
+	}
+
+	var rows []table.Row
+	for _, day := range report.DailyData {
+		row := table.Row{
+			day.Date,
+			strings.Join(day.Models, ", "),
+			formatNumber(day.InputTokens),
+			formatNumber(day.OutputTokens),
+			formatNumber(day.CacheCreationTokens),
+			formatNumber(day.CacheReadTokens),
+			formatNumber(day.TotalTokens),
+			fmt.Sprintf("$%.4f", day.CostUSD),
+			formatNumber(day.MessageCount),
+		}
+		if showSidechain {
+			row = append(row, formatSidechainCost(day.Sidechain.CostUSD, day.CostUSD))
+		}
+		if f.ShowDetails {
+			row = append(row, formatNumber(day.SessionCount))
+		}
+		rows = append(rows, row)
+
+		// 模型分解行
+		if f.ShowDetails {
+			modelNames := make([]string, 0, len(day.Breakdown))
+			for model := range day.Breakdown {
+				modelNames = append(modelNames, model)
+			}
+			sort.Strings(modelNames)
+			for _, model := range modelNames {
+				data := day.Breakdown[model]
+				breakdownRow := table.Row{
+					"└─ " + model, "",
+					formatNumber(data.InputTokens),
+					formatNumber(data.OutputTokens),
+					formatNumber(data.CacheCreationTokens),
+					formatNumber(data.CacheReadTokens),
+					formatNumber(data.TotalTokens),
+					fmt.Sprintf("$%.4f", data.CostUSD),
+					formatNumber(data.MessageCount),
+				}
+				for len(breakdownRow) < len(header) {
+					breakdownRow = append(breakdownRow, "")
+				}
+				rows = append(rows, breakdownRow)
+			}
+		}
+	}
+
+	summary := report.Summary
+	footer := table.Row{
+		i18n.T("common.total"), "",
+		formatNumber(summary.InputTokens),
+		formatNumber(summary.OutputTokens),
+		formatNumber(summary.CacheCreationTokens),
+		formatNumber(summary.CacheReadTokens),
+		formatNumber(summary.TotalTokens),
+		fmt.Sprintf("$%.4f", summary.CostUSD),
+		formatNumber(summary.MessageCount),
+	}
+	if showSidechain {
+		footer = append(footer, formatSidechainCost(summary.Sidechain.CostUSD, summary.CostUSD))
+	}
+	if f.ShowDetails {
+		footer = append(footer, formatNumber(summary.SessionCount))
+	}
+	md.table(header, rows, footer)
+
+	return md.String(), nil
+}
+
+// FormatBlocksMarkdown 格式化blocks报告为Markdown
+func (f *Formatter) FormatBlocksMarkdown(report *models.BlocksReport) (string, error) {
+	var md markdownWriter
+	md.title(i18n.T("fmt.blocks.title"))
+
+	if len(report.Blocks) == 0 {
+		md.text(i18n.T("fmt.blocks.empty"))
+		return md.String(), nil
+	}
+
+	md.summary(
+		i18n.T("fmt.report.blocks"), formatNumber(len(report.Blocks)),
+		i18n.T("col.total_tokens"), formatNumber(report.Summary.GetTotalTokens()),
+		i18n.T("col.cost_usd"), fmt.Sprintf("$%.4f", report.TotalCost),
+	)
+
+	var rows []table.Row
+	for _, block := range report.Blocks {
+		status := i18n.T("fmt.blocks.completed")
+		if block.IsActive {
+			status = i18n.T("fmt.blocks.active", block.TimeRemaining)
+			if block.BurnRate > 0 {
+				status += "\n" + i18n.T("fmt.blocks.burn_rate", formatNumber(block.BurnRate))
+			}
+			if block.ProjectedTotal > 0 {
+				status += "\n" + i18n.T("fmt.blocks.projected", formatNumber(block.ProjectedTotal))
+			}
+		}
+		models := i18n.T("common.none")
+		if len(block.Models) > 0 {
+			models = strings.Join(block.Models, ", ")
+		}
+		rows = append(rows, table.Row{
+			block.StartTime.Format("2006-01-02 15:04:05"),
+			status,
+			models,
+			formatNumber(block.Tokens.InputTokens),
+			formatNumber(block.Tokens.OutputTokens),
+			formatNumber(block.Tokens.GetTotalTokens()),
+			fmt.Sprintf("$%.4f", block.CostUSD),
+		})
+	}
+
+	md.table(table.Row{
+		i18n.T("col.block_start"), i18n.T("col.status"), i18n.T("col.model"), i18n.T("col.input_tokens"),
+		i18n.T("col.output_tokens"), i18n.T("col.total_tokens"), i18n.T("col.cost_usd"),
+	}, rows, table.Row{
+		i18n.T("common.total"), "", "",
+		formatNumber(report.Summary.InputTokens),
+		formatNumber(report.Summary.OutputTokens),
+		formatNumber(report.Summary.GetTotalTokens()),
+		fmt.Sprintf("$%.4f", report.TotalCost),
+	})
+
+	return md.String(), nil
+}
+
+// FormatProjectsMarkdown 格式化项目报告为Markdown
+func (f *Formatter) FormatProjectsMarkdown(report *models.ProjectsReport) (string, error) {
+	var md markdownWriter
+	md.title(i18n.T("fmt.projects.title"))
+
+	if len(report.Projects) == 0 {
+		md.text(i18n.T("fmt.projects.empty"))
+		return md.String(), nil
+	}
+
+	md.summary(
+		i18n.T("fmt.projects.title"), formatNumber(len(report.Projects)),
+		i18n.T("col.sessions"), formatNumber(report.Summary.SessionCount),
+		i18n.T("col.total_tokens"), formatNumber(report.Summary.Tokens.GetTotalTokens()),
+		i18n.T("col.cost_usd"), fmt.Sprintf("$%.4f", report.Summary.Cost),
+	)
+
+	summary := report.Summary
+	md.table(projectsMarkdownHeader(), projectsMarkdownRows(report.Projects), table.Row{
+		i18n.T("common.total"), "",
+		formatNumber(summary.SessionCount),
+		formatNumber(summary.MessageCount),
+		formatNumber(summary.Tokens.GetTotalTokens()),
+		fmt.Sprintf("$%.4f", summary.Cost),
+		formatSidechainCost(summary.Sidechain.CostUSD, summary.Cost),
+		"", "", "",
+	})
+
+	return md.String(), nil
+}
+
+// projectsMarkdownHeader 项目表的表头（projects 和 analyze 共用）
+func projectsMarkdownHeader() table.Row {
+	return table.Row{
+		i18n.T("col.project"), i18n.T("col.path"), i18n.T("col.sessions"), i18n.T("col.messages"),
+		i18n.T("col.total_tokens"), i18n.T("col.cost_usd"), i18n.T("col.sidechain_cost"),
+		i18n.T("col.model"), i18n.T("col.first_activity"), i18n.T("col.last_activity"),
+	}
+}
+
+// projectsMarkdownRows 项目表的数据行
+func projectsMarkdownRows(projects []models.ProjectStats) []table.Row {
+	var rows []table.Row
+	for _, project := range projects {
+		name := project.ProjectName
+		if project.Group != "" && project.Group != project.ProjectName {
+			name += " [" + project.Group + "]"
+		}
+		path := project.ProjectPath
+		if path == "" && len(project.Paths) > 0 {
+			path = i18n.T("fmt.projects.paths_count", len(project.Paths))
+		}
+		rows = append(rows, table.Row{
+			name,
+			path,
+			formatNumber(project.SessionCount),
+			formatNumber(project.MessageCount),
+			formatNumber(project.Tokens.GetTotalTokens()),
+			fmt.Sprintf("$%.4f", project.Cost),
+			formatSidechainCost(project.Sidechain.CostUSD, project.Cost),
+			strings.ReplaceAll(formatModelMix(project, 2), "\n", ", "),
+			formatActivity(project.FirstActivity),
+			formatActivity(project.LastActivity),
+		})
+	}
+	return rows
+}
+
+// FormatBranchesMarkdown 格式化分支报告为Markdown
+func (f *Formatter) FormatBranchesMarkdown(report *models.BranchesReport) (string, error) {
+	p := f.plain()
+	var md markdownWriter
+	md.title(i18n.T("fmt.branches.title"))
+
+	if len(report.Branches) == 0 {
+		md.text(i18n.T("fmt.branches.empty"))
+		return md.String(), nil
+	}
+
+	var rows []table.Row
+	for _, branch := range report.Branches {
+		commits := "-"
+		if branch.Repository != "" {
+			commits = formatNumber(len(branch.Commits))
+		}
+		rows = append(rows, table.Row{
+			branch.ProjectName,
+			p.branchName(branch.Branch),
+			formatNumber(branch.SessionCount),
+			formatNumber(branch.MessageCount),
+			formatNumber(branch.Tokens.GetTotalTokens()),
+			fmt.Sprintf("$%.4f", branch.Cost),
+			commits,
+			formatActivity(branch.FirstActivity),
+			formatActivity(branch.LastActivity),
+		})
+		if f.ShowDetails {
+			for _, commit := range branch.Commits {
+				rows = append(rows, table.Row{
+					"└─ " + shortHash(commit.Hash), commit.Subject, "", "", "", "", "", formatActivity(commit.Time), "",
+				})
+			}
+		}
+	}
+
+	md.table(table.Row{
+		i18n.T("col.project"), i18n.T("col.branch"), i18n.T("col.sessions"), i18n.T("col.messages"),
+		i18n.T("col.total_tokens"), i18n.T("col.cost_usd"), i18n.T("col.commits"),
+		i18n.T("col.first_activity"), i18n.T("col.last_activity"),
+	}, rows, table.Row{
+		i18n.T("common.total"), "",
+		formatNumber(report.Summary.SessionCount),
+		formatNumber(report.Summary.MessageCount),
+		formatNumber(report.Summary.Tokens.GetTotalTokens()),
+		fmt.Sprintf("$%.4f", report.Summary.Cost),
+		formatNumber(len(report.Summary.Commits)),
+		"", "",
+	})
+
+	return md.String(), nil
+}
+
+// FormatToolsMarkdown 格式化工具报告为Markdown
+func (f *Formatter) FormatToolsMarkdown(report *models.ToolsReport) (string, error) {
+	p := f.plain()
+	var md markdownWriter
+	md.title(i18n.T("fmt.tools.title"))
+
+	if len(report.Tools) == 0 {
+		md.text(i18n.T("fmt.tools.empty"))
+		return md.String(), nil
+	}
+	md.note(i18n.T("fmt.tools.cost_hint"))
+
+	var rows []table.Row
+	for _, tool := range report.Tools {
+		rows = append(rows, table.Row{
+			tool.Name,
+			formatNumber(tool.CallCount),
+			formatNumber(tool.ErrorCount),
+			p.errorRate(tool.ErrorRate()),
+			formatNumber(tool.Tokens.GetTotalTokens()),
+			fmt.Sprintf("$%.4f", tool.Cost),
+			strings.Join(p.topToolProjects(tool), ", "),
+		})
+	}
+
+	md.table(table.Row{
+		i18n.T("col.tool"), i18n.T("col.calls"), i18n.T("col.errors"), i18n.T("col.error_rate"),
+		i18n.T("col.total_tokens"), i18n.T("col.cost_usd"), i18n.T("col.top_projects"),
+	}, rows, table.Row{
+		i18n.T("common.total"),
+		formatNumber(report.Summary.CallCount),
+		formatNumber(report.Summary.ErrorCount),
+		fmt.Sprintf("%.1f%%", report.Summary.ErrorRate()*100),
+		formatNumber(report.Summary.Tokens.GetTotalTokens()),
+		fmt.Sprintf("$%.4f", report.Summary.Cost),
+		"",
+	})
+
+	return md.String(), nil
+}
+
+// FormatCacheMarkdown 格式化缓存效率报告为Markdown
+func (f *Formatter) FormatCacheMarkdown(report *models.CacheReport) (string, error) {
+	p := f.plain()
+	var md markdownWriter
+	md.title(i18n.T("fmt.cache.title"))
+
+	if len(report.Items) == 0 {
+		md.text(i18n.T("fmt.cache.empty"))
+		return md.String(), nil
+	}
+	md.note(i18n.T("fmt.cache.savings_hint"), i18n.T("fmt.cache.waste_hint"))
+
+	var rows []table.Row
+	for _, item := range report.Items {
+		rows = append(rows, table.Row{
+			cacheItemLabel(report.Dimension, item.Name),
+			formatNumber(item.MessageCount),
+			formatNumber(item.InputTokens),
+			formatNumber(item.CacheCreationTokens),
+			formatNumber(item.CacheReadTokens),
+			p.hitRatio(item.HitRatio),
+			fmt.Sprintf("$%.4f", item.SavingsUSD),
+			fmt.Sprintf("$%.4f", item.NetSavingsUSD),
+			formatCacheWaste(item.Wasted5mUSD, item.Wasted5mTokens, item.CacheCreationTokens),
+			formatCacheWaste(item.Wasted1hUSD, item.Wasted1hTokens, item.CacheCreationTokens),
+		})
+	}
+
+	summary := report.Summary
+	md.table(table.Row{
+		i18n.T(cacheDimensionColumn(report.Dimension)), i18n.T("col.messages"), i18n.T("col.input_tokens"),
+		i18n.T("col.cache_creation"), i18n.T("col.cache_read"), i18n.T("col.hit_ratio"),
+		i18n.T("col.cache_savings"), i18n.T("col.cache_net_savings"), i18n.T("col.wasted_5m"), i18n.T("col.wasted_1h"),
+	}, rows, table.Row{
+		i18n.T("common.total"),
+		formatNumber(summary.MessageCount),
+		formatNumber(summary.InputTokens),
+		formatNumber(summary.CacheCreationTokens),
+		formatNumber(summary.CacheReadTokens),
+		fmt.Sprintf("%.1f%%", summary.HitRatio*100),
+		fmt.Sprintf("$%.4f", summary.SavingsUSD),
+		fmt.Sprintf("$%.4f", summary.NetSavingsUSD),
+		formatCacheWaste(summary.Wasted5mUSD, summary.Wasted5mTokens, summary.CacheCreationTokens),
+		formatCacheWaste(summary.Wasted1hUSD, summary.Wasted1hTokens, summary.CacheCreationTokens),
+	})
+
+	return md.String(), nil
+}
+
+// FormatLatencyMarkdown 格式化时延报告为Markdown
+func (f *Formatter) FormatLatencyMarkdown(report *models.LatencyReport) (string, error) {
+	p := f.plain()
+	var md markdownWriter
+	md.title(i18n.T("fmt.latency.title"))
+
+	if len(report.Items) == 0 {
+		md.text(i18n.T("fmt.latency.empty"))
+		return md.String(), nil
+	}
+	md.note(i18n.T("fmt.latency.hint"))
+
+	nameColumn := "col.model"
+	if report.Dimension == "version" {
+		nameColumn = "col.version"
+	}
+
+	var rows []table.Row
+	for _, item := range report.Items {
+		name := item.Name
+		if name == "" {
+			name = i18n.T("common.unknown")
+		}
+		rows = append(rows, p.latencyRow(name, item))
+	}
+
+	md.table(table.Row{
+		i18n.T(nameColumn), i18n.T("col.turns"), i18n.T("col.response_p50"), i18n.T("col.response_p90"),
+		i18n.T("col.response_p99"), i18n.T("col.tool_loop_p50"), i18n.T("col.tool_loop_p90"),
+		i18n.T("col.idle_p50"), i18n.T("col.idle_p90"),
+	}, rows, p.latencyRow(i18n.T("common.total"), report.Summary))
+
+	return md.String(), nil
+}
+
+// FormatHeatmapMarkdown 格式化热力图为Markdown（星期为行、小时为列的数值表）
+func (f *Formatter) FormatHeatmapMarkdown(report *models.HeatmapReport) (string, error) {
+	var md markdownWriter
+	md.title(i18n.T("fmt.heatmap.title", i18n.T("fmt.heatmap.metric_"+report.Metric)))
+
+	if report.Total == 0 {
+		md.text(i18n.T("fmt.heatmap.empty"))
+		return md.String(), nil
+	}
+	md.note(i18n.T("fmt.heatmap.timezone_hint", report.Timezone))
+
+	labels := strings.Split(i18n.T("fmt.heatmap.weekdays"), ",")
+	header := table.Row{""}
+	for hour := 0; hour < 24; hour++ {
+		header = append(header, fmt.Sprintf("%d", hour))
+	}
+	header = append(header, i18n.T("common.total"))
+
+	var rows []table.Row
+	var hourTotals [24]float64
+	for day, hours := range report.Matrix {
+		label := report.Weekdays[day]
+		if day < len(labels) {
+			label = labels[day]
+		}
+		row := table.Row{label}
+		var rowTotal float64
+		for hour, value := range hours {
+			rowTotal += value
+			hourTotals[hour] += value
+			row = append(row, formatMetricValue(report.Metric, value))
+		}
+		rows = append(rows, append(row, formatMetricValue(report.Metric, rowTotal)))
+	}
+
+	footer := table.Row{i18n.T("common.total")}
+	for _, value := range hourTotals {
+		footer = append(footer, formatMetricValue(report.Metric, value))
+	}
+	md.table(header, rows, append(footer, formatMetricValue(report.Metric, report.Total)))
+
+	return md.String(), nil
+}
+
+// FormatCompareMarkdown 格式化时间段对比报告为Markdown
+func (f *Formatter) FormatCompareMarkdown(report *models.CompareReport) (string, error) {
+	p := f.plain()
+	var md markdownWriter
+	md.title(i18n.T("fmt.compare.title"))
+	md.summary("A", formatPeriod(report.PeriodA), "B", formatPeriod(report.PeriodB))
+
+	summary := report.Summary
+	md.table(table.Row{i18n.T("col.metric"), "A", "B", i18n.T("col.delta"), i18n.T("col.change")}, []table.Row{
+		{i18n.T("col.total_tokens"), formatNumber(summary.A.Tokens), formatNumber(summary.B.Tokens),
+			p.signedNumber(summary.Delta.Tokens), p.percentChange(summary.Change.Tokens)},
+		{i18n.T("col.cost_usd"), fmt.Sprintf("$%.4f", summary.A.CostUSD), fmt.Sprintf("$%.4f", summary.B.CostUSD),
+			p.signedCost(summary.Delta.CostUSD), p.percentChange(summary.Change.CostUSD)},
+		{i18n.T("col.sessions"), formatNumber(summary.A.Sessions), formatNumber(summary.B.Sessions),
+			p.signedNumber(summary.Delta.Sessions), p.percentChange(summary.Change.Sessions)},
+		{i18n.T("col.messages"), formatNumber(summary.A.Messages), formatNumber(summary.B.Messages),
+			p.signedNumber(summary.Delta.Messages), p.percentChange(summary.Change.Messages)},
+	}, nil)
+
+	for _, group := range []struct {
+		title, column string
+		items         []models.CompareItem
+		isProject     bool
+	}{
+		{i18n.T("fmt.compare.by_model"), i18n.T("col.model"), report.Models, false},
+		{i18n.T("fmt.compare.by_project"), i18n.T("col.project"), report.Projects, true},
+	} {
+		md.section(group.title)
+		if len(group.items) == 0 {
+			md.text(i18n.T("common.none"))
+			continue
+		}
+
+		limit := f.TopN
+		if limit <= 0 || limit > len(group.items) {
+			limit = len(group.items)
+		}
+		var rows []table.Row
+		for _, item := range group.items[:limit] {
+			name := item.Name
+			if group.isProject {
+				name = projectLabel(name)
+			}
+			rows = append(rows, table.Row{
+				name,
+				fmt.Sprintf("$%.4f", item.A.CostUSD),
+				fmt.Sprintf("$%.4f", item.B.CostUSD),
+				p.signedCost(item.Delta.CostUSD),
+				p.percentChange(item.Change.CostUSD),
+				p.signedNumber(item.Delta.Tokens),
+				fmt.Sprintf("%.0f%%", item.Share*100),
+			})
+		}
+		md.table(table.Row{
+			group.column, i18n.T("col.cost_a"), i18n.T("col.cost_b"), i18n.T("col.delta"),
+			i18n.T("col.change"), i18n.T("col.tokens_delta"), i18n.T("col.share_of_change"),
+		}, rows, nil)
+		if limit < len(group.items) {
+			md.note(i18n.T("fmt.compare.more", len(group.items)-limit))
+		}
+	}
+
+	return md.String(), nil
+}
+
+// FormatForecastMarkdown 格式化成本预测报告为Markdown
+func (f *Formatter) FormatForecastMarkdown(report *models.ForecastReport) (string, error) {
+	p := f.plain()
+	var md markdownWriter
+	md.title(i18n.T("fmt.forecast.title", report.AsOf))
+
+	if report.HistoryDays == 0 {
+		md.text(i18n.T("fmt.forecast.empty"))
+		return md.String(), nil
+	}
+	md.note(
+		i18n.T("fmt.forecast.model_hint", report.HistoryDays, report.Confidence*100),
+		i18n.T("fmt.forecast.trend_hint", fmt.Sprintf("%+.3f", report.Trend)),
+	)
+
+	var rows []table.Row
+	for _, target := range report.Targets {
+		budget := "-"
+		if target.BudgetUSD > 0 {
+			budget = fmt.Sprintf("$%.2f", target.BudgetUSD)
+		}
+		rows = append(rows, table.Row{
+			i18n.T("fmt.forecast.target_" + target.Name),
+			fmt.Sprintf("%s ~ %s", target.StartDate, target.EndDate),
+			fmt.Sprintf("$%.2f", target.ActualUSD),
+			fmt.Sprintf("$%.2f", target.LinearUSD),
+			fmt.Sprintf("$%.2f", target.SeasonalUSD),
+			fmt.Sprintf("**$%.2f**", target.ProjectedUSD),
+			fmt.Sprintf("$%.2f ~ $%.2f", target.LowerUSD, target.UpperUSD),
+			budget,
+			p.budgetStatus(target),
+		})
+	}
+
+	md.table(table.Row{
+		i18n.T("col.period"), i18n.T("col.date_range"), i18n.T("col.actual_to_date"), i18n.T("col.linear"),
+		i18n.T("col.weekday_avg"), i18n.T("col.forecast"), i18n.T("col.forecast_range", report.Confidence*100),
+		i18n.T("col.budget"), i18n.T("col.status"),
+	}, rows, nil)
+
+	return md.String(), nil
+}
+
+// FormatAnomaliesMarkdown 格式化异常检测报告为Markdown
+func (f *Formatter) FormatAnomaliesMarkdown(report *models.AnomalyReport) (string, error) {
+	var md markdownWriter
+	md.title(i18n.T("fmt.anomalies.title", i18n.T("fmt.heatmap.metric_"+report.Metric)))
+	md.note(
+		i18n.T("fmt.anomalies.baseline_hint", report.WindowDays, report.Threshold),
+		i18n.T("fmt.anomalies.checked_hint", report.Checked[models.AnomalyDay],
+			report.Checked[models.AnomalySession], report.Checked[models.AnomalyBlock]),
+	)
+
+	if len(report.Anomalies) == 0 {
+		md.text(i18n.T("fmt.anomalies.none"))
+		return md.String(), nil
+	}
+
+	var rows []table.Row
+	for _, anomaly := range report.Anomalies {
+		ratio := "-"
+		if anomaly.Ratio > 0 {
+			ratio = fmt.Sprintf("×%.1f", anomaly.Ratio)
+		}
+		rows = append(rows, table.Row{
+			i18n.T("fmt.anomalies.kind_" + anomaly.Kind),
+			anomalyTarget(anomaly),
+			formatMetricValue(report.Metric, anomaly.Observed),
+			formatMetricValue(report.Metric, anomaly.Baseline),
+			ratio,
+			fmt.Sprintf("%.1f", anomaly.Score),
+			formatContributor(anomaly.TopSession, shortSessionID),
+			formatContributor(anomaly.TopModel, func(name string) string { return name }),
+		})
+	}
+
+	md.table(table.Row{
+		i18n.T("col.kind"), i18n.T("col.target"), i18n.T("col.observed"), i18n.T("col.baseline"),
+		i18n.T("col.ratio"), i18n.T("col.score"), i18n.T("col.top_session"), i18n.T("col.top_model"),
+	}, rows, nil)
+
+	return md.String(), nil
+}
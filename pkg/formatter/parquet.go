@@ -0,0 +1,148 @@
+package formatter
+
+import (
+	"io"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// parquetRowGroupSize 每个行组的最大行数，写满后落盘以限制内存占用
+const parquetRowGroupSize = 64 * 1024
+
+// parquetBatchSize 写入前在内存中攒批的行数
+const parquetBatchSize = 1024
+
+// ParquetUsageRow Parquet 明细表的行结构，列名与 NDJSON 导出字段一致（包括每行的 schema_version）
+// 低基数的字符串列使用字典编码，Token 为 int64，成本为 float64
+type ParquetUsageRow struct {
+	SchemaVersion       int32     `parquet:"schema_version"`
+	Timestamp           time.Time `parquet:"timestamp,timestamp(millisecond)"`
+	SessionID           string    `parquet:"session_id,dict"`
+	RequestID           string    `parquet:"request_id"`
+	ProjectPath         string    `parquet:"project_path,dict"`
+	GitBranch           string    `parquet:"git_branch,dict"`
+	Model               string    `parquet:"model,dict"`
+	InputTokens         int64     `parquet:"input_tokens"`
+	OutputTokens        int64     `parquet:"output_tokens"`
+	CacheCreationTokens int64     `parquet:"cache_creation_tokens"`
+	CacheReadTokens     int64     `parquet:"cache_read_tokens"`
+	CostUSD             float64   `parquet:"cost_usd"`
+	PriceVersion        string    `parquet:"price_version,dict"`
+	IsSidechain         bool      `parquet:"is_sidechain"`
+}
+
+// ParquetRollupRow Parquet 每日汇总表的行结构，date 为 DATE 逻辑类型
+type ParquetRollupRow struct {
+	SchemaVersion       int32   `parquet:"schema_version"`
+	Date                int32   `parquet:"date,date"`
+	ProjectPath         string  `parquet:"project_path,dict"`
+	Model               string  `parquet:"model,dict"`
+	IsSidechain         bool    `parquet:"is_sidechain"`
+	Entries             int64   `parquet:"entries"`
+	InputTokens         int64   `parquet:"input_tokens"`
+	OutputTokens        int64   `parquet:"output_tokens"`
+	CacheCreationTokens int64   `parquet:"cache_creation_tokens"`
+	CacheReadTokens     int64   `parquet:"cache_read_tokens"`
+	CostUSD             float64 `parquet:"cost_usd"`
+	PriceVersion        string  `parquet:"price_version,dict"`
+}
+
+// ParquetUsageWriter 以 Parquet 格式流式写入导出记录
+type ParquetUsageWriter struct {
+	writer *parquet.GenericWriter[ParquetUsageRow]
+	batch  []ParquetUsageRow
+}
+
+// NewParquetUsageWriter 创建写入 out 的明细表写入器，结束时必须调用 Close
+func NewParquetUsageWriter(out io.Writer) *ParquetUsageWriter {
+	return &ParquetUsageWriter{
+		writer: parquet.NewGenericWriter[ParquetUsageRow](out, parquetWriterOptions()...),
+		batch:  make([]ParquetUsageRow, 0, parquetBatchSize),
+	}
+}
+
+// Write 写入一条导出记录
+func (w *ParquetUsageWriter) Write(record models.UsageRecord) error {
+	w.batch = append(w.batch, ParquetUsageRow{
+		SchemaVersion:       models.SchemaVersion,
+		Timestamp:           record.Timestamp,
+		SessionID:           record.SessionID,
+		RequestID:           record.RequestID,
+		ProjectPath:         record.ProjectPath,
+		GitBranch:           record.GitBranch,
+		Model:               record.Model,
+		InputTokens:         int64(record.InputTokens),
+		OutputTokens:        int64(record.OutputTokens),
+		CacheCreationTokens: int64(record.CacheCreationTokens),
+		CacheReadTokens:     int64(record.CacheReadTokens),
+		CostUSD:             record.CostUSD,
+		PriceVersion:        record.PriceVersion,
+		IsSidechain:         record.IsSidechain,
+	})
+	if len(w.batch) < parquetBatchSize {
+		return nil
+	}
+	return w.flushBatch()
+}
+
+// Close 写出剩余记录和文件尾部元数据
+func (w *ParquetUsageWriter) Close() error {
+	if err := w.flushBatch(); err != nil {
+		return err
+	}
+	return w.writer.Close()
+}
+
+// flushBatch 将攒批的记录交给底层写入器
+func (w *ParquetUsageWriter) flushBatch() error {
+	if len(w.batch) == 0 {
+		return nil
+	}
+	if _, err := w.writer.Write(w.batch); err != nil {
+		return err
+	}
+	w.batch = w.batch[:0]
+	return nil
+}
+
+// WriteParquetRollups 将每日汇总写为 Parquet 文件
+func WriteParquetRollups(out io.Writer, rollups []models.UsageRollup) error {
+	rows := make([]ParquetRollupRow, 0, len(rollups))
+	for _, rollup := range rollups {
+		date, err := time.Parse("2006-01-02", rollup.Date)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, ParquetRollupRow{
+			SchemaVersion:       models.SchemaVersion,
+			Date:                int32(date.Unix() / 86400),
+			ProjectPath:         rollup.ProjectPath,
+			Model:               rollup.Model,
+			IsSidechain:         rollup.IsSidechain,
+			Entries:             int64(rollup.Entries),
+			InputTokens:         int64(rollup.InputTokens),
+			OutputTokens:        int64(rollup.OutputTokens),
+			CacheCreationTokens: int64(rollup.CacheCreationTokens),
+			CacheReadTokens:     int64(rollup.CacheReadTokens),
+			CostUSD:             rollup.CostUSD,
+			PriceVersion:        rollup.PriceVersion,
+		})
+	}
+
+	writer := parquet.NewGenericWriter[ParquetRollupRow](out, parquetWriterOptions()...)
+	if _, err := writer.Write(rows); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// parquetWriterOptions 两种表共用的写入选项
+func parquetWriterOptions() []parquet.WriterOption {
+	return []parquet.WriterOption{
+		parquet.Compression(&parquet.Zstd),
+		parquet.MaxRowsPerRowGroup(parquetRowGroupSize),
+		parquet.CreatedBy("claude-stats", "", ""),
+	}
+}
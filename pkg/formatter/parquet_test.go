@@ -0,0 +1,196 @@
+package formatter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// TestParquetUsageRoundTrip 明细记录写出后用 parquet-go 读回，时间戳和数值保持不变
+func TestParquetUsageRoundTrip(t *testing.T) {
+	timestamp := time.Date(2026, 10, 18, 9, 30, 15, 123_000_000, time.UTC)
+	records := []models.UsageRecord{
+		{
+			Timestamp:           timestamp,
+			SessionID:           "session-1",
+			RequestID:           "req_1",
+			ProjectPath:         "~/work/api",
+			GitBranch:           "main",
+			Model:               "claude-opus-4-20250514",
+			InputTokens:         1200,
+			OutputTokens:        3400,
+			CacheCreationTokens: 5_000_000_000, // 超过 int32 范围
+			CacheReadTokens:     78000,
+			CostUSD:             1.234567,
+			PriceVersion:        "2025-06",
+			IsSidechain:         true,
+		},
+		{
+			Timestamp:    timestamp.Add(time.Minute),
+			SessionID:    "session-1",
+			RequestID:    "req_2",
+			ProjectPath:  "~/work/api",
+			Model:        "claude-opus-4-20250514",
+			InputTokens:  10,
+			OutputTokens: 20,
+			CostUSD:      0.000125,
+			PriceVersion: "2025-06",
+		},
+	}
+
+	var buf bytes.Buffer
+	writer := NewParquetUsageWriter(&buf)
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			t.Fatalf("写入记录失败: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("关闭写入器失败: %v", err)
+	}
+
+	rows, err := parquet.Read[ParquetUsageRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("读取 Parquet 失败: %v", err)
+	}
+	if len(rows) != len(records) {
+		t.Fatalf("读回 %d 行，期望 %d 行", len(rows), len(records))
+	}
+
+	for i, record := range records {
+		row := rows[i]
+		if row.SchemaVersion != models.SchemaVersion {
+			t.Errorf("第 %d 行 schema_version = %d，期望 %d", i, row.SchemaVersion, models.SchemaVersion)
+		}
+		if !row.Timestamp.Equal(record.Timestamp) {
+			t.Errorf("第 %d 行 timestamp = %v，期望 %v", i, row.Timestamp, record.Timestamp)
+		}
+		if row.SessionID != record.SessionID || row.RequestID != record.RequestID ||
+			row.ProjectPath != record.ProjectPath || row.GitBranch != record.GitBranch ||
+			row.Model != record.Model || row.PriceVersion != record.PriceVersion {
+			t.Errorf("第 %d 行字符串字段不一致: %+v", i, row)
+		}
+		if row.InputTokens != int64(record.InputTokens) || row.OutputTokens != int64(record.OutputTokens) ||
+			row.CacheCreationTokens != int64(record.CacheCreationTokens) || row.CacheReadTokens != int64(record.CacheReadTokens) {
+			t.Errorf("第 %d 行 token 数不一致: %+v", i, row)
+		}
+		if row.CostUSD != record.CostUSD {
+			t.Errorf("第 %d 行 cost_usd = %v，期望 %v", i, row.CostUSD, record.CostUSD)
+		}
+		if row.IsSidechain != record.IsSidechain {
+			t.Errorf("第 %d 行 is_sidechain = %v，期望 %v", i, row.IsSidechain, record.IsSidechain)
+		}
+	}
+
+	assertDictionaryColumns(t, buf.Bytes(), "model", "project_path")
+}
+
+// TestParquetRollupRoundTrip 每日汇总写出后用 parquet-go 读回，日期和数值保持不变
+func TestParquetRollupRoundTrip(t *testing.T) {
+	rollups := []models.UsageRollup{
+		{
+			Date:                "2026-10-17",
+			ProjectPath:         "~/work/api",
+			Model:               "claude-sonnet-4-20250514",
+			Entries:             42,
+			InputTokens:         1000,
+			OutputTokens:        2000,
+			CacheCreationTokens: 3000,
+			CacheReadTokens:     4_000_000_000,
+			CostUSD:             12.5,
+			PriceVersion:        "2025-06",
+		},
+		{
+			Date:         "2026-10-18",
+			ProjectPath:  "~/oss/cli",
+			Model:        "claude-opus-4-20250514",
+			IsSidechain:  true,
+			Entries:      1,
+			InputTokens:  5,
+			OutputTokens: 6,
+			CostUSD:      0.0001,
+			PriceVersion: "2025-06",
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteParquetRollups(&buf, rollups); err != nil {
+		t.Fatalf("写入汇总失败: %v", err)
+	}
+
+	rows, err := parquet.Read[ParquetRollupRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("读取 Parquet 失败: %v", err)
+	}
+	if len(rows) != len(rollups) {
+		t.Fatalf("读回 %d 行，期望 %d 行", len(rows), len(rollups))
+	}
+
+	for i, rollup := range rollups {
+		row := rows[i]
+		if row.SchemaVersion != models.SchemaVersion {
+			t.Errorf("第 %d 行 schema_version = %d，期望 %d", i, row.SchemaVersion, models.SchemaVersion)
+		}
+		date := time.Unix(int64(row.Date)*86400, 0).UTC().Format("2006-01-02")
+		if date != rollup.Date {
+			t.Errorf("第 %d 行 date = %s，期望 %s", i, date, rollup.Date)
+		}
+		if row.ProjectPath != rollup.ProjectPath || row.Model != rollup.Model ||
+			row.PriceVersion != rollup.PriceVersion || row.IsSidechain != rollup.IsSidechain {
+			t.Errorf("第 %d 行维度字段不一致: %+v", i, row)
+		}
+		if row.Entries != int64(rollup.Entries) || row.InputTokens != int64(rollup.InputTokens) ||
+			row.OutputTokens != int64(rollup.OutputTokens) || row.CacheCreationTokens != int64(rollup.CacheCreationTokens) ||
+			row.CacheReadTokens != int64(rollup.CacheReadTokens) {
+			t.Errorf("第 %d 行计数不一致: %+v", i, row)
+		}
+		if row.CostUSD != rollup.CostUSD {
+			t.Errorf("第 %d 行 cost_usd = %v，期望 %v", i, row.CostUSD, rollup.CostUSD)
+		}
+	}
+
+	assertDictionaryColumns(t, buf.Bytes(), "model", "project_path")
+}
+
+// assertDictionaryColumns 检查指定列在每个行组中都使用字典编码
+func assertDictionaryColumns(t *testing.T, data []byte, columns ...string) {
+	t.Helper()
+
+	file, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("打开 Parquet 文件失败: %v", err)
+	}
+
+	for _, column := range columns {
+		found := false
+		for _, rowGroup := range file.Metadata().RowGroups {
+			for _, chunk := range rowGroup.Columns {
+				if strings.Join(chunk.MetaData.PathInSchema, ".") != column {
+					continue
+				}
+				found = true
+				if !hasDictionaryEncoding(chunk.MetaData.Encoding) {
+					t.Errorf("列 %s 未使用字典编码: %v", column, chunk.MetaData.Encoding)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("文件中没有列 %s", column)
+		}
+	}
+}
+
+// hasDictionaryEncoding 编码列表中是否包含字典编码
+func hasDictionaryEncoding(encodings []format.Encoding) bool {
+	for _, encoding := range encodings {
+		if encoding == format.RLEDictionary || encoding == format.PlainDictionary {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,259 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// projectTrendDays 项目趋势列显示的天数
+const projectTrendDays = 14
+
+// FormatProjects 格式化项目报告为表格
+func (f *Formatter) FormatProjects(report *models.ProjectsReport) (string, error) {
+	var output strings.Builder
+
+	// 添加标题
+	output.WriteString(f.Colors.IconHeader("📁", i18n.T("fmt.projects.title"), BrightBlue))
+	output.WriteString("\n")
+
+	if len(report.Projects) == 0 {
+		output.WriteString("\n   📝 " + i18n.T("fmt.projects.empty") + "\n")
+		return output.String(), nil
+	}
+
+	output.WriteString(f.Colors.Dim("   * " + i18n.T("fmt.projects.trend_hint", projectTrendDays) + "\n\n"))
+
+	// 所有项目的趋势使用相同的截止日期，便于纵向比较
+	trendEnd := report.Summary.LastActivity
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		f.Colors.Header(i18n.T("col.project")),
+		f.Colors.Header(i18n.T("col.path")),
+		f.Colors.Header(i18n.T("col.sessions")),
+		f.Colors.Header(i18n.T("col.messages")),
+		f.Colors.Header(i18n.T("col.total_tokens")),
+		f.Colors.Header(i18n.T("col.cost_usd")),
+		f.Colors.Header(i18n.T("col.sidechain_cost")),
+		f.Colors.Header(i18n.T("col.model")),
+		f.Colors.Header(i18n.T("col.trend")),
+		f.Colors.Header(i18n.T("col.first_activity")),
+		f.Colors.Header(i18n.T("col.last_activity")),
+	})
+
+	for _, project := range report.Projects {
+		name := project.ProjectName
+		if project.Group != "" && project.Group != project.ProjectName {
+			name += f.Colors.Dim(" [" + project.Group + "]")
+		}
+
+		path := project.ProjectPath
+		if path == "" && len(project.Paths) > 0 {
+			path = i18n.T("fmt.projects.paths_count", len(project.Paths))
+		}
+		if len(path) > 40 {
+			path = "..." + path[len(path)-37:]
+		}
+
+		t.AppendRow(table.Row{
+			f.Colors.BrightCyan(name),
+			f.Colors.Dim(path),
+			formatNumber(project.SessionCount),
+			formatNumber(project.MessageCount),
+			formatNumber(project.Tokens.GetTotalTokens()),
+			fmt.Sprintf("$%.4f", project.Cost),
+			f.Colors.Dim(formatSidechainCost(project.Sidechain.CostUSD, project.Cost)),
+			formatModelMix(project, 2),
+			f.Colors.Info(sparkline(projectTrend(project, trendEnd, projectTrendDays))),
+			formatActivity(project.FirstActivity),
+			formatActivity(project.LastActivity),
+		})
+
+		// 详细模式下显示模型分解和分组包含的路径
+		if f.ShowDetails {
+			for _, mb := range sortedModelBuckets(project.Models) {
+				t.AppendRow(table.Row{
+					f.Colors.Dim("  └─ " + mb.model),
+					"",
+					"",
+					formatNumber(mb.bucket.MessageCount),
+					formatNumber(mb.bucket.Tokens.GetTotalTokens()),
+					fmt.Sprintf("$%.4f", mb.bucket.CostUSD),
+					"", "", "", "", "",
+				})
+			}
+			for _, p := range project.Paths {
+				t.AppendRow(table.Row{f.Colors.Dim("  · " + p), "", "", "", "", "", "", "", "", "", ""})
+			}
+		}
+	}
+
+	// 添加汇总行
+	t.AppendFooter(table.Row{
+		f.Colors.Bold(i18n.T("common.total")),
+		"",
+		f.Colors.Bold(formatNumber(report.Summary.SessionCount)),
+		f.Colors.Bold(formatNumber(report.Summary.MessageCount)),
+		f.Colors.Bold(formatNumber(report.Summary.Tokens.GetTotalTokens())),
+		f.Colors.Bold(fmt.Sprintf("$%.4f", report.Summary.Cost)),
+		f.Colors.Bold(formatSidechainCost(report.Summary.Sidechain.CostUSD, report.Summary.Cost)),
+		"",
+		sparkline(projectTrend(report.Summary, trendEnd, projectTrendDays)),
+		"",
+		"",
+	})
+
+	t.SetStyle(table.StyleColoredBright)
+	output.WriteString(t.Render())
+	output.WriteString("\n\n")
+
+	return output.String(), nil
+}
+
+// FormatProjectsJSON 格式化项目报告为JSON
+func (f *Formatter) FormatProjectsJSON(report *models.ProjectsReport) (string, error) {
+	return marshalReport(report)
+}
+
+// FormatProjectsCSV 格式化项目报告为CSV
+func (f *Formatter) FormatProjectsCSV(report *models.ProjectsReport) (string, error) {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+
+	// 写入标题行（使用与语言无关的稳定标识，便于脚本处理）
+	headers := []string{
+		"project", "path", "group", "session_count", "message_count", "input_tokens", "output_tokens",
+		"cache_creation_tokens", "cache_read_tokens", "total_tokens", "cost_usd",
+		"sidechain_tokens", "sidechain_cost_usd", "models",
+		"first_activity", "last_activity",
+	}
+	if err := writer.Write(headers); err != nil {
+		return "", err
+	}
+
+	rows := append([]models.ProjectStats{}, report.Projects...)
+	rows = append(rows, report.Summary)
+
+	for _, project := range rows {
+		path := project.ProjectPath
+		if path == "" {
+			path = strings.Join(project.Paths, ";")
+		}
+
+		var modelNames []string
+		for _, mb := range sortedModelBuckets(project.Models) {
+			modelNames = append(modelNames, mb.model)
+		}
+
+		row := []string{
+			project.ProjectName,
+			path,
+			project.Group,
+			fmt.Sprintf("%d", project.SessionCount),
+			fmt.Sprintf("%d", project.MessageCount),
+			fmt.Sprintf("%d", project.Tokens.InputTokens),
+			fmt.Sprintf("%d", project.Tokens.OutputTokens),
+			fmt.Sprintf("%d", project.Tokens.CacheCreationTokens),
+			fmt.Sprintf("%d", project.Tokens.CacheReadTokens),
+			fmt.Sprintf("%d", project.Tokens.GetTotalTokens()),
+			fmt.Sprintf("%.4f", project.Cost),
+			fmt.Sprintf("%d", project.Sidechain.Tokens.GetTotalTokens()),
+			fmt.Sprintf("%.4f", project.Sidechain.CostUSD),
+			strings.Join(modelNames, ","),
+			formatTimestamp(project.FirstActivity),
+			formatTimestamp(project.LastActivity),
+		}
+		if err := writer.Write(row); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return builder.String(), writer.Error()
+}
+
+// modelBucket 排序用的模型用量
+type modelBucket struct {
+	model  string
+	bucket models.UsageBucket
+}
+
+// sortedModelBuckets 按成本从高到低排列模型用量
+func sortedModelBuckets(buckets map[string]models.UsageBucket) []modelBucket {
+	result := make([]modelBucket, 0, len(buckets))
+	for model, bucket := range buckets {
+		result = append(result, modelBucket{model, bucket})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].bucket.CostUSD != result[j].bucket.CostUSD {
+			return result[i].bucket.CostUSD > result[j].bucket.CostUSD
+		}
+		return result[i].model < result[j].model
+	})
+	return result
+}
+
+// formatModelMix 显示成本占比最高的几个模型
+func formatModelMix(project models.ProjectStats, limit int) string {
+	return formatBucketMix(project.Models, project.Cost, limit)
+}
+
+// formatBucketMix 按成本占比显示前几个模型，total 为总成本
+func formatBucketMix(usage map[string]models.UsageBucket, total float64, limit int) string {
+	buckets := sortedModelBuckets(usage)
+	if len(buckets) == 0 {
+		return i18n.T("common.none")
+	}
+
+	var parts []string
+	for i, mb := range buckets {
+		if i >= limit {
+			parts = append(parts, fmt.Sprintf("+%d", len(buckets)-limit))
+			break
+		}
+		share := 0.0
+		if total > 0 {
+			share = mb.bucket.CostUSD / total * 100
+		}
+		parts = append(parts, fmt.Sprintf("%s %.0f%%", mb.model, share))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// projectTrend 取截止日期前若干天的每日Token数
+func projectTrend(project models.ProjectStats, end time.Time, days int) []float64 {
+	values := make([]float64, days)
+	if end.IsZero() {
+		return values
+	}
+	for i := 0; i < days; i++ {
+		date := end.AddDate(0, 0, i-days+1).Format("2006-01-02")
+		if bucket, ok := project.Daily[date]; ok {
+			values[i] = float64(bucket.Tokens.GetTotalTokens())
+		}
+	}
+	return values
+}
+
+// formatActivity 格式化活动时间（表格用）
+func formatActivity(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// formatTimestamp 格式化时间戳（CSV用）
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,143 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// FormatQuery 格式化 SQL 查询结果为表格
+func (f *Formatter) FormatQuery(result *models.QueryResult) (string, error) {
+	var output strings.Builder
+
+	t := table.NewWriter()
+	header := table.Row{}
+	for _, column := range result.Columns {
+		header = append(header, f.Colors.Header(column))
+	}
+	t.AppendHeader(header)
+
+	// 数值列右对齐
+	var configs []table.ColumnConfig
+	for i := range result.Columns {
+		if queryColumnIsNumeric(result, i) {
+			configs = append(configs, table.ColumnConfig{Number: i + 1, Align: text.AlignRight})
+		}
+	}
+	t.SetColumnConfigs(configs)
+
+	for _, values := range result.Rows {
+		row := table.Row{}
+		for _, value := range values {
+			if value == nil {
+				row = append(row, f.Colors.Dim("NULL"))
+				continue
+			}
+			row = append(row, queryValue(value))
+		}
+		t.AppendRow(row)
+	}
+
+	// 列名保持 SQL 中的原样，不做大写转换
+	t.SetStyle(table.StyleColoredBright)
+	t.Style().Format.Header = text.FormatDefault
+	output.WriteString(t.Render())
+	output.WriteString("\n")
+	output.WriteString(f.Colors.Dim("   " + i18n.T("fmt.query.rows", len(result.Rows))))
+	output.WriteString("\n")
+
+	return output.String(), nil
+}
+
+// FormatQueryJSON 格式化 SQL 查询结果为JSON
+func (f *Formatter) FormatQueryJSON(result *models.QueryResult) (string, error) {
+	return marshalReport(result)
+}
+
+// FormatQueryCSV 格式化 SQL 查询结果为CSV（列名即标题行，NULL 输出为空）
+func (f *Formatter) FormatQueryCSV(result *models.QueryResult) (string, error) {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+
+	if err := writer.Write(result.Columns); err != nil {
+		return "", err
+	}
+	for _, values := range result.Rows {
+		row := make([]string, len(values))
+		for i, value := range values {
+			if value != nil {
+				row[i] = queryValue(value)
+			}
+		}
+		if err := writer.Write(row); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return builder.String(), writer.Error()
+}
+
+// FormatQueryMarkdown 格式化 SQL 查询结果为 Markdown 表格
+func (f *Formatter) FormatQueryMarkdown(result *models.QueryResult) (string, error) {
+	md := &markdownWriter{}
+
+	header := table.Row{}
+	for _, column := range result.Columns {
+		header = append(header, column)
+	}
+
+	var rows []table.Row
+	for _, values := range result.Rows {
+		row := table.Row{}
+		for _, value := range values {
+			if value == nil {
+				row = append(row, "NULL")
+				continue
+			}
+			row = append(row, queryValue(value))
+		}
+		rows = append(rows, row)
+	}
+	md.table(header, rows, nil)
+	md.note(i18n.T("fmt.query.rows", len(result.Rows)))
+
+	return md.String(), nil
+}
+
+// queryValue 将数据库返回的值转为显示文本；浮点数去掉多余的尾随零
+func queryValue(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// queryColumnIsNumeric 检查某列的非空值是否全部为数值
+func queryColumnIsNumeric(result *models.QueryResult, column int) bool {
+	numeric := false
+	for _, values := range result.Rows {
+		switch values[column].(type) {
+		case nil:
+			continue
+		case int64, float64:
+			numeric = true
+		default:
+			return false
+		}
+	}
+	return numeric
+}
@@ -0,0 +1,236 @@
+package formatter
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+)
+
+// bundledTemplates 随程序发布的示例模板，可以用 --template <名称> 直接使用
+//
+//go:embed templates/*.tmpl
+var bundledTemplates embed.FS
+
+// templateColors 模板中 color 函数可用的颜色名
+var templateColors = map[string]string{
+	"red":            Red,
+	"green":          Green,
+	"yellow":         Yellow,
+	"blue":           Blue,
+	"magenta":        Magenta,
+	"cyan":           Cyan,
+	"white":          White,
+	"bright_red":     BrightRed,
+	"bright_green":   BrightGreen,
+	"bright_yellow":  BrightYellow,
+	"bright_blue":    BrightBlue,
+	"bright_magenta": BrightMagenta,
+	"bright_cyan":    BrightCyan,
+	"bold":           Bold,
+	"dim":            Dim,
+}
+
+// BundledTemplateNames 返回内置示例模板的名称（不含扩展名）
+func BundledTemplateNames() []string {
+	entries, err := bundledTemplates.ReadDir("templates")
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".tmpl"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadTemplate 读取模板内容：优先按文件路径读取，文件不存在时查找同名的内置模板
+func LoadTemplate(name string) (string, error) {
+	data, err := os.ReadFile(name)
+	if err == nil {
+		return string(data), nil
+	}
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	bundled := path.Join("templates", strings.TrimSuffix(name, ".tmpl")+".tmpl")
+	if data, bundledErr := bundledTemplates.ReadFile(bundled); bundledErr == nil {
+		return string(data), nil
+	}
+	return "", i18n.Errorf("err.template_not_found", name, strings.Join(BundledTemplateNames(), ", "))
+}
+
+// FormatTemplate 使用 f.Template 中的 text/template 渲染报告数据
+// data 为与 JSON 输出相同的报告结构（DailyReport、BlocksReport、UsageStats 等）
+func (f *Formatter) FormatTemplate(data interface{}) (string, error) {
+	tmpl, err := template.New("report").Funcs(f.templateFuncs()).Parse(f.Template)
+	if err != nil {
+		return "", err
+	}
+
+	var output strings.Builder
+	if err := tmpl.Execute(&output, data); err != nil {
+		return "", err
+	}
+	return output.String(), nil
+}
+
+// templateFuncs 模板中可用的辅助函数；颜色函数遵循 --no-color
+func (f *Formatter) templateFuncs() template.FuncMap {
+	colorize := func(name string) func(interface{}) string {
+		return func(value interface{}) string {
+			return f.Colors.Colorize(fmt.Sprint(value), templateColors[name])
+		}
+	}
+
+	funcs := template.FuncMap{
+		// 数字与金额
+		"number": func(value interface{}) (string, error) {
+			n, err := toFloat(value)
+			if err != nil {
+				return "", err
+			}
+			return formatNumber(int(math.Round(n))), nil
+		},
+		"cost": func(value interface{}, precision ...int) (string, error) {
+			n, err := toFloat(value)
+			if err != nil {
+				return "", err
+			}
+			digits := 4
+			if len(precision) > 0 {
+				digits = precision[0]
+			}
+			return fmt.Sprintf("$%.*f", digits, n), nil
+		},
+		"percent": func(part, total interface{}) (string, error) {
+			p, err := toFloat(part)
+			if err != nil {
+				return "", err
+			}
+			t, err := toFloat(total)
+			if err != nil {
+				return "", err
+			}
+			if t == 0 {
+				return "-", nil
+			}
+			return fmt.Sprintf("%.1f%%", p/t*100), nil
+		},
+		"add": floatOp(func(a, b float64) float64 { return a + b }),
+		"sub": floatOp(func(a, b float64) float64 { return a - b }),
+		"mul": floatOp(func(a, b float64) float64 { return a * b }),
+		"div": floatOp(func(a, b float64) float64 {
+			if b == 0 {
+				return 0
+			}
+			return a / b
+		}),
+
+		// 时间
+		"date": func(layout string, t time.Time) string {
+			if t.IsZero() {
+				return "-"
+			}
+			return t.Local().Format(layout)
+		},
+		"duration": func(value interface{}) (string, error) {
+			switch v := value.(type) {
+			case time.Duration:
+				return formatSeconds(v.Seconds(), 1), nil
+			case string:
+				if d, err := time.ParseDuration(v); err == nil {
+					return formatSeconds(d.Seconds(), 1), nil
+				}
+				return v, nil
+			default:
+				seconds, err := toFloat(value)
+				if err != nil {
+					return "", err
+				}
+				return formatSeconds(seconds, 1), nil
+			}
+		},
+
+		// 颜色
+		"color": func(name string, value interface{}) (string, error) {
+			code, ok := templateColors[name]
+			if !ok {
+				return "", fmt.Errorf("unknown color %q", name)
+			}
+			return f.Colors.Colorize(fmt.Sprint(value), code), nil
+		},
+
+		// 文本
+		"t":        i18n.T,
+		"join":     strings.Join,
+		"upper":    strings.ToUpper,
+		"lower":    strings.ToLower,
+		"repeat":   func(count int, s string) string { return strings.Repeat(s, intMax(count, 0)) },
+		"padLeft":  func(width int, value interface{}) string { return padLeft(fmt.Sprint(value), width) },
+		"padRight": func(width int, value interface{}) string { return padRight(fmt.Sprint(value), width) },
+		"json": func(value interface{}) (string, error) {
+			data, err := json.Marshal(value)
+			return string(data), err
+		},
+	}
+	for name := range templateColors {
+		funcs[name] = colorize(name)
+	}
+	return funcs
+}
+
+// floatOp 将二元浮点运算包装为接受任意数值类型的模板函数
+func floatOp(op func(a, b float64) float64) func(a, b interface{}) (float64, error) {
+	return func(a, b interface{}) (float64, error) {
+		x, err := toFloat(a)
+		if err != nil {
+			return 0, err
+		}
+		y, err := toFloat(b)
+		if err != nil {
+			return 0, err
+		}
+		return op(x, y), nil
+	}
+}
+
+// toFloat 将模板中的任意数值类型转换为 float64
+func toFloat(value interface{}) (float64, error) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Ptr:
+		if v.IsNil() {
+			return 0, nil
+		}
+		return toFloat(v.Elem().Interface())
+	default:
+		return 0, fmt.Errorf("expected a number, got %T", value)
+	}
+}
+
+// padRight 按显示宽度右侧补齐空格
+func padRight(value string, width int) string {
+	if padding := width - text.RuneWidthWithoutEscSequences(value); padding > 0 {
+		return value + strings.Repeat(" ", padding)
+	}
+	return value
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package formatter
+
+// ttyWidth 非Unix平台不检测终端宽度，使用 COLUMNS 或默认值
+func ttyWidth() int {
+	return 0
+}
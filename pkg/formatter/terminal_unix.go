@@ -0,0 +1,18 @@
+//go:build unix
+
+package formatter
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// ttyWidth 读取标准输出所在终端的列数，不是终端时返回0
+func ttyWidth() int {
+	size, err := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0
+	}
+	return int(size.Col)
+}
@@ -0,0 +1,193 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// defaultTopProjects 每个工具默认显示的项目数
+const defaultTopProjects = 3
+
+// FormatTools 格式化工具报告为表格
+func (f *Formatter) FormatTools(report *models.ToolsReport) (string, error) {
+	var output strings.Builder
+
+	// 添加标题
+	output.WriteString(f.Colors.IconHeader("🔧", i18n.T("fmt.tools.title"), BrightMagenta))
+	output.WriteString("\n")
+
+	if len(report.Tools) == 0 {
+		output.WriteString("\n   📝 " + i18n.T("fmt.tools.empty") + "\n")
+		return output.String(), nil
+	}
+
+	output.WriteString(f.Colors.Dim("   * " + i18n.T("fmt.tools.cost_hint") + "\n\n"))
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		f.Colors.Header(i18n.T("col.tool")),
+		f.Colors.Header(i18n.T("col.calls")),
+		f.Colors.Header(i18n.T("col.errors")),
+		f.Colors.Header(i18n.T("col.error_rate")),
+		f.Colors.Header(i18n.T("col.total_tokens")),
+		f.Colors.Header(i18n.T("col.cost_usd")),
+		f.Colors.Header(i18n.T("col.top_projects")),
+	})
+
+	for _, tool := range report.Tools {
+		t.AppendRow(table.Row{
+			f.Colors.BrightCyan(tool.Name),
+			formatNumber(tool.CallCount),
+			formatNumber(tool.ErrorCount),
+			f.errorRate(tool.ErrorRate()),
+			formatNumber(tool.Tokens.GetTotalTokens()),
+			fmt.Sprintf("$%.4f", tool.Cost),
+			strings.Join(f.topToolProjects(tool), "\n"),
+		})
+	}
+
+	// 添加汇总行
+	t.AppendFooter(table.Row{
+		f.Colors.Bold(i18n.T("common.total")),
+		f.Colors.Bold(formatNumber(report.Summary.CallCount)),
+		f.Colors.Bold(formatNumber(report.Summary.ErrorCount)),
+		f.Colors.Bold(fmt.Sprintf("%.1f%%", report.Summary.ErrorRate()*100)),
+		f.Colors.Bold(formatNumber(report.Summary.Tokens.GetTotalTokens())),
+		f.Colors.Bold(fmt.Sprintf("$%.4f", report.Summary.Cost)),
+		"",
+	})
+
+	t.SetStyle(table.StyleColoredBright)
+	output.WriteString(t.Render())
+	output.WriteString("\n\n")
+
+	return output.String(), nil
+}
+
+// FormatToolsJSON 格式化工具报告为JSON
+func (f *Formatter) FormatToolsJSON(report *models.ToolsReport) (string, error) {
+	return marshalReport(report)
+}
+
+// FormatToolsCSV 格式化工具报告为CSV
+func (f *Formatter) FormatToolsCSV(report *models.ToolsReport) (string, error) {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+
+	// 写入标题行（使用与语言无关的稳定标识，便于脚本处理）
+	headers := []string{
+		"tool", "call_count", "result_count", "error_count", "error_rate",
+		"input_tokens", "output_tokens", "cache_creation_tokens", "cache_read_tokens",
+		"total_tokens", "cost_usd", "top_projects",
+	}
+	if err := writer.Write(headers); err != nil {
+		return "", err
+	}
+
+	rows := append([]models.ToolStats{}, report.Tools...)
+	rows = append(rows, report.Summary)
+
+	for _, tool := range rows {
+		var projects []string
+		for _, tp := range sortedToolProjects(tool, f.topN()) {
+			projects = append(projects, fmt.Sprintf("%s:%d", tp.project, tp.calls))
+		}
+
+		row := []string{
+			tool.Name,
+			fmt.Sprintf("%d", tool.CallCount),
+			fmt.Sprintf("%d", tool.ResultCount),
+			fmt.Sprintf("%d", tool.ErrorCount),
+			fmt.Sprintf("%.4f", tool.ErrorRate()),
+			fmt.Sprintf("%d", tool.Tokens.InputTokens),
+			fmt.Sprintf("%d", tool.Tokens.OutputTokens),
+			fmt.Sprintf("%d", tool.Tokens.CacheCreationTokens),
+			fmt.Sprintf("%d", tool.Tokens.CacheReadTokens),
+			fmt.Sprintf("%d", tool.Tokens.GetTotalTokens()),
+			fmt.Sprintf("%.4f", tool.Cost),
+			strings.Join(projects, ";"),
+		}
+		if err := writer.Write(row); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return builder.String(), writer.Error()
+}
+
+// toolProject 工具在某个项目中的调用次数
+type toolProject struct {
+	project string
+	calls   int
+}
+
+// sortedToolProjects 返回调用次数最多的前n个项目
+func sortedToolProjects(tool models.ToolStats, n int) []toolProject {
+	result := make([]toolProject, 0, len(tool.Projects))
+	for project, calls := range tool.Projects {
+		result = append(result, toolProject{project, calls})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].calls != result[j].calls {
+			return result[i].calls > result[j].calls
+		}
+		return result[i].project < result[j].project
+	})
+	if n > 0 && len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// topToolProjects 格式化工具调用最多的项目（表格用）
+func (f *Formatter) topToolProjects(tool models.ToolStats) []string {
+	var lines []string
+	for _, tp := range sortedToolProjects(tool, f.topN()) {
+		lines = append(lines, fmt.Sprintf("%s (%s)", projectLabel(tp.project), formatNumber(tp.calls)))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, f.Colors.Dim(i18n.T("common.none")))
+	}
+	return lines
+}
+
+// projectLabel 返回项目键的简短显示名（路径取最后两级目录，别名原样显示）
+func projectLabel(key string) string {
+	if !strings.ContainsAny(key, `/\`) {
+		return key
+	}
+	parent := filepath.Base(filepath.Dir(key))
+	if parent == "." || parent == string(filepath.Separator) {
+		return filepath.Base(key)
+	}
+	return parent + "/" + filepath.Base(key)
+}
+
+// errorRate 按错误率高低着色
+func (f *Formatter) errorRate(rate float64) string {
+	text := fmt.Sprintf("%.1f%%", rate*100)
+	switch {
+	case rate >= 0.2:
+		return f.Colors.Error(text)
+	case rate >= 0.05:
+		return f.Colors.Warning(text)
+	default:
+		return f.Colors.Success(text)
+	}
+}
+
+// topN 返回排行列表的条目数
+func (f *Formatter) topN() int {
+	if f.TopN > 0 {
+		return f.TopN
+	}
+	return defaultTopProjects
+}
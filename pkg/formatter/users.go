@@ -0,0 +1,198 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// FormatUsers 格式化用户报告为表格
+func (f *Formatter) FormatUsers(report *models.UsersReport) (string, error) {
+	var output strings.Builder
+
+	output.WriteString(f.Colors.IconHeader("👥", i18n.T("fmt.users.title"), BrightBlue))
+	output.WriteString("\n")
+
+	if len(report.Users) == 0 {
+		output.WriteString("\n   📝 " + i18n.T("fmt.users.empty") + "\n")
+		return output.String(), nil
+	}
+
+	t := table.NewWriter()
+	t.AppendHeader(usersHeader(f.Colors.Header))
+
+	for _, user := range report.Users {
+		t.AppendRow(table.Row{
+			f.Colors.BrightCyan(user.User),
+			f.Colors.Dim(strings.Join(user.Hosts, "\n")),
+			formatNumber(user.ProjectCount),
+			formatNumber(user.SessionCount),
+			formatNumber(user.MessageCount),
+			formatNumber(user.Tokens.GetTotalTokens()),
+			fmt.Sprintf("$%.4f", user.Cost),
+			f.Colors.Dim(formatSidechainCost(user.Sidechain.CostUSD, user.Cost)),
+			formatBucketMix(user.Models, user.Cost, 2),
+			formatActivity(user.FirstActivity),
+			formatActivity(user.LastActivity),
+		})
+
+		// 详细模式下显示模型分解
+		if f.ShowDetails {
+			for _, mb := range sortedModelBuckets(user.Models) {
+				t.AppendRow(table.Row{
+					f.Colors.Dim("  └─ " + mb.model),
+					"", "", "",
+					formatNumber(mb.bucket.MessageCount),
+					formatNumber(mb.bucket.Tokens.GetTotalTokens()),
+					fmt.Sprintf("$%.4f", mb.bucket.CostUSD),
+					"", "", "", "",
+				})
+			}
+		}
+	}
+
+	summary := report.Summary
+	t.AppendFooter(table.Row{
+		f.Colors.Bold(i18n.T("common.total")),
+		"",
+		f.Colors.Bold(formatNumber(summary.ProjectCount)),
+		f.Colors.Bold(formatNumber(summary.SessionCount)),
+		f.Colors.Bold(formatNumber(summary.MessageCount)),
+		f.Colors.Bold(formatNumber(summary.Tokens.GetTotalTokens())),
+		f.Colors.Bold(fmt.Sprintf("$%.4f", summary.Cost)),
+		f.Colors.Bold(formatSidechainCost(summary.Sidechain.CostUSD, summary.Cost)),
+		"", "", "",
+	})
+
+	t.SetStyle(table.StyleColoredBright)
+	output.WriteString(t.Render())
+	output.WriteString("\n\n")
+
+	return output.String(), nil
+}
+
+// FormatUsersJSON 格式化用户报告为JSON
+func (f *Formatter) FormatUsersJSON(report *models.UsersReport) (string, error) {
+	return marshalReport(report)
+}
+
+// FormatUsersCSV 格式化用户报告为CSV
+func (f *Formatter) FormatUsersCSV(report *models.UsersReport) (string, error) {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+
+	// 写入标题行（使用与语言无关的稳定标识，便于脚本处理）
+	headers := []string{
+		"user", "hosts", "project_count", "session_count", "message_count", "input_tokens", "output_tokens",
+		"cache_creation_tokens", "cache_read_tokens", "total_tokens", "cost_usd",
+		"sidechain_tokens", "sidechain_cost_usd", "models",
+		"first_activity", "last_activity",
+	}
+	if err := writer.Write(headers); err != nil {
+		return "", err
+	}
+
+	rows := append([]models.UserStats{}, report.Users...)
+	rows = append(rows, report.Summary)
+
+	for _, user := range rows {
+		var modelNames []string
+		for _, mb := range sortedModelBuckets(user.Models) {
+			modelNames = append(modelNames, mb.model)
+		}
+
+		row := []string{
+			user.User,
+			strings.Join(user.Hosts, ";"),
+			fmt.Sprintf("%d", user.ProjectCount),
+			fmt.Sprintf("%d", user.SessionCount),
+			fmt.Sprintf("%d", user.MessageCount),
+			fmt.Sprintf("%d", user.Tokens.InputTokens),
+			fmt.Sprintf("%d", user.Tokens.OutputTokens),
+			fmt.Sprintf("%d", user.Tokens.CacheCreationTokens),
+			fmt.Sprintf("%d", user.Tokens.CacheReadTokens),
+			fmt.Sprintf("%d", user.Tokens.GetTotalTokens()),
+			fmt.Sprintf("%.4f", user.Cost),
+			fmt.Sprintf("%d", user.Sidechain.Tokens.GetTotalTokens()),
+			fmt.Sprintf("%.4f", user.Sidechain.CostUSD),
+			strings.Join(modelNames, ","),
+			formatTimestamp(user.FirstActivity),
+			formatTimestamp(user.LastActivity),
+		}
+		if err := writer.Write(row); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return builder.String(), writer.Error()
+}
+
+// FormatUsersMarkdown 格式化用户报告为Markdown
+func (f *Formatter) FormatUsersMarkdown(report *models.UsersReport) (string, error) {
+	var md markdownWriter
+	md.title(i18n.T("fmt.users.title"))
+
+	if len(report.Users) == 0 {
+		md.text(i18n.T("fmt.users.empty"))
+		return md.String(), nil
+	}
+
+	summary := report.Summary
+	md.summary(
+		i18n.T("fmt.users.title"), formatNumber(len(report.Users)),
+		i18n.T("col.sessions"), formatNumber(summary.SessionCount),
+		i18n.T("col.total_tokens"), formatNumber(summary.Tokens.GetTotalTokens()),
+		i18n.T("col.cost_usd"), fmt.Sprintf("$%.4f", summary.Cost),
+	)
+
+	var rows []table.Row
+	for _, user := range report.Users {
+		rows = append(rows, table.Row{
+			user.User,
+			strings.Join(user.Hosts, ", "),
+			formatNumber(user.ProjectCount),
+			formatNumber(user.SessionCount),
+			formatNumber(user.MessageCount),
+			formatNumber(user.Tokens.GetTotalTokens()),
+			fmt.Sprintf("$%.4f", user.Cost),
+			formatSidechainCost(user.Sidechain.CostUSD, user.Cost),
+			strings.ReplaceAll(formatBucketMix(user.Models, user.Cost, 2), "\n", ", "),
+			formatActivity(user.FirstActivity),
+			formatActivity(user.LastActivity),
+		})
+	}
+	md.table(usersHeader(func(s string) string { return s }), rows, table.Row{
+		i18n.T("common.total"), "",
+		formatNumber(summary.ProjectCount),
+		formatNumber(summary.SessionCount),
+		formatNumber(summary.MessageCount),
+		formatNumber(summary.Tokens.GetTotalTokens()),
+		fmt.Sprintf("$%.4f", summary.Cost),
+		formatSidechainCost(summary.Sidechain.CostUSD, summary.Cost),
+		"", "", "",
+	})
+
+	return md.String(), nil
+}
+
+// usersHeader 用户表的表头，header 用于为表格输出着色
+func usersHeader(header func(string) string) table.Row {
+	return table.Row{
+		header(i18n.T("col.user")),
+		header(i18n.T("col.hosts")),
+		header(i18n.T("col.projects")),
+		header(i18n.T("col.sessions")),
+		header(i18n.T("col.messages")),
+		header(i18n.T("col.total_tokens")),
+		header(i18n.T("col.cost_usd")),
+		header(i18n.T("col.sidechain_cost")),
+		header(i18n.T("col.model")),
+		header(i18n.T("col.first_activity")),
+		header(i18n.T("col.last_activity")),
+	}
+}
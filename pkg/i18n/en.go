@@ -0,0 +1,822 @@
+package i18n
+
+// enMessages 英文消息目录
+// 键必须与 zhMessages 保持一致
+var enMessages = map[string]string{
+	// 命令说明
+	"cmd.root.short": "The complete Claude Code usage statistics tool",
+	"cmd.root.long": `claude-stats - professional usage statistics and analysis for Claude Code
+
+Features:
+• Specialized commands (daily, monthly, session, blocks)
+• Smart cost calculation and trend analysis
+• Multiple config directories (CLAUDE_CONFIG_DIR environment variable)
+• Live monitoring and token limit warnings
+• Cross-platform (Windows, Mac, Linux, WSL)
+• Multiple export formats (JSON, CSV, table)
+• Localized interface (--lang zh|en, config key lang, or LANG/LC_ALL)
+
+Basic commands:
+  claude-stats daily             # Daily usage report (default)
+  claude-stats monthly           # Monthly usage report
+  claude-stats session           # Session analysis
+  claude-stats blocks            # 5-hour billing block analysis
+  claude-stats blocks --live     # Live monitoring mode
+  claude-stats analyze           # General analysis (legacy)
+
+Quick start:
+  claude-stats                   # Show daily usage
+  claude-stats --breakdown       # Show per-model breakdown
+  claude-stats --json            # JSON output
+  claude-stats --lang zh         # Chinese interface
+
+Multiple config directories:
+  export CLAUDE_CONFIG_DIR="/path1,/path2"
+  claude-stats daily --breakdown`,
+	"cmd.daily.short": "Analyze Claude Code usage by day",
+	"cmd.daily.long": `Analyze Claude Code usage by date, with precise daily token statistics and cost analysis.
+
+This command uses data processing optimized for per-day grouping to keep
+results accurate and fast. Compared with the general analysis, daily offers:
+• Exact grouping on date boundaries
+• Optimized date range handling
+• Dedicated per-day cost allocation
+• More accurate usage pattern analysis
+
+Features:
+• Daily token usage statistics
+• Daily cost analysis and trends
+• Per-model breakdown (--breakdown)
+• Date range filters (--since, --until)
+• Sort order (--order)
+
+Examples:
+  claude-stats daily                           # Usage for all dates
+  claude-stats daily --breakdown               # Per-model breakdown for each day
+  claude-stats daily --since 20241201         # Usage since December 1st
+  claude-stats daily --since 20241201 --until 20241231  # Usage in December
+  claude-stats daily --order asc              # Oldest first
+  claude-stats daily --json                   # JSON output
+
+Date formats:
+  YYYYMMDD, YYYY-MM-DD, YYYY/MM/DD and more are supported`,
+	"cmd.blocks.short": "Analyze 5-hour billing block usage",
+	"cmd.blocks.long": `Analyze Claude Code usage in 5-hour billing blocks to understand usage patterns and forecast cost.
+
+The 5-hour block is the billing unit of Claude subscriptions; each block has a fixed message limit.
+This command helps you:
+• See usage intensity across time periods
+• Identify active usage blocks
+• Forecast usage in the current block
+• Monitor usage in real time
+
+Features:
+• Automatic block boundary detection (one block every 5 hours)
+• Active block status and time remaining
+• Burn rate and projected usage
+• Live monitoring mode (--live)
+• Token limit warnings (--token-limit)
+
+Examples:
+  claude-stats blocks                    # Basic block analysis
+  claude-stats blocks --live             # Monitor the current block live
+  claude-stats blocks --live -t 500000   # Monitor against a token limit
+  claude-stats blocks --active           # Only show the active block
+  claude-stats blocks --recent           # Show recent blocks
+  claude-stats blocks --json             # JSON output`,
+	"cmd.analyze.short": "Analyze Claude Code usage statistics",
+	"cmd.analyze.long": `Analyze Claude Code JSONL logs in a directory and produce a detailed usage report.
+
+Features:
+• Automatic Claude log directory detection
+• Token statistics (input, output, cache)
+• Cost estimation (API and subscription modes)
+• Grouping by model, date and session
+• Multiple output formats (table, JSON, CSV)
+• Multiple config directories (via CLAUDE_CONFIG_DIR)
+
+Examples:
+  claude-stats analyze                    # Analyze the default Claude directory
+  claude-stats analyze ~/claude-logs     # Analyze a specific directory
+  claude-stats analyze --json            # JSON output
+  claude-stats analyze --csv report.csv  # Export a CSV report
+  claude-stats analyze --breakdown       # Show per-model breakdown
+  claude-stats analyze --plan max5x      # Subscription quota against the Max5x budgets
+  claude-stats analyze --since 20240101 --until 20241231  # Date range
+
+Multiple config directories:
+  export CLAUDE_CONFIG_DIR="/path1,/path2"  # Analyze several directories
+  claude-stats analyze --breakdown           # Aggregate all config directories`,
+	"cmd.projects.short": "Analyze Claude Code usage by project",
+	"cmd.projects.long": `Summarize Claude Code usage by project (the full path of the working directory).
+
+Projects with the same name in different directories are counted separately, e.g. ~/work/api and ~/oss/api.
+Each project shows sessions, messages, tokens, cost, model mix, first/last activity and a daily trend.
+
+Aliases and groups can be configured in the config file:
+  projects:
+    - match: ~/work/api        # full path, path prefix or wildcard
+      alias: work-api          # paths with the same alias are merged into one project
+    - match: ~/oss/*
+      group: oss               # use --group to aggregate by group
+
+Examples:
+  claude-stats projects                     # All projects sorted by cost
+  claude-stats projects --sort last         # Sort by last activity
+  claude-stats projects --group             # Aggregate by group
+  claude-stats projects --details           # Per-project model breakdown
+  claude-stats projects --project work-api  # Only the given project
+  claude-stats projects --format csv -o projects.csv
+
+The --project filter works with every command and accepts a full path, path prefix, wildcard, alias, group name or directory name.`,
+	"cmd.branches.short": "Analyze Claude Code usage by Git branch",
+	"cmd.branches.long": `Summarize Claude Code usage by project and Git branch to answer "how much did feature X cost to build".
+
+Every Claude Code log entry carries a gitBranch field; this command attributes tokens and cost to project + branch.
+With --commits it runs git log locally and links each branch to commits made during its session windows (up to 30 minutes after a session ends).
+
+Examples:
+  claude-stats branches                         # Branch cost for all projects
+  claude-stats branches --branch 'feature/*'    # Feature branches only
+  claude-stats branches --commits --details     # Link and list commits
+  claude-stats branches --repo ~/work/api --project ~/work/api
+  claude-stats branches --format csv -o branches.csv`,
+	"cmd.tools.short": "Analyze tool calls, error rates and cost",
+	"cmd.tools.long": `Count tool_use content blocks in assistant messages (Bash, Edit, Read, Grep, Task…) and the matching tool_result blocks in user messages.
+
+Each tool shows:
+• Call count and error count (tool_result with is_error true)
+• Error rate
+• Tokens and cost of the messages that issued the calls (split evenly when one message calls several tools)
+• The projects using it most
+
+Examples:
+  claude-stats tools                      # Sorted by cost
+  claude-stats tools --sort error_rate    # Find the tools that fail most
+  claude-stats tools --top 5              # Show the top 5 projects per tool
+  claude-stats tools --format csv -o tools.csv`,
+	"cmd.cache.short": "Analyze prompt cache hit ratio, savings and wasted cache writes",
+	"cmd.cache.long": `Measure prompt cache efficiency per day, project, session or model.
+
+Each row shows:
+• Hit ratio: cache reads as a share of all input (input + cache writes + cache reads)
+• Savings: what cache reads saved compared with paying the full input price
+• Net savings: savings minus the premium paid for cache writes over the input price
+• Wasted writes: cache writes that were not read back later in the same session within the 5-minute or 1-hour TTL (estimated)
+
+Examples:
+  claude-stats cache                          # by day
+  claude-stats cache --by project             # which projects waste the most
+  claude-stats cache --by session --top 10    # the 10 most wasteful sessions
+  claude-stats cache --by model --format csv -o cache.csv`,
+	"cmd.latency.short": "Analyze response latency, tool-loop duration and human idle time",
+	"cmd.latency.long": `Rebuild each session's message tree from the uuid/parentUuid of every record and compute:
+• Response latency: from the user prompt to the first assistant reply
+• Tool loop: from the first assistant reply to the last assistant message of the turn (turns that used tools only)
+• Idle time: from the assistant's reply to the user's next prompt
+
+Percentiles are shown per model or per Claude Code version, so you can see whether a model or client upgrade got slower.
+
+Examples:
+  claude-stats latency                     # by model
+  claude-stats latency --by version        # by client version
+  claude-stats latency --sort p90          # sort by response latency P90
+  claude-stats latency --format csv -o latency.csv`,
+	"cmd.heatmap.short": "Show a weekday × hour usage heatmap",
+	"cmd.heatmap.long": `Aggregate usage into a 7×24 grid of weekdays (rows) by hours (columns) in the local time zone; brighter cells mean more usage.
+
+Use it to plan heavy Opus work around block resets, or to spot off-hours automation that burns quota.
+
+Examples:
+  claude-stats heatmap                          # tokens
+  claude-stats heatmap --metric cost            # cost
+  claude-stats heatmap --metric messages --since 20250801
+  claude-stats heatmap --format csv -o heatmap.csv`,
+	"cmd.compare.short": "Compare usage between two periods",
+	"cmd.compare.long": `Compare tokens, cost, sessions and messages between two periods (A is the baseline, B the comparison) and list the models and projects that drove the cost change.
+
+Dates use YYYYMMDD and ranges include both ends.
+
+Examples:
+  claude-stats compare --week                                   # week to date vs same span last week
+  claude-stats compare --month                                  # month to date vs same span last month
+  claude-stats compare --a 20250801:20250807 --b 20250808:20250814
+  claude-stats compare --week --format json`,
+	"cmd.forecast.short": "Forecast month-end (and quarter-end) cost",
+	"cmd.forecast.long": `Forecast month-end spend from daily cost history, optionally quarter-end as well.
+
+The forecast reports a linear trend, a weekday average, and their combination (trend × weekday factor), with a 90% confidence band estimated from historical residuals.
+When budgets are configured it reports whether the projection crosses them and on which date.
+
+Budgets can be set in the config file:
+  budgets:
+    monthly: 200
+    quarterly: 500
+
+Examples:
+  claude-stats forecast                      # month-end forecast
+  claude-stats forecast --quarter            # also forecast quarter-end
+  claude-stats forecast --budget 150         # monthly budget
+  claude-stats forecast --history 28 --format json`,
+	"cmd.anomalies.short": "Flag days, sessions and billing blocks with unusual usage",
+	"cmd.anomalies.long": `Flag days, sessions and 5-hour billing blocks whose tokens or cost are far outside the rolling baseline, such as a runaway agent loop.
+
+The baseline is the median of the same kind of item (only items with usage) over the previous --window days,
+and the score is the robust z-score 0.6745 × (observed − median) / MAD; items at or above --threshold are reported. Only spikes above the baseline are reported.
+Each finding shows the observed value, the baseline and the top contributing session and model.
+
+With --check the command exits with status 2 when anomalies are found (1 means a runtime error), for use in cron jobs or CI.
+
+Examples:
+  claude-stats anomalies                            # by cost
+  claude-stats anomalies --metric tokens --threshold 5
+  claude-stats anomalies --kind session,block --window 30
+  claude-stats anomalies --check --last 24h          # only the last 24 hours`,
+	"cmd.export.short": "Export normalized usage records one per line",
+	"cmd.export.long": `Write every log entry that carries usage as one JSON line (NDJSON) with timestamp, session, project path, git branch, model, all token kinds, computed cost, price table version, sidechain flag and requestId.
+
+The export streams line by line and never loads all logs into memory, so it can feed jq, DuckDB or a data warehouse directly.
+
+--format parquet writes a typed columnar file (timestamps, int64 tokens, float64 cost, dictionary-encoded model and project columns); --rollup daily aggregates by date, project, model and sidechain flag instead.
+
+Examples:
+  claude-stats export > usage.ndjson
+  claude-stats export --since 20250801 -o august.ndjson
+  claude-stats export --project my-app | jq -s 'map(.cost_usd) | add'
+  claude-stats export --format parquet -o usage.parquet
+  claude-stats export --format parquet --rollup daily -o daily.parquet`,
+	"cmd.sync.short": "Incrementally sync logs into a local SQLite database",
+	"cmd.sync.long": `Read the JSONL logs into a local SQLite database (default ~/.claude-stats.db; override with --db or the database config key).
+
+Each file is read only from where the previous sync stopped, so repeated runs are fast; truncated or rewritten files are re-imported. The database has these tables:
+  entries   per-entry usage (same fields as export, plus date and block_start)
+  sessions  per-session totals
+  projects  per-project totals (name and group from the configured project rules)
+  blocks    5-hour billing block totals
+  files     sync position of each log file
+
+Examples:
+  claude-stats sync
+  claude-stats sync ~/.claude --db ./usage.db`,
+	"cmd.query.short": "Run read-only SQL against the local SQLite database",
+	"cmd.query.long": `Run SQL against the database built by sync and print the result as a table, CSV, JSON or Markdown. The database is opened read-only, so any write statement fails.
+
+Examples:
+  claude-stats query "SELECT model, SUM(cost_usd) AS cost FROM entries GROUP BY model"
+  claude-stats query "SELECT date, SUM(cost_usd) FROM entries WHERE is_sidechain GROUP BY date" -f csv
+  claude-stats query "SELECT * FROM blocks ORDER BY cost_usd DESC LIMIT 5"`,
+	"cmd.archive.short": "Persist usage records into a long-term archive",
+	"cmd.archive.long": `Claude Code deletes transcripts older than its retention period (30 days by default), which leaves quarterly or yearly reports incomplete. archive saves the usage records found in the current logs (same fields as export) plus daily rollups into a local archive directory (default ~/.claude-stats-archive; override with --archive-dir or the archive_dir config key), one gzip-compressed NDJSON file per month.
+
+Repeated runs only append new records (deduplicated on requestId). When an archive exists, every report command transparently merges the archived records that are no longer in the live logs; use --no-archive to report on live logs only. The archive holds usage data only, so tool, cache efficiency and latency reports still cover live logs only.
+
+Run it regularly, e.g. from a weekly cron job:
+  claude-stats archive
+  claude-stats daily --since 20250101`,
+	"cmd.import.short": "Import team members' usage snapshots",
+	"cmd.import.long": `Import snapshots that other team members produced with export --write-snapshot into imports/<user label>/ under the archive directory. Every report command then merges this data per user (skip it with --no-archive).
+
+Records are deduplicated by requestId, so importing the same snapshot twice, or a snapshot that overlaps your own logs, never double counts.
+
+Examples:
+  claude-stats import alice.json bob.json
+  claude-stats users --since 20250101`,
+	"cmd.users.short": "Usage per team member (user label)",
+	"cmd.users.long": `Summarise sessions, projects, tokens and cost per user label. Local logs are labelled with the user config key or the system user name; other members' data comes from snapshots added with import or passed with --snapshot.
+
+Examples:
+  claude-stats export --write-snapshot --label alice -o alice.json
+  claude-stats users --snapshot alice.json,bob.json
+  claude-stats daily --user bob`,
+	"cmd.import_ccusage.short": "Import ccusage JSON reports, or verify them against our numbers",
+	"cmd.import_ccusage.long": `Read the --json output of ccusage daily, monthly, session or blocks (including the per-project output of daily --instances).
+
+By default the report is converted into usage records and stored in the archive (or under imports/<label>/ with --label, as that member's data), so history whose logs were already cleaned up can be migrated. Each model in each period becomes one record. Periods that overlap data already in the local logs or archive are skipped, and importing the same file again never double counts. Costs of converted records are recalculated with our price table.
+
+--verify writes nothing. It compares ccusage's tokens and cost for every period with what we compute for the same period, and marks periods whose difference exceeds --tolerance (percent) as mismatches; it then exits with status 2, so it can be used as a regression check. ccusage splits days and months in its own timezone (the local one by default); we read the dates in the report in --timezone, which also defaults to the local timezone. If you passed --timezone to ccusage, pass the same value here.
+
+Examples:
+  ccusage daily --json > ccusage-daily.json
+  claude-stats import-ccusage --verify ccusage-daily.json
+  claude-stats import-ccusage --verify --timezone UTC ccusage-utc.json
+  claude-stats import-ccusage ccusage-daily.json
+  claude-stats import-ccusage --label bob bob-ccusage.json`,
+	"cmd.schema.short": "Print JSON Schema documents for the JSON outputs",
+	"cmd.schema.long": `Generate JSON Schema (draft 2020-12) documents from the report models. Every --format json output carries schema_version (the format version) and generator (the program version), and any change to a report's structure bumps schema_version.
+
+Without arguments, lists the available report names. --check compares the current schemas with the golden files built into the program and exits with status 2 when a report changed without a schema_version bump, so it can run in CI.
+
+Examples:
+  claude-stats schema
+  claude-stats schema daily -o daily.schema.json
+  claude-stats schema --write schemas/
+  claude-stats schema --check`,
+	"cmd.chargeback.short": "Allocate monthly cost to cost centers",
+	"cmd.chargeback.long": `Allocate each project's monthly cost to teams or cost centers using the chargeback rules from the config file. Rules match the project path (glob match or regex) or the git remote (remote or remote_regex); the first matching rule in config order wins. center assigns all cost to one cost center, split divides it by percentage across several; anything below 100% and projects without a matching rule count as unallocated.
+
+The report lists allocated cost per cost center per month, the unallocated remainder and how often each rule matched. CSV output contains line items in a fixed column order for import into finance systems.
+
+Examples:
+  claude-stats chargeback --since 20250701
+  claude-stats chargeback -f csv -o chargeback-2025-07.csv --since 20250701 --until 20250731`,
+
+	// 标志位说明
+	"flag.config":                  "config file (default: $HOME/.claude-stats.yaml)",
+	"flag.verbose":                 "verbose output",
+	"flag.lang":                    "interface language (zh, en); defaults to config key lang or LANG/LC_ALL",
+	"flag.archive_dir":             "long-term archive directory (default: archive_dir config key or ~/.claude-stats-archive)",
+	"flag.no_archive":              "do not merge archived data; report on live logs only",
+	"flag.snapshot":                "team snapshot files to merge into the report (comma-separated)",
+	"flag.user":                    "only include data for this user label",
+	"flag.format_analyze":          "output format (table, json, csv, html, markdown, template)",
+	"flag.format_anomalies":        "output format (table, json, csv, markdown)",
+	"flag.format_blocks":           "output format (table, json, html, markdown, template)",
+	"flag.format_branches":         "output format (table, json, csv, markdown)",
+	"flag.format_cache":            "output format (table, json, csv, markdown)",
+	"flag.format_chargeback":       "output format (table, json, csv, markdown)",
+	"flag.format_compare":          "output format (table, json, csv, markdown)",
+	"flag.format_daily":            "output format (table, json, csv, html, markdown, template)",
+	"flag.format_forecast":         "output format (table, json, markdown)",
+	"flag.format_heatmap":          "output format (table, json, csv, markdown)",
+	"flag.format_import_ccusage":   "output format (table, json, csv, markdown)",
+	"flag.format_latency":          "output format (table, json, csv, markdown)",
+	"flag.format_projects":         "output format (table, json, csv, html, markdown)",
+	"flag.format_query":            "output format (table, json, csv, markdown)",
+	"flag.format_tools":            "output format (table, json, csv, markdown)",
+	"flag.format_users":            "output format (table, json, csv, markdown)",
+	"flag.output":                  "output file path",
+	"flag.since":                   "start date (YYYYMMDD)",
+	"flag.until":                   "end date (YYYYMMDD)",
+	"flag.daily_breakdown":         "show per-model breakdown for each day",
+	"flag.daily_order":             "sort order: desc (newest first) or asc (oldest first)",
+	"flag.no_color":                "disable colored output",
+	"flag.offline":                 "offline mode",
+	"flag.offline_pricing":         "offline mode, use cached pricing data",
+	"flag.mode":                    "cost calculation mode (auto, calculate, display)",
+	"flag.version":                 "show version information",
+	"flag.model":                   "filter by model",
+	"flag.details":                 "show details",
+	"flag.config_dirs":             "Claude config directories, comma separated",
+	"flag.breakdown":               "show per-model cost breakdown",
+	"flag.order":                   "sort order (asc, desc)",
+	"flag.blocks_live":             "live monitoring mode",
+	"flag.blocks_token_limit":      "token limit (number or 'max')",
+	"flag.blocks_refresh_interval": "live mode refresh interval (seconds)",
+	"flag.blocks_active":           "only show the active block",
+	"flag.blocks_recent":           "show recent blocks",
+	"flag.project":                 "only include matching projects (path, prefix, wildcard, alias, group or directory name)",
+	"flag.sidechain":               "How to treat subagent (sidechain) records: include (default), exclude (main thread only), only (subagents only)",
+	"flag.redact":                  "Redaction: none (default), paths (replace home directories and user names in paths with ~), hash (replace project paths, session IDs, branches and hostnames with hashes)",
+	"flag.chart":                   "Append charts to table output (bars, token mix and sparklines), sized to the terminal width",
+	"flag.template":                "template file for --format template, or the name of a bundled template (daily-summary, daily-prometheus, blocks-statusline, analyze-models)",
+	"flag.template_string":         "inline template for --format template (Go text/template syntax)",
+	"flag.projects_group":          "aggregate projects by configured group",
+	"flag.projects_sort":           "sort field (cost, tokens, messages, sessions, last, name)",
+	"flag.branch":                  "only show matching branches (wildcards supported)",
+	"flag.branches_commits":        "link commits made during sessions using local git log",
+	"flag.branches_repo":           "local repository used to link commits (defaults to the project's repository; implies --commits)",
+	"flag.branches_sort":           "sort field (cost, tokens, messages, sessions, commits, last, name)",
+	"flag.tools_sort":              "sort field (cost, calls, errors, error_rate, tokens, name)",
+	"flag.tools_top":               "number of projects shown per tool",
+	"flag.cache_by":                "group by (day, project, session, model)",
+	"flag.cache_sort":              "sort field (wasted, wasted_1h, savings, hit_ratio, tokens, name); defaults to date or wasted dollars",
+	"flag.cache_top":               "show only the first N rows (0 for all)",
+	"flag.latency_by":              "group by (model, version)",
+	"flag.latency_sort":            "sort field (name, p50, p90, p99, turns)",
+	"flag.heatmap_metric":          "metric (tokens, cost, messages)",
+	"flag.compare_a":               "Baseline period (YYYYMMDD:YYYYMMDD)",
+	"flag.compare_b":               "Comparison period (YYYYMMDD:YYYYMMDD)",
+	"flag.compare_week":            "Compare week to date with the same span last week",
+	"flag.compare_month":           "Compare month to date with the same span last month",
+	"flag.compare_top":             "Show the top N models and projects by change",
+	"flag.forecast_history":        "Days of history used to fit the forecast",
+	"flag.forecast_quarter":        "Also forecast quarter-end spend",
+	"flag.forecast_budget":         "Monthly budget in USD (overrides budgets.monthly in the config file)",
+	"flag.forecast_quarter_budget": "Quarterly budget in USD (overrides budgets.quarterly in the config file)",
+	"flag.anomalies_metric":        "Metric to check (cost, tokens)",
+	"flag.anomalies_threshold":     "Robust z-score threshold; lower is more sensitive",
+	"flag.anomalies_window":        "Rolling baseline window in days",
+	"flag.anomalies_kind":          "Kinds to check (day, session, block), comma-separated",
+	"flag.anomalies_last":          "Only report anomalies within this recent period, e.g. 24h or 7d",
+	"flag.anomalies_check":         "Check mode: exit with status 2 when anomalies are found",
+	"flag.export_format":           "Export format (ndjson, parquet)",
+	"flag.export_rollup":           "Export daily rollups instead of per-entry records (daily)",
+	"flag.export_snapshot":         "write a portable team snapshot (JSON with user label and hostname)",
+	"flag.export_label":            "user label stored in the snapshot (default: user config key or system user name)",
+	"flag.ccusage_verify":          "compare the ccusage report with our numbers instead of importing it",
+	"flag.ccusage_label":           "import as this user label under imports/<label>/ (default: the local archive)",
+	"flag.ccusage_tolerance":       "relative token and cost difference allowed by --verify, in percent",
+	"flag.ccusage_timezone":        "timezone ccusage used when it generated the report (IANA name such as UTC or Europe/Berlin); defaults to the local timezone",
+	"flag.schema_write":            "write the schemas of all reports into this directory (<name>.schema.json)",
+	"flag.schema_check":            "compare with the built-in golden files; exit with status 2 when a schema changed without a schema_version bump",
+	"flag.plan":                    "subscription plan (pro, max5x, max20x), overrides plan in the config file; estimated from history when unset",
+	"flag.db":                      "SQLite database path (default: database config key or ~/.claude-stats.db)",
+
+	// 通用消息
+	"main.error":              "Error: %v",
+	"root.using_config":       "Using config file:",
+	"root.unknown_lang":       "⚠️  Unsupported language %q, available: %s",
+	"root.invalid_projects":   "⚠️  Invalid projects rules in config file, ignored: %v",
+	"common.analyzing_dirs":   "🔍 Analyzing directories: %s",
+	"common.processing_dir":   "📂 Processing directory: %s",
+	"common.dir_missing":      "⚠️  Directory not found, skipping: %s",
+	"common.dir_failed":       "⚠️  Failed to process directory, skipping %s: %v",
+	"common.parse_dir_failed": "⚠️  Failed to parse directory, skipping %s: %v",
+	"common.saved":            "✅ Report saved to: %s",
+	"sync.done":               "✅ Synced %d new entries from %d updated files (%d scanned) into %s",
+	"sync.reset":              "   %d truncated or rewritten files were re-imported",
+	"sync.totals":             "   Database now holds %d entries, %d sessions, %d projects, %d blocks",
+	"archive.done":            "📦 Archived %d new entries into %s",
+	"archive.totals":          "   Archive now holds %d entries across %d months",
+	"archive.merged":          "📦 Merged %d records from archive %s that are no longer in the live logs",
+	"import.done":             "📥 %s (user %s, host %s): %d new records, %d already present",
+	"import.location":         "   Imported data is stored under %s/imports",
+	"import.merged":           "👥 Merged %d imported records from %s",
+	"snapshot.merged":         "👥 Merged %d snapshot records from %s",
+	"common.total":            "Total",
+	"common.none":             "None",
+	"common.unknown":          "Unknown",
+
+	// 错误消息
+	"err.date_format":              "invalid date: %w",
+	"err.start_date":               "failed to parse start date: %w",
+	"err.end_date":                 "failed to parse end date: %w",
+	"err.parse_date":               "cannot parse date: %s, supported formats: YYYYMMDD, YYYY-MM-DD, YYYY/MM/DD",
+	"err.unsupported_format":       "unsupported format: %s",
+	"err.format_failed":            "formatting failed: %w",
+	"err.write_failed":             "failed to write file: %w",
+	"err.no_valid_dirs":            "no valid Claude config directory found",
+	"err.daily_analyze":            "daily analysis failed: %w",
+	"err.blocks_analyze":           "block analysis failed: %w",
+	"err.unsupported_sort":         "unsupported sort field: %s",
+	"err.unsupported_sidechain":    "unsupported --sidechain value: %s (expected include, exclude or only)",
+	"err.unsupported_cache_by":     "unsupported grouping: %s (expected day, project, session or model)",
+	"err.unsupported_latency_by":   "unsupported grouping: %s (expected model or version)",
+	"err.unsupported_metric":       "unsupported metric: %s (expected tokens, cost or messages)",
+	"err.compare_periods":          "specify --week, --month, or both --a and --b",
+	"err.compare_range":            "invalid date range: %s (expected YYYYMMDD:YYYYMMDD)",
+	"err.forecast_history":         "history must be at least one day: %d",
+	"err.unsupported_anomaly_kind": "unsupported kind: %s (choose from day, session, block)",
+	"err.anomalies_threshold":      "threshold must be greater than 0: %g",
+	"err.anomalies_window":         "window must be at least one day: %d",
+	"err.anomalies_last":           "invalid period: %s (e.g. 24h or 7d)",
+	"err.unsupported_rollup":       "unsupported rollup: %s (supported: daily)",
+	"err.unsupported_redact":       "unsupported redaction mode: %s (supported: none, paths, hash)",
+	"err.db_open":                  "failed to open database %s: %v",
+	"err.db_missing":               "database %s does not exist; run claude-stats sync first",
+	"err.sync_failed":              "sync failed: %v",
+	"err.query_failed":             "query failed: %v",
+	"err.query_multiple":           "only one SQL statement can be run at a time",
+	"err.query_not_select":         "only read-only queries (SELECT, WITH, VALUES) are allowed, not %s",
+	"err.archive_failed":           "archive failed: %v",
+	"err.archive_read":             "failed to read archive %s: %v",
+	"err.snapshot_read":            "failed to read snapshot %s: %v",
+	"err.snapshot_type":            "not a snapshot file (type %q)",
+	"err.snapshot_version":         "unsupported snapshot version: %d",
+	"err.snapshot_user":            "snapshot has no user label",
+	"err.snapshot_flags":           "--write-snapshot cannot be combined with --format or --rollup",
+	"err.chargeback_config":        "invalid chargeback rules: %v",
+	"err.chargeback_match":         "rule %s needs a match condition (match, regex, remote or remote_regex)",
+	"err.chargeback_target":        "rule %s must set exactly one of center or split",
+	"err.chargeback_share":         "rule %s: each split entry needs a cost center and a percentage above 0",
+	"err.chargeback_split_total":   "rule %s: split percentages add up to %.2f%%, which exceeds 100%%",
+	"err.chargeback_regex":         "rule %s has an invalid regex: %v",
+	"err.ccusage_read":             "failed to read ccusage report %s: %v",
+	"err.ccusage_format":           "unrecognised ccusage JSON: expected the --json output of ccusage daily, monthly, session or blocks",
+	"err.unknown_timezone":         "unknown timezone %q: %v",
+	"err.ccusage_row":              "invalid time in ccusage %s report: %v",
+	"err.ccusage_verify_args":      "--verify compares one file at a time",
+	"err.schema_unknown":           "unknown report %s (available: %s)",
+	"err.schema_type":              "cannot generate a schema for type %s",
+	"err.schema_untagged":          "%s.%s has no json tag",
+	"err.unknown_plan":             "unknown subscription plan: %s (expected pro, max5x or max20x)",
+	"err.plans_config":             "invalid plan budgets: %v",
+	"err.template_required":        "--format template needs --template or --template-string (bundled templates: %s)",
+	"err.template_conflict":        "--template and --template-string cannot be used together",
+	"err.template_not_found":       "template file %s not found and no bundled template has that name (bundled templates: %s)",
+
+	// daily / analyze / blocks 命令运行时消息
+	"daily.start":                "📅 Starting daily analysis: %s",
+	"daily.done":                 "✅ Analysis complete: %d days of data",
+	"analyze.multi_dirs":         "💡 Aggregating data from %d config directories",
+	"analyze.dir_done":           "✅ Finished directory: %s (sessions: %d, messages: %d)",
+	"analyze.aggregated":         "📊 Aggregation complete: %d directories processed",
+	"blocks.live_start":          "🔴 Live monitoring started (refresh interval: %ds)",
+	"blocks.live_exit_hint":      "💡 Press Ctrl+C to exit",
+	"blocks.live_time":           "🕐 Monitoring time: %s",
+	"blocks.token_limit":         "⚡ Token limit: %s",
+	"blocks.live_parse_failed":   "❌ Parsing failed: %v",
+	"blocks.live_analyze_failed": "❌ Analysis failed: %v",
+	"blocks.live_format_failed":  "❌ Formatting failed: %v",
+	"blocks.limit_critical":      "🚨 Warning: current block token usage %.1f%% (%.0f/%d)",
+	"blocks.limit_warning":       "⚠️  Caution: current block token usage %.1f%% (%.0f/%d)",
+	"blocks.limit_notice":        "💡 Note: current block token usage %.1f%% (%.0f/%d)",
+	"branches.repo_failed":       "⚠️  Cannot find the Git repository for %s: %v",
+	"chargeback.remote_failed":   "⚠️  Cannot read the git remote for %s: %v",
+	"ccusage.imported":           "📥 %s (ccusage %s): imported %d periods (%d new records), skipped %d periods already covered by existing data",
+	"ccusage.location":           "   Data is stored in the archive %s",
+	"schema.list_title":          "Available reports (claude-stats schema <report>), schema_version %d:",
+	"schema.written":             "Wrote %d schemas to %s",
+	"schema.check_ok":            "✅ %d schemas match the golden files (schema_version %d)",
+	"schema.check_changed":       "❌ %s: the structure changed but schema_version is still %d; bump models.SchemaVersion and run go generate ./pkg/schema",
+	"schema.check_outdated":      "❌ %s: the golden file is for schema_version %d; regenerate it with go generate ./pkg/schema",
+	"schema.check_missing":       "❌ %s: no golden file; run go generate ./pkg/schema",
+	"branches.log_failed":        "⚠️  Failed to read commits of branch %s: %v",
+
+	// 解析器消息
+	"parser.processing_file":       "📂 Processing file: %s",
+	"parser.skip_file":             "⚠️  Skipping file %s: %v",
+	"parser.err_parse_file":        "failed to parse file %s: %w",
+	"parser.err_open_file":         "failed to open file: %w",
+	"parser.line_error":            "⚠️  Line %d parse error: %v",
+	"parser.err_parse_line":        "failed to parse line %d: %w",
+	"parser.err_read_file":         "failed to read file: %w",
+	"parser.err_json":              "invalid JSON: %w",
+	"parser.err_timestamp":         "cannot parse timestamp: %s",
+	"parser.debug_record":          "🔍 Debug - record #%d:",
+	"parser.debug_content_preview": "  Content preview: %s...",
+	"cost.api_cheaper":             "API mode is cheaper",
+
+	// 表格列标题
+	"col.date":                  "Date",
+	"col.model":                 "Model",
+	"col.input_tokens":          "Input Tokens",
+	"col.output_tokens":         "Output Tokens",
+	"col.cache_creation":        "Cache Create",
+	"col.cache_read":            "Cache Read",
+	"col.cache_creation_tokens": "Cache Create Tokens",
+	"col.cache_read_tokens":     "Cache Read Tokens",
+	"col.total_tokens":          "Total Tokens",
+	"col.cost_usd":              "Cost (USD)",
+	"col.equivalent_cost":       "Equivalent Cost (USD)",
+	"col.messages":              "Messages",
+	"col.sessions":              "Sessions",
+	"col.block_start":           "Block Start",
+	"col.status":                "Status",
+	"col.type":                  "Type",
+	"col.amount":                "Amount",
+	"col.percentage":            "Percent",
+	"col.description":           "Description",
+	"col.input":                 "Input",
+	"col.output":                "Output",
+	"col.cache":                 "Cache",
+	"col.total":                 "Total",
+	"col.project":               "Project",
+	"col.path":                  "Path",
+	"col.tokens":                "Tokens",
+	"col.sidechain_tokens":      "Subagent tokens",
+	"col.sidechain_cost":        "Subagent cost",
+	"col.last_activity":         "Last Activity",
+	"col.first_activity":        "First Activity",
+	"col.trend":                 "Trend",
+	"col.branch":                "Branch",
+	"col.commits":               "Commits",
+	"col.tool":                  "Tool",
+	"col.calls":                 "Calls",
+	"col.errors":                "Errors",
+	"col.error_rate":            "Error Rate",
+	"col.top_projects":          "Top Projects",
+	"col.user":                  "User",
+	"col.hosts":                 "Hosts",
+	"col.projects":              "Projects",
+	"col.month":                 "Month",
+	"col.cost_center":           "Cost Center",
+	"col.rule":                  "Rule",
+	"col.ccusage_tokens":        "ccusage Tokens",
+	"col.ccusage_cost":          "ccusage Cost",
+	"col.cost_delta":            "Cost Delta",
+	"col.hit_ratio":             "Hit Ratio",
+	"col.cache_savings":         "Saved",
+	"col.cache_net_savings":     "Net Saved",
+	"col.wasted_5m":             "Wasted (5m)",
+	"col.wasted_1h":             "Wasted (1h)",
+	"col.version":               "Version",
+	"col.turns":                 "Turns",
+	"col.response_p50":          "Response P50",
+	"col.response_p90":          "Response P90",
+	"col.response_p99":          "Response P99",
+	"col.tool_loop_p50":         "Tool Loop P50",
+	"col.tool_loop_p90":         "Tool Loop P90",
+	"col.idle_p50":              "Idle P50",
+	"col.idle_p90":              "Idle P90",
+	"col.metric":                "Metric",
+	"col.delta":                 "Delta",
+	"col.change":                "Change",
+	"col.cost_a":                "Cost A",
+	"col.cost_b":                "Cost B",
+	"col.tokens_delta":          "Token Delta",
+	"col.share_of_change":       "Share of Change",
+	"col.period":                "Period",
+	"col.date_range":            "Date Range",
+	"col.actual_to_date":        "Actual to Date",
+	"col.linear":                "Linear",
+	"col.weekday_avg":           "Weekday Avg",
+	"col.forecast":              "Forecast",
+	"col.forecast_range":        "%.0f%% Range",
+	"col.budget":                "Budget",
+	"col.kind":                  "Kind",
+	"col.target":                "Item",
+	"col.observed":              "Observed",
+	"col.baseline":              "Baseline",
+	"col.ratio":                 "Ratio",
+	"col.score":                 "Score",
+	"col.top_session":           "Top Session",
+	"col.top_model":             "Top Model",
+	"col.session_id":            "Session ID",
+	"col.start_time":            "Start Time",
+
+	// 报告内容
+	"fmt.blocks.title":     "5-Hour Billing Blocks",
+	"fmt.blocks.empty":     "No block activity",
+	"fmt.blocks.active":    "Active (%s)",
+	"fmt.blocks.burn_rate": "Rate: %s/min",
+	"fmt.blocks.projected": "Projected: %s",
+	"fmt.blocks.completed": "Completed",
+
+	"fmt.daily.title":               "Daily Usage",
+	"fmt.daily.empty":               "No daily data",
+	"fmt.daily.cost_notice":         "This is a local usage analysis tool; costs are equivalent estimates based on your local token usage and public Claude API prices.",
+	"fmt.daily.subscription_notice": "For subscribers (Pro/Max) the actual bill is a fixed monthly fee; these estimates show usage value, not the amount you owe.",
+
+	"fmt.header.author":   "Author: zhuiye",
+	"fmt.header.subtitle": "Claude Code usage statistics  •  Generated at: %s",
+
+	"fmt.basic.title":          "Overview",
+	"fmt.basic.mode":           "Detected mode",
+	"fmt.basic.total_sessions": "Total sessions",
+	"fmt.basic.total_messages": "Total messages",
+	"fmt.basic.parsed":         "Parsed",
+	"fmt.basic.extracted":      "Tokens extracted",
+	"fmt.basic.sidechain":      "Subagent usage",
+	"fmt.basic.time_range":     "%s to %s",
+	"fmt.basic.period":         "Period",
+	"fmt.basic.duration":       "Duration",
+	"fmt.basic.message_types":  "Message types",
+
+	"fmt.tokens.title":               "Token Usage",
+	"fmt.tokens.base_hint":           "Percentage base: base tokens (%s) = input tokens + output tokens",
+	"fmt.tokens.input_desc":          "Prompt cost",
+	"fmt.tokens.output_desc":         "Response cost",
+	"fmt.tokens.cache_creation_desc": "Context cache",
+	"fmt.tokens.cache_read_desc":     "Cache hits",
+	"fmt.tokens.excluded":            "not in %",
+	"fmt.tokens.empty":               "No token data",
+	"fmt.tokens.check_jsonl":         "Check the JSONL format",
+	"fmt.tokens.base_total":          "Base token total",
+	"fmt.tokens.real_cost":           "Actual usage cost",
+
+	"fmt.models.title":                   "Usage by Model",
+	"fmt.projects.title":                 "Projects",
+	"fmt.projects.empty":                 "No project data",
+	"fmt.users.title":                    "Users",
+	"fmt.users.empty":                    "No user data",
+	"fmt.chargeback.title":               "Chargeback",
+	"fmt.chargeback.empty":               "No cost data",
+	"fmt.chargeback.no_rules":            "No chargeback rules in the config file; all cost is unallocated",
+	"fmt.chargeback.price_hint":          "Costs use price table %s",
+	"fmt.chargeback.unallocated":         "Unallocated",
+	"fmt.chargeback.allocated_label":     "Allocated",
+	"fmt.chargeback.summary":             "Allocated %s (%s), unallocated %s",
+	"fmt.chargeback.rules_title":         "Rule hits",
+	"fmt.ccusage.title":                  "ccusage Cross-check (%s)",
+	"fmt.ccusage.empty":                  "The ccusage report has no data",
+	"fmt.ccusage.ok":                     "OK",
+	"fmt.ccusage.mismatch":               "Mismatch",
+	"fmt.ccusage.summary":                "%d periods compared, %d exceed the %.2f%% tolerance",
+	"fmt.ccusage.price_hint":             "Our costs use price table %s",
+	"fmt.projects.trend_hint":            "The trend column shows daily tokens for the last %d days",
+	"fmt.projects.paths_count":           "%d paths",
+	"fmt.branches.title":                 "Branches",
+	"fmt.branches.empty":                 "No branch data",
+	"fmt.branches.no_branch":             "(no branch)",
+	"fmt.tools.title":                    "Tool Usage",
+	"fmt.tools.empty":                    "No tool call data",
+	"fmt.tools.cost_hint":                "Tokens and cost come from the assistant messages that issued the calls, split evenly when several tools share a message",
+	"fmt.cache.title":                    "Prompt Cache Efficiency",
+	"fmt.cache.empty":                    "No cache usage found",
+	"fmt.cache.savings_hint":             "Saved = what cache reads saved versus the full input price; Net Saved also subtracts the cache write premium",
+	"fmt.cache.waste_hint":               "Wasted writes are estimates: cache writes not read back later in the same session within the TTL; the percentage is the share of all cache writes",
+	"fmt.latency.title":                  "Response Latency",
+	"fmt.latency.empty":                  "No records with a message tree found (uuid/parentUuid required)",
+	"fmt.latency.hint":                   "Response latency is attributed to the model of the first reply and the client version at prompt time; idle time measures the human, not the model",
+	"fmt.heatmap.title":                  "Usage Heatmap (%s)",
+	"fmt.heatmap.metric_tokens":          "tokens",
+	"fmt.heatmap.metric_cost":            "cost",
+	"fmt.heatmap.metric_messages":        "messages",
+	"fmt.heatmap.empty":                  "No usage in the selected range",
+	"fmt.heatmap.timezone_hint":          "Time zone: %s; rows are weekdays, columns are hours",
+	"fmt.heatmap.weekdays":               "Mon,Tue,Wed,Thu,Fri,Sat,Sun",
+	"fmt.heatmap.legend":                 "Legend: %s  (max %s)",
+	"fmt.compare.title":                  "Period Comparison",
+	"fmt.compare.days":                   "%d days",
+	"fmt.compare.by_model":               "By model (largest cost change)",
+	"fmt.compare.by_project":             "By project (largest cost change)",
+	"fmt.compare.more":                   "%d more not shown; adjust with --top",
+	"fmt.compare.new":                    "new",
+	"fmt.forecast.title":                 "Cost Forecast (as of %s)",
+	"fmt.forecast.empty":                 "No recent cost data to forecast from",
+	"fmt.forecast.model_hint":            "Based on the last %d days: linear trend × weekday factor, %.0f%% confidence band; today counts as the larger of actual and forecast",
+	"fmt.forecast.trend_hint":            "Trend: %s USD/day",
+	"fmt.forecast.target_month":          "Month-end",
+	"fmt.forecast.target_quarter":        "Quarter-end",
+	"fmt.forecast.status_none":           "no budget",
+	"fmt.forecast.status_under":          "within budget",
+	"fmt.forecast.status_at_risk":        "at risk",
+	"fmt.forecast.status_projected_over": "projected over",
+	"fmt.forecast.status_over":           "over budget",
+	"fmt.forecast.crosses":               "%s (%s)",
+	"fmt.forecast.chart_title":           "Daily cost",
+	"fmt.forecast.chart_legend":          "█ actual  ░ forecast  · upper band",
+	"fmt.anomalies.title":                "Usage Anomalies (%s)",
+	"fmt.anomalies.baseline_hint":        "Baseline: median of the same kind over the previous %d days; threshold: robust z-score ≥ %g",
+	"fmt.anomalies.checked_hint":         "Checked %d days, %d sessions and %d billing blocks",
+	"fmt.anomalies.none":                 "No anomalies found",
+	"fmt.anomalies.kind_day":             "Day",
+	"fmt.anomalies.kind_session":         "Session",
+	"fmt.anomalies.kind_block":           "Block",
+	"fmt.chart.daily_cost":               "Daily cost",
+	"fmt.chart.token_mix":                "Daily token mix",
+	"fmt.chart.cost_trend":               "Cost trend",
+	"fmt.chart.token_trend":              "Token trend",
+	"fmt.chart.block_tokens":             "Token mix per block (● active)",
+	"fmt.chart.model_tokens":             "Token mix by model",
+	"fmt.chart.project_tokens":           "Token mix by project with the last %d days",
+	"fmt.query.rows":                     "%d rows",
+	"fmt.html.generated":                 "Generated at %s",
+	"fmt.report.title":                   "Claude Code Usage Report",
+	"fmt.html.model_share":               "Cost share by model",
+	"fmt.html.project_cost":              "Cost by project (top %d)",
+	"fmt.html.block_timeline":            "Billing block timeline (height is cost)",
+	"fmt.html.sessions":                  "Sessions",
+	"fmt.report.days":                    "Days",
+	"fmt.report.blocks":                  "Blocks",
+	"fmt.html.other":                     "Other",
+	"fmt.html.sort_hint":                 "Click a column header to sort",
+	"fmt.sessions.title":                 "Sessions (latest 20)",
+
+	"fmt.cost.title":             "Cost Analysis",
+	"fmt.cost.subscription_hint": "(API-equivalent cost estimate for subscription mode)",
+	"fmt.cost.input":             "Input cost:          %s (%.1f%% 📉 low cost)",
+	"fmt.cost.output":            "Output cost:         %s (%.1f%% 📈 main cost)",
+	"fmt.cost.ratio":             "Cost ratio:          output costs %s× input",
+	"fmt.cost.cache_creation":    "Cache write cost:    %s",
+	"fmt.cost.cache_read":        "Cache read cost:     %s",
+	"fmt.cost.total":             "Total cost:          %s",
+	"fmt.cost.savings":           "Savings:             subscription saves %s compared with the API",
+
+	"fmt.quota.title":                "Subscription Quota",
+	"fmt.quota.estimate_warning":     "Note: the plan is estimated from history, so the budgets may be off; set plan in the config file or pass --plan",
+	"fmt.quota.status_hint":          "Run /status in Claude Code for exact usage of the current window",
+	"fmt.quota.plan":                 "Plan",
+	"fmt.quota.per_month":            "$%d/month",
+	"fmt.quota.mechanism":            "Limit mechanism",
+	"fmt.quota.mechanism_desc":       "5-hour windows, the same billing windows as the blocks command",
+	"fmt.quota.source_configured":    "configured",
+	"fmt.quota.source_estimated":     "estimated from history",
+	"fmt.quota.window":               "Current window: %s - %s (resets %s)",
+	"fmt.quota.no_window":            "No active window; the next message starts a new 5-hour window",
+	"fmt.quota.tokens":               "Tokens used: %s / %s (%.1f%%)",
+	"fmt.quota.used":                 "Used in window:  %s / %d prompts",
+	"fmt.quota.progress":             "Progress",
+	"fmt.quota.remaining":            "Remaining: %s (%.1f%% of the window budget left)",
+	"fmt.quota.model":                "Inferred model",
+	"fmt.quota.model_high":           "high-performance model",
+	"fmt.quota.model_standard":       "standard model",
+	"fmt.quota.model_unknown":        "unknown model",
+	"fmt.quota.details":              "Plan estimate based on:",
+	"fmt.quota.detail_user_messages": "User messages in period: %d",
+	"fmt.quota.detail_windows":       "Windows spanned: %d (5 hours each)",
+	"fmt.quota.detail_avg":           "Average per window: %.1f messages",
+	"fmt.quota.detail_hours":         "Period length: %.1f hours",
+	"fmt.quota.accurate_hint":        "For exact information: run /status in Claude Code",
+	"fmt.quota.limit_reached":        "The budget of the current window is used up",
+	"fmt.quota.low_remaining":        "Only %d prompts left in the current window, watch your usage",
+	"fmt.quota.ok_remaining":         "%d more prompts available in the current window",
+
+	"fmt.duration.expired":       "expired",
+	"fmt.duration.hours_minutes": "in %dh %dm",
+	"fmt.duration.minutes":       "in %dm",
+
+	"fmt.suggest.title":            "Subscription Tips",
+	"fmt.suggest.estimate_warning": "These tips are based on estimates; weigh them against your actual usage",
+	"fmt.suggest.tips":             "Efficiency tips:",
+	"fmt.suggest.tip_compact":      "Use %s to compact the context",
+	"fmt.suggest.tip_clear":        "Use %s to reset the conversation",
+	"fmt.suggest.tip_status":       "Use %s to check live limits",
+	"fmt.suggest.high_usage":       "Current window usage is high",
+	"fmt.suggest.high_compact":     "Consider %s to reduce context",
+	"fmt.suggest.high_avoid_long":  "Avoid long uninterrupted conversations",
+	"fmt.suggest.high_upgrade":     "Consider upgrading if you hit limits often",
+	"fmt.suggest.low_usage":        "Plenty of room in the current window",
+	"fmt.suggest.low_plan_fits":    "Your current plan fits your usage",
+	"fmt.suggest.timezone":         "Time zone notes:",
+	"fmt.suggest.timezone_utc":     "Claude Code limits may be based on UTC",
+	"fmt.suggest.timezone_local":   "Reset times may differ from your local time",
+	"fmt.suggest.timezone_status":  "Use %s to confirm the exact time",
+	"fmt.suggest.savings":          "Subscription saves about $%.2f/month compared with the API",
+	"fmt.suggest.fallback_status":  "Use /status in Claude Code for detailed limit information",
+	"fmt.suggest.fallback_docs":    "See the official documentation for plan details",
+
+	"fmt.mode.api":          "API mode (billed per token)",
+	"fmt.mode.subscription": "Subscription mode (request limited)",
+}
@@ -0,0 +1,119 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// 支持的语言
+const (
+	LangZH = "zh"
+	LangEN = "en"
+
+	// DefaultLang 未能识别语言时使用的默认语言
+	DefaultLang = LangZH
+)
+
+// catalogs 各语言的消息目录
+var catalogs = map[string]map[string]string{
+	LangZH: zhMessages,
+	LangEN: enMessages,
+}
+
+// current 当前使用的语言
+var current = DefaultLang
+
+// SetLang 设置当前语言，无法识别时保持不变并返回false
+func SetLang(lang string) bool {
+	normalized := Normalize(lang)
+	if normalized == "" {
+		return false
+	}
+	current = normalized
+	return true
+}
+
+// Lang 返回当前语言
+func Lang() string {
+	return current
+}
+
+// Supported 返回所有支持的语言
+func Supported() []string {
+	return []string{LangZH, LangEN}
+}
+
+// Normalize 将 "en_US.UTF-8"、"zh-CN" 等写法规范化为目录中的语言代码
+// 无法识别时返回空字符串
+func Normalize(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == "" {
+		return ""
+	}
+
+	// 去掉编码和修饰部分，如 en_US.UTF-8@euro
+	if idx := strings.IndexAny(lang, ".@"); idx >= 0 {
+		lang = lang[:idx]
+	}
+	if idx := strings.IndexAny(lang, "_-"); idx >= 0 {
+		lang = lang[:idx]
+	}
+
+	if _, ok := catalogs[lang]; ok {
+		return lang
+	}
+	return ""
+}
+
+// FromEnv 按POSIX优先级从 LC_ALL、LC_MESSAGES、LANG 中检测语言
+// "C"、"POSIX" 等未指定语言的值会被忽略
+func FromEnv() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if lang := Normalize(os.Getenv(name)); lang != "" {
+			return lang
+		}
+	}
+	return ""
+}
+
+// Resolve 返回候选值中第一个可识别的语言，全部无法识别时回退到环境变量和默认语言
+func Resolve(candidates ...string) string {
+	for _, candidate := range candidates {
+		if lang := Normalize(candidate); lang != "" {
+			return lang
+		}
+	}
+	if lang := FromEnv(); lang != "" {
+		return lang
+	}
+	return DefaultLang
+}
+
+// T 返回当前语言下key对应的消息，提供参数时按fmt格式化
+// 当前语言缺失时回退到默认语言，仍缺失则原样返回key
+func T(key string, args ...interface{}) string {
+	msg, ok := catalogs[current][key]
+	if !ok {
+		msg, ok = catalogs[DefaultLang][key]
+	}
+	if !ok {
+		msg = key
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Has 检查key是否存在于消息目录中
+func Has(key string) bool {
+	_, ok := catalogs[DefaultLang][key]
+	return ok
+}
+
+// Errorf 使用本地化的格式字符串创建错误，支持 %w 包装
+func Errorf(key string, args ...interface{}) error {
+	return fmt.Errorf(T(key), args...)
+}
@@ -0,0 +1,822 @@
+package i18n
+
+// zhMessages 中文消息目录（默认语言）
+// 带参数的消息使用fmt格式化动词，无参数的消息原样输出
+var zhMessages = map[string]string{
+	// 命令说明
+	"cmd.root.short": "完美的Claude Code使用统计工具",
+	"cmd.root.long": `claude-stats - 专业的Claude Code使用统计和分析工具
+
+支持功能：
+• 专门化命令架构（daily, monthly, session, blocks）
+• 智能成本计算和趋势分析
+• 多配置目录支持（CLAUDE_CONFIG_DIR环境变量）
+• 实时监控和Token限制预警
+• 跨平台支持（Windows、Mac、Linux、WSL）
+• 多格式导出（JSON、CSV、表格）
+• 多语言界面（--lang zh|en，或配置文件 lang、LANG/LC_ALL 环境变量）
+
+基本命令：
+  claude-stats daily             # 每日使用报告（默认）
+  claude-stats monthly           # 月度使用报告
+  claude-stats session           # 会话分析
+  claude-stats blocks            # 5小时计费窗口分析
+  claude-stats blocks --live     # 实时监控模式
+  claude-stats analyze           # 通用分析（兼容旧版）
+
+快速开始：
+  claude-stats                   # 显示每日使用情况
+  claude-stats --breakdown       # 显示详细的模型分解
+  claude-stats --json            # JSON格式输出
+  claude-stats --lang en         # 英文界面
+
+多配置目录：
+  export CLAUDE_CONFIG_DIR="/path1,/path2"
+  claude-stats daily --breakdown`,
+	"cmd.daily.short": "按日分析Claude Code使用情况",
+	"cmd.daily.long": `按日期分析Claude Code的使用情况，提供精确的每日Token统计和成本分析。
+
+此命令专门优化了按日分组的数据处理逻辑，确保计算精度和性能。
+相比通用分析，daily命令能够：
+• 精确按日期边界分组数据
+• 优化的日期范围处理
+• 专门的日级成本分配算法
+• 更准确的使用模式分析
+
+支持功能：
+• 按日显示Token使用统计
+• 每日成本分析和趋势
+• 模型使用分解（--breakdown）
+• 时间范围过滤（--since, --until）
+• 多种排序方式（--order）
+
+示例：
+  claude-stats daily                           # 显示所有日期的使用情况
+  claude-stats daily --breakdown               # 显示每日的模型使用分解
+  claude-stats daily --since 20241201         # 显示12月1日以来的使用
+  claude-stats daily --since 20241201 --until 20241231  # 显示12月的使用
+  claude-stats daily --order asc              # 按时间正序排列
+  claude-stats daily --json                   # JSON格式输出
+
+日期格式：
+  支持 YYYYMMDD, YYYY-MM-DD, YYYY/MM/DD 等多种格式`,
+	"cmd.blocks.short": "分析5小时计费窗口使用情况",
+	"cmd.blocks.long": `分析Claude Code的5小时计费窗口使用情况，帮助理解使用模式和预测成本。
+
+5小时计费窗口是Claude订阅模式的计费单位，每个窗口内有固定的消息限制。
+此命令可以帮助您：
+• 了解不同时间段的使用强度
+• 识别活跃使用窗口
+• 预测当前窗口的使用趋势
+• 监控实时使用率
+
+支持功能：
+• 自动检测窗口边界（每5小时一个周期）
+• 显示活跃窗口状态和剩余时间
+• 计算燃烧速率和预测使用量
+• 实时监控模式（--live）
+• Token限制预警（--token-limit）
+
+示例：
+  claude-stats blocks                    # 显示基本窗口分析
+  claude-stats blocks --live             # 实时监控当前窗口
+  claude-stats blocks --live -t 500000   # 设置Token限制监控
+  claude-stats blocks --active           # 只显示活跃窗口
+  claude-stats blocks --recent           # 显示最近的窗口
+  claude-stats blocks --json             # JSON格式输出`,
+	"cmd.analyze.short": "分析Claude Code使用统计",
+	"cmd.analyze.long": `分析指定目录中的Claude Code JSONL日志文件，生成详细的使用统计报告。
+
+支持的功能：
+• 自动检测Claude日志目录
+• Token使用统计（输入、输出、缓存）
+• 成本估算（API和订阅模式）
+• 按模型、日期、会话分组统计
+• 多种输出格式（表格、JSON、CSV）
+• 多配置目录支持（通过CLAUDE_CONFIG_DIR环境变量）
+
+示例：
+  claude-stats analyze                    # 分析默认Claude目录
+  claude-stats analyze ~/claude-logs     # 分析指定目录
+  claude-stats analyze --json            # JSON格式输出
+  claude-stats analyze --csv report.csv  # 导出CSV报告
+  claude-stats analyze --breakdown       # 显示模型详细分解
+  claude-stats analyze --plan max5x      # 按 Max5x 的额度计算订阅限额
+  claude-stats analyze --since 20240101 --until 20241231  # 指定日期范围
+
+多配置目录：
+  export CLAUDE_CONFIG_DIR="/path1,/path2"  # 分析多个目录
+  claude-stats analyze --breakdown           # 聚合分析所有配置目录`,
+	"cmd.projects.short": "按项目分析Claude Code使用情况",
+	"cmd.projects.long": `按项目（工作目录的完整路径）汇总Claude Code的使用情况。
+
+不同目录下的同名项目会分别统计，例如 ~/work/api 和 ~/oss/api。
+每个项目显示会话数、消息数、Token、成本、模型分布、首次/最后活动时间和每日趋势。
+
+可以在配置文件中为项目设置别名或分组：
+  projects:
+    - match: ~/work/api        # 完整路径、路径前缀或通配符
+      alias: work-api          # 相同别名的路径会合并为同一项目
+    - match: ~/oss/*
+      group: oss               # 使用 --group 按分组汇总
+
+示例：
+  claude-stats projects                     # 按成本排序显示所有项目
+  claude-stats projects --sort last         # 按最后活动时间排序
+  claude-stats projects --group             # 按分组汇总
+  claude-stats projects --details           # 显示每个项目的模型分解
+  claude-stats projects --project work-api  # 只显示指定项目
+  claude-stats projects --format csv -o projects.csv
+
+--project 过滤条件适用于所有命令，可以是完整路径、路径前缀、通配符、别名、分组名或目录名。`,
+	"cmd.branches.short": "按Git分支分析Claude Code使用情况",
+	"cmd.branches.long": `按项目和Git分支汇总Claude Code的使用情况，回答“开发某个功能花了多少钱”。
+
+Claude Code日志中每条记录都带有 gitBranch 字段，本命令据此将Token和成本归属到项目+分支。
+使用 --commits 时会在本地运行 git log，将分支与会话时间段内（会话结束后30分钟内）产生的提交关联起来。
+
+示例：
+  claude-stats branches                         # 所有项目的分支成本
+  claude-stats branches --branch 'feature/*'    # 只看功能分支
+  claude-stats branches --commits --details     # 关联并列出提交
+  claude-stats branches --repo ~/work/api --project ~/work/api
+  claude-stats branches --format csv -o branches.csv`,
+	"cmd.tools.short": "分析工具调用的次数、错误率和成本",
+	"cmd.tools.long": `统计助手消息中的 tool_use 内容块（Bash、Edit、Read、Grep、Task…）以及用户消息中对应的 tool_result。
+
+每个工具显示：
+• 调用次数和错误次数（tool_result 中 is_error 为 true）
+• 错误率
+• 发起调用的消息的Token和成本（一条消息调用多个工具时平均分摊）
+• 调用最多的项目
+
+示例：
+  claude-stats tools                      # 按成本排序
+  claude-stats tools --sort error_rate    # 找出最容易失败的工具
+  claude-stats tools --top 5              # 每个工具显示前5个项目
+  claude-stats tools --format csv -o tools.csv`,
+	"cmd.cache.short": "分析提示缓存的命中率、节省金额和浪费的缓存写入",
+	"cmd.cache.long": `按日期、项目、会话或模型统计提示缓存的效率。
+
+每一行显示：
+• 缓存命中率：缓存读取占全部输入（输入 + 缓存写入 + 缓存读取）的比例
+• 节省金额：缓存读取相比按输入全价计费少付的金额
+• 净节省：节省金额减去缓存写入比输入全价多付的部分
+• 浪费的写入：同一会话中在5分钟或1小时TTL内没有被后续读取的缓存写入（估算）
+
+示例：
+  claude-stats cache                          # 按日期
+  claude-stats cache --by project             # 哪些项目浪费最多
+  claude-stats cache --by session --top 10    # 浪费最多的10个会话
+  claude-stats cache --by model --format csv -o cache.csv`,
+	"cmd.latency.short": "分析响应时延、工具循环时长和人工空闲时间",
+	"cmd.latency.long": `根据每条记录的 uuid/parentUuid 重建每个会话的消息树，并计算：
+• 响应时延：用户提问到助手首次回复
+• 工具循环：助手首次回复到本轮最后一条助手消息（仅统计调用了工具的轮次）
+• 空闲时间：助手回复后到用户下一次提问的间隔
+
+按模型或 Claude Code 版本分组显示百分位数，便于判断模型或客户端升级是否变慢。
+
+示例：
+  claude-stats latency                     # 按模型
+  claude-stats latency --by version        # 按客户端版本
+  claude-stats latency --sort p90          # 按响应时延P90排序
+  claude-stats latency --format csv -o latency.csv`,
+	"cmd.heatmap.short": "按星期 × 小时显示用量热力图",
+	"cmd.heatmap.long": `把用量按本地时区的星期（行）和小时（列）汇总成 7×24 的热力图，颜色越亮用量越高。
+
+可以用来把繁重的 Opus 工作安排在计费窗口重置附近，或发现在非工作时间消耗额度的自动化任务。
+
+示例：
+  claude-stats heatmap                          # Token数
+  claude-stats heatmap --metric cost            # 成本
+  claude-stats heatmap --metric messages --since 20250801
+  claude-stats heatmap --format csv -o heatmap.csv`,
+	"cmd.compare.short": "对比两个时间段的用量变化",
+	"cmd.compare.long": `对比两个时间段（A为基准，B为对比）的Token、成本、会话和消息数，并列出对成本变化贡献最大的模型和项目。
+
+日期格式为 YYYYMMDD，范围包含起止两天。
+
+示例：
+  claude-stats compare --week                                   # 本周至今 vs 上周同期
+  claude-stats compare --month                                  # 本月至今 vs 上月同期
+  claude-stats compare --a 20250801:20250807 --b 20250808:20250814
+  claude-stats compare --week --format json`,
+	"cmd.forecast.short": "预测月末（及季末）成本",
+	"cmd.forecast.long": `根据每日成本历史预测月末花费，可选同时预测季末花费。
+
+预测同时给出线性趋势、按星期平均以及两者组合（趋势 × 星期系数）的结果，并根据历史残差给出90%置信区间。
+配置了预算时会提示预测是否超出预算以及预计超出的日期。
+
+预算可在配置文件中设置：
+  budgets:
+    monthly: 200
+    quarterly: 500
+
+示例：
+  claude-stats forecast                      # 月末预测
+  claude-stats forecast --quarter            # 同时预测季末
+  claude-stats forecast --budget 150         # 指定月度预算
+  claude-stats forecast --history 28 --format json`,
+	"cmd.anomalies.short": "找出用量异常的天、会话和计费窗口",
+	"cmd.anomalies.long": `找出Token或成本远超滚动基线的天、会话和5小时计费窗口，例如失控的代理循环。
+
+基线为此前 --window 天内同类对象（只统计有用量的对象）的中位数，
+分数为稳健z分数 0.6745 × (观测值 − 中位数) / MAD，达到 --threshold 时报告；只报告高于基线的异常。
+每条异常会给出观测值、基线以及贡献最大的会话和模型。
+
+使用 --check 时，发现异常以退出码 2 结束（1 表示运行错误），可用于定时任务或CI。
+
+示例：
+  claude-stats anomalies                            # 按成本检测
+  claude-stats anomalies --metric tokens --threshold 5
+  claude-stats anomalies --kind session,block --window 30
+  claude-stats anomalies --check --last 24h          # 只检查最近24小时`,
+	"cmd.export.short": "逐条导出规范化的用量记录",
+	"cmd.export.long": `将每条带 usage 的日志记录导出为一行 JSON（NDJSON），包含时间戳、会话、项目路径、Git分支、模型、各类Token、计算出的成本、价格表版本、子代理标记和 requestId。
+
+导出以流式方式逐行读取和写入，不会把全部日志加载到内存，可直接交给 jq、DuckDB 或数据仓库处理。
+
+--format parquet 写出带类型的列式文件（时间戳、int64 Token、float64 成本，模型和项目列使用字典编码）；--rollup daily 改为按日期、项目、模型和子代理标记汇总。
+
+示例:
+  claude-stats export > usage.ndjson
+  claude-stats export --since 20250801 -o august.ndjson
+  claude-stats export --project my-app | jq -s 'map(.cost_usd) | add'
+  claude-stats export --format parquet -o usage.parquet
+  claude-stats export --format parquet --rollup daily -o daily.parquet`,
+	"cmd.sync.short": "将日志增量同步到本地 SQLite 数据库",
+	"cmd.sync.long": `读取JSONL日志并写入本地 SQLite 数据库（默认 ~/.claude-stats.db，可用 --db 或配置文件 database 指定）。
+
+每个文件只读取上次同步之后追加的内容，重复执行很快；文件被截断或重写时会重新导入。数据库包含以下表:
+  entries   逐条用量明细（与 export 字段一致，另有 date 和 block_start）
+  sessions  会话汇总
+  projects  项目汇总（名称和分组来自配置中的项目规则）
+  blocks    5小时计费窗口汇总
+  files     各日志文件的同步位置
+
+示例:
+  claude-stats sync
+  claude-stats sync ~/.claude --db ./usage.db`,
+	"cmd.query.short": "对本地 SQLite 数据库执行只读 SQL",
+	"cmd.query.long": `对 sync 生成的数据库执行 SQL 并以表格、CSV、JSON 或 Markdown 输出结果。数据库以只读方式打开，任何写入语句都会失败。
+
+示例:
+  claude-stats query "SELECT model, SUM(cost_usd) AS cost FROM entries GROUP BY model"
+  claude-stats query "SELECT date, SUM(cost_usd) FROM entries WHERE is_sidechain GROUP BY date" -f csv
+  claude-stats query "SELECT * FROM blocks ORDER BY cost_usd DESC LIMIT 5"`,
+	"cmd.archive.short": "将用量记录持久化到长期归档",
+	"cmd.archive.long": `Claude Code 会删除超过保留期（默认30天）的对话日志，之后按季度或年度统计时数据会不完整。archive 将当前日志中的用量记录（与 export 字段相同）和每日汇总保存到本地归档目录（默认 ~/.claude-stats-archive，可用 --archive-dir 或配置文件 archive_dir 指定），每月一个 gzip 压缩的 NDJSON 文件。
+
+重复运行只会追加新记录（按 requestId 去重）。归档存在时，所有报告命令都会自动合并其中已不在实时日志里的记录；使用 --no-archive 可只统计实时日志。归档只包含用量数据，工具调用、缓存效率和时延等报告仍只基于实时日志。
+
+建议定期运行（例如每周一次的定时任务）:
+  claude-stats archive
+  claude-stats daily --since 20250101`,
+	"cmd.import.short": "导入团队成员的用量快照",
+	"cmd.import.long": `将其他成员用 export --write-snapshot 生成的快照导入归档目录下的 imports/<用户标签>/，之后所有报告命令都会按用户合并这些数据（--no-archive 可跳过）。
+
+记录按 requestId 去重，同一快照重复导入或与本机日志重叠时不会重复计数。
+
+示例:
+  claude-stats import alice.json bob.json
+  claude-stats users --since 20250101`,
+	"cmd.users.short": "按团队成员（用户标签）统计用量",
+	"cmd.users.long": `按用户标签汇总会话、项目、Token 和成本。本机日志使用配置文件 user 或系统用户名作为标签；其他成员的数据来自 import 导入的快照或 --snapshot 指定的快照文件。
+
+示例:
+  claude-stats export --write-snapshot --label alice -o alice.json
+  claude-stats users --snapshot alice.json,bob.json
+  claude-stats daily --user bob`,
+	"cmd.import_ccusage.short": "导入 ccusage 的 JSON 报告，或与本工具的计算结果对比",
+	"cmd.import_ccusage.long": `读取 ccusage daily、monthly、session 或 blocks 的 --json 输出（daily --instances 的按项目分组输出也支持）。
+
+默认将报告换算为用量记录存入归档（--label 指定时存入 imports/<标签>/，作为该成员的数据），供迁移已被清理的历史数据。每个周期每个模型换算为一条记录；与本机日志或归档已有数据重叠的周期会被跳过，重复导入同一文件不会重复计数。换算后的成本按本工具的价格表重新计算。
+
+--verify 不写入任何数据，而是将每个周期 ccusage 的 Token 和成本与本工具对同一周期的计算结果逐行对比，差异超过 --tolerance（百分比）的周期标记为不一致，此时以退出码 2 结束，可用作回归检查。ccusage 按其运行时的时区（默认本地时区）划分日期和月份，本工具对比和导入时按 --timezone（默认同样为本地时区）解释报告中的日期；生成报告时如果给 ccusage 指定了 --timezone，这里请传入相同的值。
+
+示例:
+  ccusage daily --json > ccusage-daily.json
+  claude-stats import-ccusage --verify ccusage-daily.json
+  claude-stats import-ccusage --verify --timezone UTC ccusage-utc.json
+  claude-stats import-ccusage ccusage-daily.json
+  claude-stats import-ccusage --label bob bob-ccusage.json`,
+	"cmd.schema.short": "输出 JSON 报告的 JSON Schema",
+	"cmd.schema.long": `由报告结构生成 JSON Schema（draft 2020-12）文档。所有 --format json 输出都带有 schema_version（格式版本）和 generator（程序版本）字段，报告结构的任何变化都会递增 schema_version。
+
+不带参数时列出可用的报告名称。--check 将当前的 schema 与程序内置的 golden 文件对比，报告结构变化而 schema_version 没有递增时以退出码 2 结束，可在 CI 中运行。
+
+示例:
+  claude-stats schema
+  claude-stats schema daily -o daily.schema.json
+  claude-stats schema --write schemas/
+  claude-stats schema --check`,
+	"cmd.chargeback.short": "按成本中心分摊每月成本",
+	"cmd.chargeback.long": `根据配置文件中的 chargeback 规则，将各项目的每月成本分摊到团队或成本中心。规则可按项目路径（通配符 match 或正则 regex）或 git 远程地址（remote 或 remote_regex）匹配，按配置顺序取第一条命中的规则；center 表示全部分给一个成本中心，split 按百分比拆分给多个成本中心，不足100%的部分和没有匹配规则的项目计为未分摊。
+
+报告按月列出各成本中心的分摊金额、未分摊的剩余金额和每条规则的命中情况。CSV 输出为逐项明细，列顺序固定，便于财务系统导入。
+
+示例:
+  claude-stats chargeback --since 20250701
+  claude-stats chargeback -f csv -o chargeback-2025-07.csv --since 20250701 --until 20250731`,
+
+	// 标志位说明
+	"flag.config":                  "配置文件 (默认: $HOME/.claude-stats.yaml)",
+	"flag.verbose":                 "详细输出",
+	"flag.lang":                    "界面语言 (zh, en)，默认读取配置文件lang或LANG/LC_ALL环境变量",
+	"flag.archive_dir":             "长期归档目录（默认: 配置文件 archive_dir 或 ~/.claude-stats-archive）",
+	"flag.no_archive":              "不合并归档数据，只统计实时日志",
+	"flag.snapshot":                "合并到报告中的团队快照文件（逗号分隔）",
+	"flag.user":                    "只统计指定用户标签的数据",
+	"flag.format_analyze":          "输出格式 (table, json, csv, html, markdown, template)",
+	"flag.format_anomalies":        "输出格式 (table, json, csv, markdown)",
+	"flag.format_blocks":           "输出格式 (table, json, html, markdown, template)",
+	"flag.format_branches":         "输出格式 (table, json, csv, markdown)",
+	"flag.format_cache":            "输出格式 (table, json, csv, markdown)",
+	"flag.format_chargeback":       "输出格式 (table, json, csv, markdown)",
+	"flag.format_compare":          "输出格式 (table, json, csv, markdown)",
+	"flag.format_daily":            "输出格式 (table, json, csv, html, markdown, template)",
+	"flag.format_forecast":         "输出格式 (table, json, markdown)",
+	"flag.format_heatmap":          "输出格式 (table, json, csv, markdown)",
+	"flag.format_import_ccusage":   "输出格式 (table, json, csv, markdown)",
+	"flag.format_latency":          "输出格式 (table, json, csv, markdown)",
+	"flag.format_projects":         "输出格式 (table, json, csv, html, markdown)",
+	"flag.format_query":            "输出格式 (table, json, csv, markdown)",
+	"flag.format_tools":            "输出格式 (table, json, csv, markdown)",
+	"flag.format_users":            "输出格式 (table, json, csv, markdown)",
+	"flag.output":                  "输出文件路径",
+	"flag.since":                   "开始日期 (YYYYMMDD)",
+	"flag.until":                   "结束日期 (YYYYMMDD)",
+	"flag.daily_breakdown":         "显示每日按模型分解的详细统计",
+	"flag.daily_order":             "排序顺序: desc(最新优先) 或 asc(最旧优先)",
+	"flag.no_color":                "禁用颜色输出",
+	"flag.offline":                 "离线模式",
+	"flag.offline_pricing":         "离线模式，使用缓存定价数据",
+	"flag.mode":                    "成本计算模式 (auto, calculate, display)",
+	"flag.version":                 "显示版本信息",
+	"flag.model":                   "过滤特定模型",
+	"flag.details":                 "显示详细信息",
+	"flag.config_dirs":             "指定多个Claude配置目录，逗号分隔",
+	"flag.breakdown":               "显示按模型分解的详细成本",
+	"flag.order":                   "排序顺序 (asc, desc)",
+	"flag.blocks_live":             "实时监控模式",
+	"flag.blocks_token_limit":      "Token限制 (数字或'max')",
+	"flag.blocks_refresh_interval": "实时模式刷新间隔(秒)",
+	"flag.blocks_active":           "只显示活跃窗口",
+	"flag.blocks_recent":           "显示最近的窗口",
+	"flag.project":                 "只统计匹配的项目（路径、前缀、通配符、别名、分组或目录名）",
+	"flag.sidechain":               "子代理（sidechain）记录的处理方式: include（默认）, exclude（仅主线程）, only（仅子代理）",
+	"flag.redact":                  "脱敏方式: none（默认）、paths（路径中的主目录和用户名替换为 ~）、hash（项目路径、会话ID、分支和主机名替换为哈希）",
+	"flag.chart":                   "在表格后附加图表（条形图、Token构成和趋势图），宽度随终端调整",
+	"flag.template":                "--format template 使用的模板文件，也可以是内置模板名（daily-summary、daily-prometheus、blocks-statusline、analyze-models）",
+	"flag.template_string":         "--format template 使用的内联模板（Go text/template 语法）",
+	"flag.projects_group":          "按配置中的分组汇总项目",
+	"flag.projects_sort":           "排序字段 (cost, tokens, messages, sessions, last, name)",
+	"flag.branch":                  "只显示匹配的分支（支持通配符）",
+	"flag.branches_commits":        "使用本地git log关联会话期间的提交",
+	"flag.branches_repo":           "用于关联提交的本地仓库路径（默认使用项目路径所在仓库，隐含 --commits）",
+	"flag.branches_sort":           "排序字段 (cost, tokens, messages, sessions, commits, last, name)",
+	"flag.tools_sort":              "排序字段 (cost, calls, errors, error_rate, tokens, name)",
+	"flag.tools_top":               "每个工具显示的项目数",
+	"flag.cache_by":                "分组维度 (day, project, session, model)",
+	"flag.cache_sort":              "排序字段 (wasted, wasted_1h, savings, hit_ratio, tokens, name)，默认按日期或浪费金额",
+	"flag.cache_top":               "只显示前N行（0表示全部）",
+	"flag.latency_by":              "分组维度 (model, version)",
+	"flag.latency_sort":            "排序字段 (name, p50, p90, p99, turns)",
+	"flag.heatmap_metric":          "统计指标 (tokens, cost, messages)",
+	"flag.compare_a":               "基准时间段 (YYYYMMDD:YYYYMMDD)",
+	"flag.compare_b":               "对比时间段 (YYYYMMDD:YYYYMMDD)",
+	"flag.compare_week":            "本周至今对比上周同期",
+	"flag.compare_month":           "本月至今对比上月同期",
+	"flag.compare_top":             "显示变化最大的前N个模型和项目",
+	"flag.forecast_history":        "用于拟合的历史天数",
+	"flag.forecast_quarter":        "同时预测季末花费",
+	"flag.forecast_budget":         "月度预算（美元），覆盖配置文件中的 budgets.monthly",
+	"flag.forecast_quarter_budget": "季度预算（美元），覆盖配置文件中的 budgets.quarterly",
+	"flag.anomalies_metric":        "检测指标 (cost, tokens)",
+	"flag.anomalies_threshold":     "稳健z分数阈值，越小越敏感",
+	"flag.anomalies_window":        "滚动基线的天数",
+	"flag.anomalies_kind":          "检测的对象类型 (day, session, block)，可用逗号分隔多个",
+	"flag.anomalies_last":          "只报告最近一段时间内的异常，如 24h、7d",
+	"flag.anomalies_check":         "检查模式：发现异常时以退出码 2 结束",
+	"flag.export_format":           "导出格式 (ndjson, parquet)",
+	"flag.export_rollup":           "按天汇总后导出 (daily)，默认导出逐条明细",
+	"flag.export_snapshot":         "导出可在团队间传递的快照文件（JSON，包含用户标签和主机名）",
+	"flag.export_label":            "快照中的用户标签（默认: 配置文件 user 或系统用户名）",
+	"flag.ccusage_verify":          "对比 ccusage 报告与本工具的计算结果，不导入数据",
+	"flag.ccusage_label":           "将数据作为该用户标签导入 imports/<标签>/（默认存入本机归档）",
+	"flag.ccusage_tolerance":       "--verify 允许的 Token 和成本相对差异（百分比）",
+	"flag.ccusage_timezone":        "生成报告时 ccusage 使用的时区（IANA 名称，如 UTC、Asia/Shanghai），默认为本地时区",
+	"flag.schema_write":            "将全部报告的 schema 写入该目录（<名称>.schema.json）",
+	"flag.schema_check":            "与内置的 golden 文件对比，结构变化而未递增 schema_version 时以退出码 2 结束",
+	"flag.plan":                    "订阅计划 (pro, max5x, max20x)，覆盖配置文件中的 plan；不指定时按历史用量推测",
+	"flag.db":                      "SQLite 数据库路径（默认: 配置文件 database 或 ~/.claude-stats.db）",
+
+	// 通用消息
+	"main.error":              "错误: %v",
+	"root.using_config":       "使用配置文件:",
+	"root.unknown_lang":       "⚠️  不支持的语言 %q，可选: %s",
+	"root.invalid_projects":   "⚠️  配置文件中的 projects 规则无效，已忽略: %v",
+	"common.analyzing_dirs":   "🔍 分析目录: %s",
+	"common.processing_dir":   "📂 处理目录: %s",
+	"common.dir_missing":      "⚠️  目录不存在，跳过: %s",
+	"common.dir_failed":       "⚠️  处理目录失败，跳过 %s: %v",
+	"common.parse_dir_failed": "⚠️  解析目录失败，跳过 %s: %v",
+	"common.saved":            "✅ 报告已保存到: %s",
+	"sync.done":               "✅ 同步了 %d 条新记录（来自 %d 个有更新的文件，共扫描 %d 个）到 %s",
+	"sync.reset":              "   %d 个文件被截断或重写，已重新导入",
+	"sync.totals":             "   数据库现有 %d 条记录、%d 个会话、%d 个项目、%d 个5小时窗口",
+	"archive.done":            "📦 已归档 %d 条新记录到 %s",
+	"archive.totals":          "   归档现有 %d 条记录，覆盖 %d 个月",
+	"archive.merged":          "📦 合并了 %d 条已不在实时日志中的归档记录（来自 %s）",
+	"import.done":             "📥 %s（用户 %s，主机 %s）: 新增 %d 条记录，%d 条已存在",
+	"import.location":         "   导入数据保存在 %s/imports",
+	"import.merged":           "👥 合并了 %d 条导入记录（来自 %s）",
+	"snapshot.merged":         "👥 合并了 %d 条快照记录（来自 %s）",
+	"common.total":            "总计",
+	"common.none":             "无",
+	"common.unknown":          "未知",
+
+	// 错误消息
+	"err.date_format":              "日期格式错误: %w",
+	"err.start_date":               "开始日期解析失败: %w",
+	"err.end_date":                 "结束日期解析失败: %w",
+	"err.parse_date":               "无法解析日期: %s，支持格式: YYYYMMDD, YYYY-MM-DD, YYYY/MM/DD",
+	"err.unsupported_format":       "不支持的格式: %s",
+	"err.format_failed":            "格式化失败: %w",
+	"err.write_failed":             "写入文件失败: %w",
+	"err.no_valid_dirs":            "没有找到有效的Claude配置目录",
+	"err.daily_analyze":            "日分析失败: %w",
+	"err.blocks_analyze":           "分析blocks失败: %w",
+	"err.unsupported_sort":         "不支持的排序字段: %s",
+	"err.unsupported_sidechain":    "不支持的 --sidechain 取值: %s（可选 include, exclude, only）",
+	"err.unsupported_cache_by":     "不支持的分组维度: %s（可选 day, project, session, model）",
+	"err.unsupported_latency_by":   "不支持的分组维度: %s（可选 model, version）",
+	"err.unsupported_metric":       "不支持的指标: %s（可选 tokens, cost, messages）",
+	"err.compare_periods":          "请指定 --week、--month，或同时指定 --a 和 --b",
+	"err.compare_range":            "无效的日期范围: %s（格式为 YYYYMMDD:YYYYMMDD）",
+	"err.forecast_history":         "历史天数必须大于0: %d",
+	"err.unsupported_anomaly_kind": "不支持的检测对象: %s（可选 day, session, block）",
+	"err.anomalies_threshold":      "阈值必须大于0: %g",
+	"err.anomalies_window":         "基线天数必须大于0: %d",
+	"err.anomalies_last":           "无效的时间范围: %s（如 24h、7d）",
+	"err.unsupported_rollup":       "不支持的汇总方式: %s (可选: daily)",
+	"err.unsupported_redact":       "不支持的脱敏方式: %s (可选: none, paths, hash)",
+	"err.db_open":                  "打开数据库 %s 失败: %v",
+	"err.db_missing":               "数据库 %s 不存在，请先运行 claude-stats sync",
+	"err.sync_failed":              "同步失败: %v",
+	"err.query_failed":             "查询失败: %v",
+	"err.query_multiple":           "一次只能执行一条 SQL 语句",
+	"err.query_not_select":         "只允许只读查询（SELECT、WITH、VALUES），不支持 %s",
+	"err.archive_failed":           "归档失败: %v",
+	"err.archive_read":             "读取归档 %s 失败: %v",
+	"err.snapshot_read":            "读取快照 %s 失败: %v",
+	"err.snapshot_type":            "不是快照文件（type 为 %q）",
+	"err.snapshot_version":         "不支持的快照版本: %d",
+	"err.snapshot_user":            "快照缺少用户标签",
+	"err.snapshot_flags":           "--write-snapshot 不能与 --format 或 --rollup 同时使用",
+	"err.chargeback_config":        "成本分摊规则配置无效: %v",
+	"err.chargeback_match":         "规则 %s 缺少匹配条件（match、regex、remote 或 remote_regex）",
+	"err.chargeback_target":        "规则 %s 必须设置 center 或 split 之一",
+	"err.chargeback_share":         "规则 %s 的 split 项需要成本中心名称和大于0的百分比",
+	"err.chargeback_split_total":   "规则 %s 的拆分比例合计 %.2f%%，不能超过100%%",
+	"err.chargeback_regex":         "规则 %s 的正则表达式无效: %v",
+	"err.ccusage_read":             "读取 ccusage 报告 %s 失败: %v",
+	"err.ccusage_format":           "无法识别的 ccusage JSON：需要 ccusage daily、monthly、session 或 blocks 的 --json 输出",
+	"err.unknown_timezone":         "未知的时区 %q: %v",
+	"err.ccusage_row":              "ccusage %s 报告中的时间无效: %v",
+	"err.ccusage_verify_args":      "--verify 一次只能对比一个文件",
+	"err.schema_unknown":           "未知的报告 %s（可用: %s）",
+	"err.schema_type":              "无法为类型 %s 生成 schema",
+	"err.schema_untagged":          "%s.%s 没有 json 标签",
+	"err.unknown_plan":             "未知的订阅计划: %s（可选 pro, max5x, max20x）",
+	"err.plans_config":             "订阅计划额度配置无效: %v",
+	"err.template_required":        "--format template 需要指定 --template 或 --template-string（内置模板: %s）",
+	"err.template_conflict":        "--template 和 --template-string 不能同时使用",
+	"err.template_not_found":       "找不到模板文件 %s，也没有同名的内置模板（内置模板: %s）",
+
+	// daily / analyze / blocks 命令运行时消息
+	"daily.start":                "📅 开始按日分析: %s",
+	"daily.done":                 "✅ 分析完成: 共 %d 天的数据",
+	"analyze.multi_dirs":         "💡 将聚合分析 %d 个配置目录的数据",
+	"analyze.dir_done":           "✅ 完成目录: %s (会话:%d, 消息:%d)",
+	"analyze.aggregated":         "📊 聚合完成: 共处理 %d 个目录",
+	"blocks.live_start":          "🔴 启动实时监控模式 (刷新间隔: %d秒)",
+	"blocks.live_exit_hint":      "💡 按 Ctrl+C 退出监控",
+	"blocks.live_time":           "🕐 监控时间: %s",
+	"blocks.token_limit":         "⚡ Token限制: %s",
+	"blocks.live_parse_failed":   "❌ 解析失败: %v",
+	"blocks.live_analyze_failed": "❌ 分析失败: %v",
+	"blocks.live_format_failed":  "❌ 格式化失败: %v",
+	"blocks.limit_critical":      "🚨 警告: 当前窗口Token使用率 %.1f%% (%.0f/%d)",
+	"blocks.limit_warning":       "⚠️  注意: 当前窗口Token使用率 %.1f%% (%.0f/%d)",
+	"blocks.limit_notice":        "💡 提示: 当前窗口Token使用率 %.1f%% (%.0f/%d)",
+	"branches.repo_failed":       "⚠️  无法定位 %s 所在的Git仓库: %v",
+	"chargeback.remote_failed":   "⚠️  无法读取 %s 的 git 远程地址: %v",
+	"ccusage.imported":           "📥 %s（ccusage %s）：导入 %d 个周期（新增 %d 条记录），%d 个周期已有数据而跳过",
+	"ccusage.location":           "   数据已存入归档 %s",
+	"schema.list_title":          "可用的报告（claude-stats schema <报告>），schema_version %d：",
+	"schema.written":             "已将 %d 个 schema 写入 %s",
+	"schema.check_ok":            "✅ %d 个 schema 与 golden 文件一致（schema_version %d）",
+	"schema.check_changed":       "❌ %s：结构已变化，但 schema_version 仍为 %d；请递增 models.SchemaVersion 并运行 go generate ./pkg/schema",
+	"schema.check_outdated":      "❌ %s：golden 文件对应 schema_version %d；请运行 go generate ./pkg/schema 重新生成",
+	"schema.check_missing":       "❌ %s：没有 golden 文件；请运行 go generate ./pkg/schema",
+	"branches.log_failed":        "⚠️  读取分支 %s 的提交失败: %v",
+
+	// 解析器消息
+	"parser.processing_file":       "📂 处理文件: %s",
+	"parser.skip_file":             "⚠️  跳过文件 %s: %v",
+	"parser.err_parse_file":        "解析文件 %s 失败: %w",
+	"parser.err_open_file":         "打开文件失败: %w",
+	"parser.line_error":            "⚠️  行 %d 解析错误: %v",
+	"parser.err_parse_line":        "行 %d 解析失败: %w",
+	"parser.err_read_file":         "读取文件失败: %w",
+	"parser.err_json":              "JSON解析失败: %w",
+	"parser.err_timestamp":         "无法解析时间戳: %s",
+	"parser.debug_record":          "🔍 调试信息 - 记录 #%d:",
+	"parser.debug_content_preview": "  Content预览: %s...",
+	"cost.api_cheaper":             "API模式更经济",
+
+	// 表格列标题
+	"col.date":                  "日期",
+	"col.model":                 "模型",
+	"col.input_tokens":          "输入Token",
+	"col.output_tokens":         "输出Token",
+	"col.cache_creation":        "缓存创建",
+	"col.cache_read":            "缓存读取",
+	"col.cache_creation_tokens": "缓存创建Token",
+	"col.cache_read_tokens":     "缓存读取Token",
+	"col.total_tokens":          "总Token",
+	"col.cost_usd":              "成本(USD)",
+	"col.equivalent_cost":       "等价成本(USD)",
+	"col.messages":              "消息数",
+	"col.sessions":              "会话数",
+	"col.block_start":           "窗口开始时间",
+	"col.status":                "状态",
+	"col.type":                  "类型",
+	"col.amount":                "数量",
+	"col.percentage":            "百分比",
+	"col.description":           "说明",
+	"col.input":                 "输入",
+	"col.output":                "输出",
+	"col.cache":                 "缓存",
+	"col.total":                 "总计",
+	"col.project":               "项目",
+	"col.path":                  "路径",
+	"col.tokens":                "Token数",
+	"col.sidechain_tokens":      "子代理Token",
+	"col.sidechain_cost":        "子代理成本",
+	"col.last_activity":         "最后活动",
+	"col.first_activity":        "首次活动",
+	"col.trend":                 "趋势",
+	"col.branch":                "分支",
+	"col.commits":               "提交数",
+	"col.tool":                  "工具",
+	"col.calls":                 "调用次数",
+	"col.errors":                "错误数",
+	"col.error_rate":            "错误率",
+	"col.top_projects":          "主要项目",
+	"col.user":                  "用户",
+	"col.hosts":                 "主机",
+	"col.projects":              "项目数",
+	"col.month":                 "月份",
+	"col.cost_center":           "成本中心",
+	"col.rule":                  "规则",
+	"col.ccusage_tokens":        "ccusage Token",
+	"col.ccusage_cost":          "ccusage 成本",
+	"col.cost_delta":            "成本差异",
+	"col.hit_ratio":             "命中率",
+	"col.cache_savings":         "节省",
+	"col.cache_net_savings":     "净节省",
+	"col.wasted_5m":             "浪费写入(5分钟)",
+	"col.wasted_1h":             "浪费写入(1小时)",
+	"col.version":               "版本",
+	"col.turns":                 "轮次",
+	"col.response_p50":          "响应P50",
+	"col.response_p90":          "响应P90",
+	"col.response_p99":          "响应P99",
+	"col.tool_loop_p50":         "工具循环P50",
+	"col.tool_loop_p90":         "工具循环P90",
+	"col.idle_p50":              "空闲P50",
+	"col.idle_p90":              "空闲P90",
+	"col.metric":                "指标",
+	"col.delta":                 "变化",
+	"col.change":                "变化率",
+	"col.cost_a":                "成本 A",
+	"col.cost_b":                "成本 B",
+	"col.tokens_delta":          "Token变化",
+	"col.share_of_change":       "占成本变化",
+	"col.period":                "周期",
+	"col.date_range":            "日期范围",
+	"col.actual_to_date":        "至今实际",
+	"col.linear":                "线性趋势",
+	"col.weekday_avg":           "星期平均",
+	"col.forecast":              "预测",
+	"col.forecast_range":        "%.0f%% 区间",
+	"col.budget":                "预算",
+	"col.kind":                  "类型",
+	"col.target":                "对象",
+	"col.observed":              "观测值",
+	"col.baseline":              "基线",
+	"col.ratio":                 "倍数",
+	"col.score":                 "分数",
+	"col.top_session":           "主要会话",
+	"col.top_model":             "主要模型",
+	"col.session_id":            "会话ID",
+	"col.start_time":            "开始时间",
+
+	// 报告内容
+	"fmt.blocks.title":     "5小时计费窗口分析",
+	"fmt.blocks.empty":     "暂无活动窗口数据",
+	"fmt.blocks.active":    "活跃 (%s)",
+	"fmt.blocks.burn_rate": "速率: %s/分钟",
+	"fmt.blocks.projected": "预测: %s",
+	"fmt.blocks.completed": "已完成",
+
+	"fmt.daily.title":               "每日使用统计",
+	"fmt.daily.empty":               "暂无每日数据",
+	"fmt.daily.cost_notice":         "这是一个本地消费分析工具，显示的成本是基于您本地的Token使用量和Claude API的公开价格估算的等价成本。",
+	"fmt.daily.subscription_notice": "对于订阅用户（如Pro/Max），您的实际账单是固定的月费，此处的成本估算可帮助您了解使用价值，而非实际应付金额。",
+
+	"fmt.header.author":   "作者: zhuiye",
+	"fmt.header.subtitle": "Claude Code 使用统计分析工具  •  生成时间: %s",
+
+	"fmt.basic.title":          "基本信息",
+	"fmt.basic.mode":           "检测模式",
+	"fmt.basic.total_sessions": "总会话数",
+	"fmt.basic.total_messages": "总消息数",
+	"fmt.basic.parsed":         "解析成功",
+	"fmt.basic.extracted":      "Token提取",
+	"fmt.basic.sidechain":      "子代理用量",
+	"fmt.basic.time_range":     "%s 至 %s",
+	"fmt.basic.period":         "分析时段",
+	"fmt.basic.duration":       "持续时间",
+	"fmt.basic.message_types":  "消息类型",
+
+	"fmt.tokens.title":               "Token 使用统计",
+	"fmt.tokens.base_hint":           "百分比基准: 基础Token(%s) = 输入Token + 输出Token",
+	"fmt.tokens.input_desc":          "用户提问成本",
+	"fmt.tokens.output_desc":         "AI回复成本",
+	"fmt.tokens.cache_creation_desc": "上下文缓存",
+	"fmt.tokens.cache_read_desc":     "缓存加速",
+	"fmt.tokens.excluded":            "不计入%",
+	"fmt.tokens.empty":               "暂无Token数据",
+	"fmt.tokens.check_jsonl":         "请检查JSONL格式",
+	"fmt.tokens.base_total":          "基础Token总计",
+	"fmt.tokens.real_cost":           "真实使用成本",
+
+	"fmt.models.title":                   "按模型统计",
+	"fmt.projects.title":                 "项目统计",
+	"fmt.projects.empty":                 "暂无项目数据",
+	"fmt.users.title":                    "用户统计",
+	"fmt.users.empty":                    "暂无用户数据",
+	"fmt.chargeback.title":               "成本分摊",
+	"fmt.chargeback.empty":               "暂无成本数据",
+	"fmt.chargeback.no_rules":            "配置文件中没有 chargeback 规则，全部成本计为未分摊",
+	"fmt.chargeback.price_hint":          "成本按价格表 %s 计算",
+	"fmt.chargeback.unallocated":         "未分摊",
+	"fmt.chargeback.allocated_label":     "已分摊",
+	"fmt.chargeback.summary":             "已分摊 %s（%s），未分摊 %s",
+	"fmt.chargeback.rules_title":         "规则命中",
+	"fmt.ccusage.title":                  "ccusage 对比（%s）",
+	"fmt.ccusage.empty":                  "ccusage 报告中没有数据",
+	"fmt.ccusage.ok":                     "一致",
+	"fmt.ccusage.mismatch":               "不一致",
+	"fmt.ccusage.summary":                "%d 个周期中 %d 个超出 %.2f%% 的容差",
+	"fmt.ccusage.price_hint":             "本工具的成本按价格表 %s 计算",
+	"fmt.projects.trend_hint":            "趋势列为最近%d天的每日Token数",
+	"fmt.projects.paths_count":           "%d 个路径",
+	"fmt.branches.title":                 "分支统计",
+	"fmt.branches.empty":                 "暂无分支数据",
+	"fmt.branches.no_branch":             "(无分支)",
+	"fmt.tools.title":                    "工具使用统计",
+	"fmt.tools.empty":                    "暂无工具调用数据",
+	"fmt.tools.cost_hint":                "Token和成本为发起调用的助手消息的用量，多个工具共用一条消息时平均分摊",
+	"fmt.cache.title":                    "提示缓存效率",
+	"fmt.cache.empty":                    "没有找到缓存使用数据",
+	"fmt.cache.savings_hint":             "节省 = 缓存读取相比按输入全价少付的金额；净节省再扣除缓存写入多付的部分",
+	"fmt.cache.waste_hint":               "浪费写入为估算值：同一会话中在TTL内没有被后续读取的缓存写入，括号内为占缓存写入的比例",
+	"fmt.latency.title":                  "响应时延分析",
+	"fmt.latency.empty":                  "没有找到可以重建消息树的记录（需要 uuid/parentUuid）",
+	"fmt.latency.hint":                   "响应时延按模型的首次回复计，版本取用户提问时的客户端版本；空闲时间反映的是人的等待而非模型速度",
+	"fmt.heatmap.title":                  "用量热力图（%s）",
+	"fmt.heatmap.metric_tokens":          "Token数",
+	"fmt.heatmap.metric_cost":            "成本",
+	"fmt.heatmap.metric_messages":        "消息数",
+	"fmt.heatmap.empty":                  "所选时间范围内没有用量数据",
+	"fmt.heatmap.timezone_hint":          "时区: %s；行为星期，列为小时",
+	"fmt.heatmap.weekdays":               "周一,周二,周三,周四,周五,周六,周日",
+	"fmt.heatmap.legend":                 "图例: %s  （最高 %s）",
+	"fmt.compare.title":                  "时间段对比",
+	"fmt.compare.days":                   "%d天",
+	"fmt.compare.by_model":               "按模型（成本变化最大）",
+	"fmt.compare.by_project":             "按项目（成本变化最大）",
+	"fmt.compare.more":                   "另有 %d 项未显示，可用 --top 调整",
+	"fmt.compare.new":                    "新增",
+	"fmt.forecast.title":                 "成本预测（截至 %s）",
+	"fmt.forecast.empty":                 "最近没有可用于预测的成本数据",
+	"fmt.forecast.model_hint":            "基于最近 %d 天：线性趋势 × 星期系数，%.0f%% 置信区间；今天按实际与预测中的较大者计",
+	"fmt.forecast.trend_hint":            "趋势: %s 美元/天",
+	"fmt.forecast.target_month":          "月末",
+	"fmt.forecast.target_quarter":        "季末",
+	"fmt.forecast.status_none":           "未设预算",
+	"fmt.forecast.status_under":          "预算内",
+	"fmt.forecast.status_at_risk":        "可能超出",
+	"fmt.forecast.status_projected_over": "预计超出",
+	"fmt.forecast.status_over":           "已超出",
+	"fmt.forecast.crosses":               "%s（%s）",
+	"fmt.forecast.chart_title":           "每日成本",
+	"fmt.forecast.chart_legend":          "█ 实际  ░ 预测  · 区间上限",
+	"fmt.anomalies.title":                "用量异常（%s）",
+	"fmt.anomalies.baseline_hint":        "基线: 此前 %d 天内同类对象的中位数；阈值: 稳健z分数 ≥ %g",
+	"fmt.anomalies.checked_hint":         "已检测 %d 天、%d 个会话、%d 个计费窗口",
+	"fmt.anomalies.none":                 "未发现异常",
+	"fmt.anomalies.kind_day":             "日",
+	"fmt.anomalies.kind_session":         "会话",
+	"fmt.anomalies.kind_block":           "窗口",
+	"fmt.chart.daily_cost":               "每日成本",
+	"fmt.chart.token_mix":                "每日Token构成",
+	"fmt.chart.cost_trend":               "成本趋势",
+	"fmt.chart.token_trend":              "Token趋势",
+	"fmt.chart.block_tokens":             "各窗口Token构成（● 为活跃窗口）",
+	"fmt.chart.model_tokens":             "各模型Token构成",
+	"fmt.chart.project_tokens":           "各项目Token构成及最近%d天趋势",
+	"fmt.query.rows":                     "%d 行",
+	"fmt.html.generated":                 "生成时间：%s",
+	"fmt.report.title":                   "Claude Code 用量报告",
+	"fmt.html.model_share":               "按模型的成本占比",
+	"fmt.html.project_cost":              "按项目的成本（前 %d 个）",
+	"fmt.html.block_timeline":            "计费窗口时间线（高度为成本）",
+	"fmt.html.sessions":                  "会话",
+	"fmt.report.days":                    "天数",
+	"fmt.report.blocks":                  "窗口数",
+	"fmt.html.other":                     "其他",
+	"fmt.html.sort_hint":                 "点击表头可排序",
+	"fmt.sessions.title":                 "会话统计 (最近20个)",
+
+	"fmt.cost.title":             "成本分析",
+	"fmt.cost.subscription_hint": "(基于订阅模式的API等价成本估算)",
+	"fmt.cost.input":             "输入成本:     %s (%.1f%% 📉 低成本)",
+	"fmt.cost.output":            "输出成本:     %s (%.1f%% 📈 主要成本)",
+	"fmt.cost.ratio":             "成本比例:     输出成本是输入成本的 %s 倍",
+	"fmt.cost.cache_creation":    "缓存创建成本: %s",
+	"fmt.cost.cache_read":        "缓存读取成本: %s",
+	"fmt.cost.total":             "总成本:       %s",
+	"fmt.cost.savings":           "成本节省:     订阅模式相比API节省 %s",
+
+	"fmt.quota.title":                "订阅限额状态",
+	"fmt.quota.estimate_warning":     "注意：计划是按历史用量推测的，额度可能不准确；请在配置文件中设置 plan 或使用 --plan 指定",
+	"fmt.quota.status_hint":          "在Claude Code中运行 /status 可获取准确的当前窗口使用情况",
+	"fmt.quota.plan":                 "订阅计划",
+	"fmt.quota.per_month":            "$%d/月",
+	"fmt.quota.mechanism":            "限额机制",
+	"fmt.quota.mechanism_desc":       "5小时窗口，与 blocks 命令的计费窗口相同",
+	"fmt.quota.source_configured":    "已配置",
+	"fmt.quota.source_estimated":     "按历史用量推测",
+	"fmt.quota.window":               "当前窗口: %s - %s（%s重置）",
+	"fmt.quota.no_window":            "当前没有活跃窗口，下一条消息将开始新的5小时窗口",
+	"fmt.quota.tokens":               "Token 用量: %s / %s (%.1f%%)",
+	"fmt.quota.used":                 "当前窗口已用:  %s / %d 条提问",
+	"fmt.quota.progress":             "使用进度",
+	"fmt.quota.remaining":            "剩余: %s 条 (窗口还剩 %.1f%%)",
+	"fmt.quota.model":                "推测模型",
+	"fmt.quota.model_high":           "高性能模型",
+	"fmt.quota.model_standard":       "标准模型",
+	"fmt.quota.model_unknown":        "未知模型",
+	"fmt.quota.details":              "计划推测依据:",
+	"fmt.quota.detail_user_messages": "分析期间用户消息: %d 条",
+	"fmt.quota.detail_windows":       "跨越窗口数量: %d 个 (5小时/窗口)",
+	"fmt.quota.detail_avg":           "平均每窗口: %.1f 条消息",
+	"fmt.quota.detail_hours":         "分析时长: %.1f 小时",
+	"fmt.quota.accurate_hint":        "获取准确信息：在Claude Code中运行 /status 命令",
+	"fmt.quota.limit_reached":        "当前窗口的额度已用完",
+	"fmt.quota.low_remaining":        "当前窗口只剩 %d 条提问，请注意用量",
+	"fmt.quota.ok_remaining":         "当前窗口还可以发送 %d 条提问",
+
+	"fmt.duration.expired":       "已过期",
+	"fmt.duration.hours_minutes": "%d小时%d分钟后",
+	"fmt.duration.minutes":       "%d分钟后",
+
+	"fmt.suggest.title":            "订阅使用建议",
+	"fmt.suggest.estimate_warning": "以下建议基于估算数据，请结合实际使用情况判断",
+	"fmt.suggest.tips":             "效率提升技巧：",
+	"fmt.suggest.tip_compact":      "使用 %s 清理上下文",
+	"fmt.suggest.tip_clear":        "使用 %s 重置对话",
+	"fmt.suggest.tip_status":       "使用 %s 查看实时限额",
+	"fmt.suggest.high_usage":       "当前窗口使用率较高",
+	"fmt.suggest.high_compact":     "考虑使用 %s 减少上下文",
+	"fmt.suggest.high_avoid_long":  "避免长时间连续对话",
+	"fmt.suggest.high_upgrade":     "如经常遇到限制，可考虑升级计划",
+	"fmt.suggest.low_usage":        "当前窗口使用充裕",
+	"fmt.suggest.low_plan_fits":    "当前计划适合您的使用模式",
+	"fmt.suggest.timezone":         "时区注意事项：",
+	"fmt.suggest.timezone_utc":     "Claude Code限额可能基于UTC时区",
+	"fmt.suggest.timezone_local":   "重置时间可能与您的本地时间不同",
+	"fmt.suggest.timezone_status":  "建议使用 %s 确认准确时间",
+	"fmt.suggest.savings":          "订阅模式相比API节省约 $%.2f/月",
+	"fmt.suggest.fallback_status":  "建议在Claude Code中使用 /status 查看详细限额信息",
+	"fmt.suggest.fallback_docs":    "参考官方文档了解订阅计划详情",
+
+	"fmt.mode.api":          "API模式 (按token计费)",
+	"fmt.mode.subscription": "订阅模式 (按请求限制)",
+}
@@ -0,0 +1,43 @@
+package models
+
+// SchemaVersion JSON 输出的格式版本
+// 报告结构有任何变化（增删字段、改名、改类型）时递增，并用 go generate ./pkg/schema 重新生成 golden 文件
+const SchemaVersion = 3
+
+// Generator JSON 输出中的 generator 字段，启动时由 cmd 设置为 "claude-stats <版本>"
+var Generator = "claude-stats"
+
+// SchemaInfo 所有 JSON 报告共有的格式版本信息，以匿名字段嵌入各报告结构
+type SchemaInfo struct {
+	SchemaVersion int    `json:"schema_version"`
+	Generator     string `json:"generator"`
+}
+
+// Stamp 写入当前的格式版本和程序版本，在序列化报告之前调用
+func (s *SchemaInfo) Stamp() {
+	s.SchemaVersion = SchemaVersion
+	s.Generator = Generator
+}
+
+// ExportRecord export 输出的 NDJSON 明细行：在 UsageRecord 的字段之前加上格式版本
+// 归档文件和快照中的记录仍为 UsageRecord，版本由所在文件决定
+type ExportRecord struct {
+	SchemaVersion int `json:"schema_version"`
+	UsageRecord
+}
+
+// NewExportRecord 为明细记录加上当前的格式版本
+func NewExportRecord(record UsageRecord) ExportRecord {
+	return ExportRecord{SchemaVersion: SchemaVersion, UsageRecord: record}
+}
+
+// ExportRollup export --rollup daily 输出的 NDJSON 汇总行
+type ExportRollup struct {
+	SchemaVersion int `json:"schema_version"`
+	UsageRollup
+}
+
+// NewExportRollup 为每日汇总加上当前的格式版本
+func NewExportRollup(rollup UsageRollup) ExportRollup {
+	return ExportRollup{SchemaVersion: SchemaVersion, UsageRollup: rollup}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"sort"
 	"time"
 )
 
@@ -16,6 +17,7 @@ type ConversationEntry struct {
 	IsSidechain bool                   `json:"isSidechain,omitempty"`
 	IsMeta      bool                   `json:"isMeta,omitempty"`
 	CWD         string                 `json:"cwd,omitempty"`
+	GitBranch   string                 `json:"gitBranch,omitempty"`
 	Version     string                 `json:"version,omitempty"`
 	RequestID   string                 `json:"requestId,omitempty"`
 	
@@ -29,6 +31,10 @@ type ConversationEntry struct {
 	// 解析后的信息
 	ParsedMessage *ParsedMessage        `json:"-"` // 不序列化，仅内部使用
 	ExtractedUsage *TokenUsage          `json:"-"` // 从消息中提取的token信息
+
+	// 来自团队快照或归档的记录所属的用户标签和主机名，实时日志中为空
+	User        string                 `json:"-"`
+	Host        string                 `json:"-"`
 	
 	RawData     map[string]interface{} `json:"-"` // 存储原始数据以处理未知字段
 }
@@ -39,6 +45,22 @@ type ParsedMessage struct {
 	Content  interface{} `json:"content,omitempty"` // 可能是字符串或复杂结构
 	Model    string      `json:"model,omitempty"`
 	Usage    *TokenUsage `json:"usage,omitempty"`
+
+	// 从content数组中提取的工具调用和工具结果
+	ToolUses    []ToolUse    `json:"tool_uses,omitempty"`
+	ToolResults []ToolResult `json:"tool_results,omitempty"`
+}
+
+// ToolUse 代表助手消息中的 tool_use 内容块
+type ToolUse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ToolResult 代表用户消息中的 tool_result 内容块
+type ToolResult struct {
+	ToolUseID string `json:"tool_use_id"`
+	IsError   bool   `json:"is_error"`
 }
 
 // TokenUsage 代表token使用情况
@@ -56,18 +78,25 @@ type Content struct {
 	Text string `json:"text,omitempty"`
 }
 
-// SubscriptionQuota 订阅限额信息
+// SubscriptionQuota 订阅限额信息，用量按当前活跃的5小时窗口内的实际记录计算
 type SubscriptionQuota struct {
-	Plan              string // 订阅计划: Pro, Max5x, Max20x
-	MessagesPerWindow int    // 每个窗口的消息限制
-	EstimatedUsed     int    // 估算已使用消息数
-	Remaining         int    // 估算剩余消息数
-	ModelSwitchPoint  int    // 模型切换点（20%处）
-	DebugInfo         map[string]interface{} `json:"debug_info,omitempty"` // 调试信息
+	Plan              string     `json:"plan"`                   // 订阅计划: Pro, Max5x, Max20x
+	PlanSource        string     `json:"plan_source"`            // 计划来源: configured（配置或 --plan 指定）, estimated（按历史用量推测）
+	MessagesPerWindow int        `json:"messages_per_window"`    // 每个窗口的消息限制
+	TokensPerWindow   int        `json:"tokens_per_window"`      // 每个窗口的Token限制（输入+输出）
+	MessagesUsed      int        `json:"messages_used"`          // 当前窗口已发送的提问数
+	TokensUsed        int        `json:"tokens_used"`            // 当前窗口已使用的Token（输入+输出）
+	Remaining         int        `json:"remaining"`              // 当前窗口剩余消息数
+	TokensRemaining   int        `json:"tokens_remaining"`       // 当前窗口剩余Token
+	ModelSwitchPoint  int        `json:"model_switch_point"`     // 模型切换点（20%处）
+	WindowStart       *time.Time `json:"window_start,omitempty"` // 当前窗口开始时间，没有活跃窗口时为空
+	WindowEnd         *time.Time `json:"window_end,omitempty"`   // 当前窗口结束（重置）时间
+	DebugInfo         map[string]interface{} `json:"debug_info,omitempty"` // 推测计划时的调试信息
 }
 
 // UsageStats 代表统计结果
 type UsageStats struct {
+	SchemaInfo
 	TotalSessions       int                    `json:"total_sessions"`
 	TotalMessages       int                    `json:"total_messages"`
 	TotalTokens         TokenUsage             `json:"total_tokens"`
@@ -80,6 +109,26 @@ type UsageStats struct {
 	
 	// 新增：Claude Code 特定统计
 	ProjectStats        map[string]ProjectStats `json:"project_stats"`
+	BranchStats         map[string]BranchStats  `json:"branch_stats,omitempty"` // 键为 项目@分支
+	ToolStats           map[string]ToolStats    `json:"tool_stats,omitempty"`
+	UserStats           map[string]UserStats    `json:"user_stats,omitempty"` // 键为用户标签
+
+	// 子代理（sidechain）用量，与主线程分开统计
+	Sidechain           UsageBucket             `json:"sidechain"`
+	SidechainDaily      map[string]UsageBucket  `json:"sidechain_daily,omitempty"`
+
+	// 提示缓存统计，键为 日期|项目|会话|模型
+	CacheStats          map[string]CacheStats   `json:"cache_stats,omitempty"`
+
+	// 按小时（UTC，键为 2006-01-02T15）统计的用量，用于热力图等按时间分布的报告
+	HourlyStats         map[string]UsageBucket  `json:"hourly_stats,omitempty"`
+	// 按 小时|会话|模型 细分的用量（仅含有Token的记录），用于异常检测中的贡献分析
+	HourlyDetail        map[string]UsageBucket  `json:"-"`
+	// 按小时统计的用户提问数（不含工具结果），用于计算订阅窗口内的消息用量
+	HourlyPrompts       map[string]int          `json:"-"`
+
+	// 从消息树中计算出的时延样本（仅用于 latency 报告）
+	LatencySamples      []LatencySample         `json:"-"`
 	MessageTypes        map[string]int          `json:"message_types"`
 	ParsedMessages      int                     `json:"parsed_messages"`
 	ExtractedTokens     int                     `json:"extracted_tokens"`
@@ -96,8 +145,10 @@ type SessionInfo struct {
 	Duration     string     `json:"duration"`
 	MessageCount int        `json:"message_count"`
 	Tokens       TokenUsage `json:"tokens"`
+	Cost         float64    `json:"cost"`
 	Model        string     `json:"model"`
 	ProjectPath  string     `json:"project_path,omitempty"`
+	Sidechain    UsageBucket `json:"sidechain"` // 其中子代理的用量
 }
 
 // CostBreakdown 代表成本分解
@@ -120,13 +171,409 @@ type Period struct {
 }
 
 // ProjectStats 代表项目级别的统计
+// 项目以完整路径（或配置中的别名）作为唯一标识，不同目录下的同名项目不会合并
 type ProjectStats struct {
-	ProjectName  string     `json:"project_name"`
-	ProjectPath  string     `json:"project_path"`
-	SessionCount int        `json:"session_count"`
+	ProjectName   string                 `json:"project_name"`
+	ProjectPath   string                 `json:"project_path"`
+	Group         string                 `json:"group,omitempty"`
+	Paths         []string               `json:"paths,omitempty"` // 按分组聚合时包含的项目路径
+	SessionCount  int                    `json:"session_count"`
+	MessageCount  int                    `json:"message_count"`
+	Tokens        TokenUsage             `json:"tokens"`
+	Cost          float64                `json:"cost"`
+	Sidechain     UsageBucket            `json:"sidechain"`        // 其中子代理的用量
+	Models        map[string]UsageBucket `json:"models,omitempty"` // 模型使用分布
+	Daily         map[string]UsageBucket `json:"daily,omitempty"`  // 每日趋势，键为 YYYY-MM-DD
+	FirstActivity time.Time              `json:"first_activity"`
+	LastActivity  time.Time              `json:"last_activity"`
+
+	SessionIDs  map[string]bool `json:"-"` // 用于跨文件合并时去重会话
+	SourcePaths map[string]bool `json:"-"` // 别名合并的全部原始路径，成本分摊规则按这些路径匹配
+}
+
+// BranchStats 代表项目内某个Git分支的统计
+type BranchStats struct {
+	ProjectName   string      `json:"project_name"`
+	ProjectPath   string      `json:"project_path"`
+	Branch        string      `json:"branch"`
+	Repository    string      `json:"repository,omitempty"` // 关联提交时解析出的仓库根目录
+	SessionCount  int         `json:"session_count"`
+	MessageCount  int         `json:"message_count"`
+	Tokens        TokenUsage  `json:"tokens"`
+	Cost          float64     `json:"cost"`
+	FirstActivity time.Time   `json:"first_activity"`
+	LastActivity  time.Time   `json:"last_activity"`
+	Commits       []GitCommit `json:"commits,omitempty"`
+
+	// SessionWindows 记录每个会话在该分支上的活动时间段，用于关联提交
+	SessionWindows map[string]Period `json:"-"`
+}
+
+// GitCommit 代表与分支会话时间段关联的提交
+type GitCommit struct {
+	Hash    string    `json:"hash"`
+	Author  string    `json:"author"`
+	Time    time.Time `json:"time"`
+	Subject string    `json:"subject"`
+}
+
+// BranchesReport 分支报告结构
+type BranchesReport struct {
+	SchemaInfo
+	Type     string        `json:"type"`
+	Branches []BranchStats `json:"branches"`
+	Summary  BranchStats   `json:"summary"`
+}
+
+// ToolStats 代表单个工具的使用统计
+// Tokens/Cost 为发起调用的助手消息的用量；一条消息调用多个工具时按调用数平均分摊
+type ToolStats struct {
+	Name        string         `json:"name"`
+	CallCount   int            `json:"call_count"`
+	ResultCount int            `json:"result_count"`
+	ErrorCount  int            `json:"error_count"`
+	Tokens      TokenUsage     `json:"tokens"`
+	Cost        float64        `json:"cost"`
+	Projects    map[string]int `json:"projects,omitempty"` // 项目 -> 调用次数
+}
+
+// ToolsReport 工具报告结构
+type ToolsReport struct {
+	SchemaInfo
+	Type    string      `json:"type"`
+	Tools   []ToolStats `json:"tools"`
+	Summary ToolStats   `json:"summary"`
+}
+
+// CacheStats 代表某一维度下的提示缓存效率
+type CacheStats struct {
+	Name                string  `json:"name"`
+	MessageCount        int     `json:"message_count"`
+	InputTokens         int     `json:"input_tokens"`
+	CacheCreationTokens int     `json:"cache_creation_tokens"`
+	CacheReadTokens     int     `json:"cache_read_tokens"`
+	HitRatio            float64 `json:"hit_ratio"`         // 缓存读取占全部输入的比例
+	SavingsUSD          float64 `json:"savings_usd"`       // 缓存读取相比按输入全价计费节省的金额
+	WritePremiumUSD     float64 `json:"write_premium_usd"` // 缓存写入相比按输入全价多付的金额
+	NetSavingsUSD       float64 `json:"net_savings_usd"`
+	Wasted5mTokens      int     `json:"wasted_5m_tokens"` // 按5分钟TTL估算，过期前未被读取的缓存写入
+	Wasted5mUSD         float64 `json:"wasted_5m_usd"`
+	Wasted1hTokens      int     `json:"wasted_1h_tokens"` // 按1小时TTL估算
+	Wasted1hUSD         float64 `json:"wasted_1h_usd"`
+
+	// 明细维度，仅用于分组
+	Date      string `json:"-"`
+	Project   string `json:"-"`
+	SessionID string `json:"-"`
+	Model     string `json:"-"`
+}
+
+// Merge 合并另一组缓存统计并重新计算比例
+func (c *CacheStats) Merge(other CacheStats) {
+	c.MessageCount += other.MessageCount
+	c.InputTokens += other.InputTokens
+	c.CacheCreationTokens += other.CacheCreationTokens
+	c.CacheReadTokens += other.CacheReadTokens
+	c.SavingsUSD += other.SavingsUSD
+	c.WritePremiumUSD += other.WritePremiumUSD
+	c.Wasted5mTokens += other.Wasted5mTokens
+	c.Wasted5mUSD += other.Wasted5mUSD
+	c.Wasted1hTokens += other.Wasted1hTokens
+	c.Wasted1hUSD += other.Wasted1hUSD
+	c.Refresh()
+}
+
+// Refresh 根据累计值重新计算命中率和净节省
+func (c *CacheStats) Refresh() {
+	c.HitRatio = 0
+	if total := c.InputTokens + c.CacheCreationTokens + c.CacheReadTokens; total > 0 {
+		c.HitRatio = float64(c.CacheReadTokens) / float64(total)
+	}
+	c.NetSavingsUSD = c.SavingsUSD - c.WritePremiumUSD
+}
+
+// CacheReport 缓存效率报告结构
+type CacheReport struct {
+	SchemaInfo
+	Type      string       `json:"type"`
+	Dimension string       `json:"dimension"` // day, project, session, model
+	Items     []CacheStats `json:"items"`
+	Summary   CacheStats   `json:"summary"`
+}
+
+// HourKeyLayout UsageStats.HourlyStats 键的时间格式（UTC）
+const HourKeyLayout = "2006-01-02T15"
+
+// 时延样本的类型
+const (
+	LatencyResponse = "response"  // 用户提问到助手首次回复
+	LatencyToolLoop = "tool_loop" // 一轮对话中工具调用循环的持续时间
+	LatencyIdle     = "idle"      // 助手回复后到用户下一次提问的间隔
+)
+
+// LatencySample 单个时延样本
+type LatencySample struct {
+	Kind      string        `json:"kind"`
+	Model     string        `json:"model"`
+	Version   string        `json:"version"`
+	SessionID string        `json:"session_id"`
+	Time      time.Time     `json:"time"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// DurationStats 一组时长的分布（单位：秒）
+type DurationStats struct {
+	Count int     `json:"count"`
+	Mean  float64 `json:"mean_seconds"`
+	P50   float64 `json:"p50_seconds"`
+	P90   float64 `json:"p90_seconds"`
+	P95   float64 `json:"p95_seconds"`
+	P99   float64 `json:"p99_seconds"`
+	Max   float64 `json:"max_seconds"`
+}
+
+// LatencyStats 某个模型或版本的时延分布
+type LatencyStats struct {
+	Name     string        `json:"name"`
+	Response DurationStats `json:"response"`
+	ToolLoop DurationStats `json:"tool_loop"`
+	Idle     DurationStats `json:"idle"`
+}
+
+// LatencyReport 时延报告结构
+type LatencyReport struct {
+	SchemaInfo
+	Type      string         `json:"type"`
+	Dimension string         `json:"dimension"` // model, version
+	Items     []LatencyStats `json:"items"`
+	Summary   LatencyStats   `json:"summary"`
+}
+
+// HeatmapReport 星期 × 小时热力图
+type HeatmapReport struct {
+	SchemaInfo
+	Type     string         `json:"type"`
+	Metric   string         `json:"metric"`   // tokens, cost, messages
+	Timezone string         `json:"timezone"` // 统计所用的本地时区
+	Weekdays []string       `json:"weekdays"` // 行顺序，从周一开始
+	Matrix   [7][24]float64 `json:"matrix"`   // [星期][小时]
+	Max      float64        `json:"max"`
+	Total    float64        `json:"total"`
+}
+
+// CompareTotals 某个时间段内的用量合计
+type CompareTotals struct {
+	Tokens   int     `json:"tokens"`
+	CostUSD  float64 `json:"cost_usd"`
+	Sessions int     `json:"sessions"`
+	Messages int     `json:"messages"`
+}
+
+// CompareChange 相对于时间段A的百分比变化，A为0时为空
+type CompareChange struct {
+	Tokens   *float64 `json:"tokens_pct"`
+	CostUSD  *float64 `json:"cost_pct"`
+	Sessions *float64 `json:"sessions_pct"`
+	Messages *float64 `json:"messages_pct"`
+}
+
+// CompareItem 某个模型或项目在两个时间段之间的对比
+type CompareItem struct {
+	Name   string        `json:"name"`
+	A      CompareTotals `json:"a"`
+	B      CompareTotals `json:"b"`
+	Delta  CompareTotals `json:"delta"` // B - A
+	Change CompareChange `json:"change"`
+	Share  float64       `json:"share_of_cost_change"` // 占总成本变化的比例
+}
+
+// CompareReport 两个时间段的对比报告
+type CompareReport struct {
+	SchemaInfo
+	Type     string        `json:"type"`
+	PeriodA  Period        `json:"period_a"`
+	PeriodB  Period        `json:"period_b"`
+	Summary  CompareItem   `json:"summary"`
+	Models   []CompareItem `json:"models"`
+	Projects []CompareItem `json:"projects"`
+}
+
+// 预算状态
+const (
+	BudgetNone      = "none"           // 未配置预算
+	BudgetUnder     = "under"          // 置信区间上限也在预算内
+	BudgetAtRisk    = "at_risk"        // 预测值在预算内，但置信区间上限超出
+	BudgetProjected = "projected_over" // 预测值超出预算
+	BudgetOver      = "over"           // 实际花费已超出预算
+)
+
+// ForecastPoint 成本预测中的单日数据（历史日只有实际值，未来日只有预测值，今天两者都有）
+type ForecastPoint struct {
+	Date      string   `json:"date"`
+	ActualUSD *float64 `json:"actual_usd,omitempty"`
+	Forecast  *float64 `json:"forecast_usd,omitempty"`
+	Lower     *float64 `json:"lower_usd,omitempty"`
+	Upper     *float64 `json:"upper_usd,omitempty"`
+}
+
+// ForecastTarget 某个周期（月末、季末）的成本预测
+type ForecastTarget struct {
+	Name          string  `json:"name"` // month, quarter
+	StartDate     string  `json:"start_date"`
+	EndDate       string  `json:"end_date"`
+	RemainingDays int     `json:"remaining_days"`
+	ActualUSD     float64 `json:"actual_usd"`    // 截至今天的实际花费
+	LinearUSD     float64 `json:"linear_usd"`    // 线性趋势预测
+	SeasonalUSD   float64 `json:"seasonal_usd"`  // 按星期平均预测
+	ProjectedUSD  float64 `json:"projected_usd"` // 趋势 × 星期系数的组合预测
+	LowerUSD      float64 `json:"lower_usd"`
+	UpperUSD      float64 `json:"upper_usd"`
+	BudgetUSD     float64 `json:"budget_usd,omitempty"`
+	BudgetStatus  string  `json:"budget_status"`
+	CrossDate     string  `json:"budget_cross_date,omitempty"` // 预测累计花费超出预算的日期
+}
+
+// ForecastReport 成本预测报告
+type ForecastReport struct {
+	SchemaInfo
+	Type        string           `json:"type"`
+	AsOf        string           `json:"as_of"`
+	HistoryDays int              `json:"history_days"`
+	Confidence  float64          `json:"confidence"`
+	Trend       float64          `json:"trend_usd_per_day"` // 线性趋势斜率
+	Weekday     [7]float64       `json:"weekday_factors"`   // 星期系数，从周一开始
+	Targets     []ForecastTarget `json:"targets"`
+	Series      []ForecastPoint  `json:"series"`
+}
+
+// 异常检测的对象类型
+const (
+	AnomalyDay     = "day"
+	AnomalySession = "session"
+	AnomalyBlock   = "block"
+)
+
+// AnomalyContributor 对异常贡献最大的会话或模型
+type AnomalyContributor struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+	Share float64 `json:"share"` // 占观测值的比例
+}
+
+// Anomaly 一条异常记录
+type Anomaly struct {
+	Kind         string              `json:"kind"` // day, session, block
+	ID           string              `json:"id"`
+	Start        time.Time           `json:"start"`
+	End          time.Time           `json:"end"`
+	Project      string              `json:"project,omitempty"`
+	Observed     float64             `json:"observed"`
+	Baseline     float64             `json:"baseline"` // 基线中位数
+	MAD          float64             `json:"mad"`      // 基线的中位数绝对偏差
+	Score        float64             `json:"score"`    // 稳健z分数
+	Ratio        float64             `json:"ratio"`    // 观测值 / 基线中位数
+	BaselineSize int                 `json:"baseline_size"`
+	TopSession   *AnomalyContributor `json:"top_session,omitempty"`
+	TopModel     *AnomalyContributor `json:"top_model,omitempty"`
+}
+
+// AnomalyReport 异常检测报告
+type AnomalyReport struct {
+	SchemaInfo
+	Type       string         `json:"type"`
+	Metric     string         `json:"metric"` // tokens, cost
+	Threshold  float64        `json:"threshold"`
+	WindowDays int            `json:"window_days"`
+	Checked    map[string]int `json:"checked"` // 各类型参与检测的数量
+	Anomalies  []Anomaly      `json:"anomalies"`
+}
+
+// UsageBucket 代表某一维度下的用量汇总
+type UsageBucket struct {
 	Tokens       TokenUsage `json:"tokens"`
-	Cost         float64    `json:"cost"`
-	LastActivity time.Time  `json:"last_activity"`
+	CostUSD      float64    `json:"cost_usd"`
+	MessageCount int        `json:"message_count"`
+}
+
+// UserStats 代表团队成员（用户标签）级别的统计
+type UserStats struct {
+	User          string                 `json:"user"`
+	Hosts         []string               `json:"hosts,omitempty"`
+	SessionCount  int                    `json:"session_count"`
+	MessageCount  int                    `json:"message_count"`
+	ProjectCount  int                    `json:"project_count"`
+	Tokens        TokenUsage             `json:"tokens"`
+	Cost          float64                `json:"cost"`
+	Sidechain     UsageBucket            `json:"sidechain"`        // 其中子代理的用量
+	Models        map[string]UsageBucket `json:"models,omitempty"` // 模型使用分布
+	FirstActivity time.Time              `json:"first_activity"`
+	LastActivity  time.Time              `json:"last_activity"`
+
+	SessionIDs  map[string]bool `json:"-"` // 用于跨文件合并时去重会话
+	ProjectKeys map[string]bool `json:"-"` // 用于跨文件合并时去重项目
+}
+
+// UsersReport 用户报告结构
+type UsersReport struct {
+	SchemaInfo
+	Type    string      `json:"type"`
+	Users   []UserStats `json:"users"`
+	Summary UserStats   `json:"summary"`
+}
+
+// ChargebackLine 成本分摊明细：某月某项目按规则分到某个成本中心的金额
+// CostCenter 为空表示未分摊（没有匹配规则，或拆分比例合计不足100%的剩余部分）
+type ChargebackLine struct {
+	Month       string  `json:"month"` // YYYY-MM
+	CostCenter  string  `json:"cost_center"`
+	Rule        string  `json:"rule,omitempty"`
+	ProjectName string  `json:"project_name"`
+	ProjectPath string  `json:"project_path"`
+	Remote      string  `json:"remote,omitempty"`
+	Percent     float64 `json:"percent"`
+	Cost        float64 `json:"cost"`
+}
+
+// ChargebackCenter 某个成本中心的分摊汇总
+type ChargebackCenter struct {
+	CostCenter string  `json:"cost_center"`
+	Projects   int     `json:"projects"`
+	Cost       float64 `json:"cost"`
+}
+
+// ChargebackMonth 某月（或全部月份）的分摊汇总
+type ChargebackMonth struct {
+	Month           string             `json:"month"`
+	TotalCost       float64            `json:"total_cost"`
+	AllocatedCost   float64            `json:"allocated_cost"`
+	UnallocatedCost float64            `json:"unallocated_cost"`
+	Centers         []ChargebackCenter `json:"centers"`
+}
+
+// ChargebackRuleHit 分摊规则的命中情况
+type ChargebackRuleHit struct {
+	Rule     string  `json:"rule"`
+	Projects int     `json:"projects"`
+	Cost     float64 `json:"cost"` // 命中项目的成本（拆分前）
+}
+
+// ChargebackReport 成本分摊报告结构
+type ChargebackReport struct {
+	SchemaInfo
+	Type         string              `json:"type"`
+	PriceVersion string              `json:"price_version"`
+	Months       []ChargebackMonth   `json:"months"`
+	Rules        []ChargebackRuleHit `json:"rules"`
+	Lines        []ChargebackLine    `json:"lines"`
+	Summary      ChargebackMonth     `json:"summary"`
+}
+
+// ProjectsReport 项目报告结构
+type ProjectsReport struct {
+	SchemaInfo
+	Type     string         `json:"type"`
+	Projects []ProjectStats `json:"projects"`
+	Summary  ProjectStats   `json:"summary"`
 }
 
 // BillingBlock 代表5小时计费窗口
@@ -148,6 +595,7 @@ type BillingBlock struct {
 
 // BlocksReport 代表blocks报告
 type BlocksReport struct {
+	SchemaInfo
 	Blocks   []BillingBlock `json:"blocks"`
 	Summary  TokenUsage     `json:"summary"`
 	TotalCost float64       `json:"total_cost"`
@@ -155,6 +603,7 @@ type BlocksReport struct {
 
 // DailyReport 日报告结构
 type DailyReport struct {
+	SchemaInfo
 	Type      string            `json:"type"`
 	DailyData []DailyDataPoint  `json:"data"`
 	Summary   DailyDataPoint    `json:"summary"`
@@ -173,6 +622,7 @@ type DailyDataPoint struct {
 	MessageCount            int                       `json:"message_count"`
 	SessionCount            int                       `json:"session_count"`
 	Breakdown               map[string]DailyModelData `json:"breakdown,omitempty"`
+	Sidechain               DailyModelData            `json:"sidechain"` // 其中子代理的用量
 }
 
 // DailyModelData 每日模型数据
@@ -186,6 +636,97 @@ type DailyModelData struct {
 	MessageCount        int     `json:"message_count"`
 }
 
+// UsageRecord 导出用的单条用量记录（每条带 usage 的日志条目对应一条）
+type UsageRecord struct {
+	Timestamp           time.Time `json:"timestamp"`
+	SessionID           string    `json:"session_id"`
+	RequestID           string    `json:"request_id,omitempty"`
+	ProjectPath         string    `json:"project_path"`
+	GitBranch           string    `json:"git_branch,omitempty"`
+	Model               string    `json:"model"`
+	InputTokens         int       `json:"input_tokens"`
+	OutputTokens        int       `json:"output_tokens"`
+	CacheCreationTokens int       `json:"cache_creation_tokens"`
+	CacheReadTokens     int       `json:"cache_read_tokens"`
+	CostUSD             float64   `json:"cost_usd"`
+	PriceVersion        string    `json:"price_version"`
+	IsSidechain         bool      `json:"is_sidechain"`
+	// 团队快照中记录所属的用户标签和主机名
+	User string `json:"user,omitempty"`
+	Host string `json:"host,omitempty"`
+}
+
+// Snapshot 可在团队成员之间传递的用量快照（export --write-snapshot 生成）
+type Snapshot struct {
+	SchemaInfo
+	Type      string        `json:"type"`
+	Version   int           `json:"version"`
+	User      string        `json:"user"`
+	Hostname  string        `json:"hostname"`
+	CreatedAt time.Time     `json:"created_at"`
+	Entries   []UsageRecord `json:"entries"`
+}
+
+// Key 返回用于去重的键：优先使用 requestId，旧日志缺少 requestId 时使用 会话ID|时间戳
+func (r UsageRecord) Key() string {
+	if r.RequestID != "" {
+		return r.RequestID
+	}
+	return r.SessionID + "|" + r.Timestamp.UTC().Format(time.RFC3339Nano)
+}
+
+// UsageRollup 导出用的每日汇总记录（按日期、项目、模型和是否子代理分组）
+type UsageRollup struct {
+	Date                string  `json:"date"`
+	ProjectPath         string  `json:"project_path"`
+	Model               string  `json:"model"`
+	IsSidechain         bool    `json:"is_sidechain"`
+	Entries             int     `json:"entries"`
+	InputTokens         int     `json:"input_tokens"`
+	OutputTokens        int     `json:"output_tokens"`
+	CacheCreationTokens int     `json:"cache_creation_tokens"`
+	CacheReadTokens     int     `json:"cache_read_tokens"`
+	CostUSD             float64 `json:"cost_usd"`
+	PriceVersion        string  `json:"price_version"`
+}
+
+// QueryResult query 命令的结果集，Rows 中每行的值与 Columns 一一对应
+type QueryResult struct {
+	SchemaInfo
+	Type    string          `json:"type"`
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// CCUsageVerifyRow ccusage 报告中一个统计周期与本工具计算结果的对比
+type CCUsageVerifyRow struct {
+	Period        string    `json:"period"` // 日期、月份、会话ID或窗口开始时间
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+	CCUsageTokens int       `json:"ccusage_tokens"`
+	Tokens        int       `json:"tokens"`
+	TokenDiff     int       `json:"token_diff"`
+	CCUsageCost   float64   `json:"ccusage_cost_usd"`
+	CostUSD       float64   `json:"cost_usd"`
+	CostDiff      float64   `json:"cost_diff_usd"`
+	CostDiffPct   float64   `json:"cost_diff_pct"`
+	Entries       int       `json:"entries"` // 本工具在该周期内统计到的记录数
+	Mismatch      bool      `json:"mismatch"`
+}
+
+// CCUsageVerifyReport import-ccusage --verify 的对比报告
+type CCUsageVerifyReport struct {
+	SchemaInfo
+	Type         string             `json:"type"`
+	Kind         string             `json:"kind"` // daily/monthly/session/blocks
+	Source       string             `json:"source"`
+	Tolerance    float64            `json:"tolerance_pct"`
+	PriceVersion string             `json:"price_version"`
+	Rows         []CCUsageVerifyRow `json:"rows"`
+	Summary      CCUsageVerifyRow   `json:"summary"`
+	Mismatches   int                `json:"mismatches"`
+}
+
 // GetTotalTokens 计算总token数
 func (u *TokenUsage) GetTotalTokens() int {
 	if u.TotalTokens > 0 {
@@ -200,7 +741,266 @@ func (u *TokenUsage) Add(other TokenUsage) {
 	u.OutputTokens += other.OutputTokens
 	u.CacheCreationTokens += other.CacheCreationTokens
 	u.CacheReadTokens += other.CacheReadTokens
-	u.TotalTokens = u.GetTotalTokens()
+	// 总数按累加后的输入+输出重新计算，避免沿用第一次累加时的旧值
+	u.TotalTokens = u.InputTokens + u.OutputTokens
+}
+
+// Add 累加一条记录的用量
+func (b *UsageBucket) Add(usage TokenUsage, cost float64) {
+	b.Tokens.Add(usage)
+	b.CostUSD += cost
+	b.MessageCount++
+}
+
+// Merge 合并另一个用量汇总
+func (b *UsageBucket) Merge(other UsageBucket) {
+	b.Tokens.Add(other.Tokens)
+	b.CostUSD += other.CostUSD
+	b.MessageCount += other.MessageCount
+}
+
+// AddSession 记录项目中的会话，重复的会话只计数一次
+func (p *ProjectStats) AddSession(sessionID string) {
+	if sessionID == "" {
+		return
+	}
+	if p.SessionIDs == nil {
+		p.SessionIDs = make(map[string]bool)
+	}
+	if !p.SessionIDs[sessionID] {
+		p.SessionIDs[sessionID] = true
+		p.SessionCount++
+	}
+}
+
+// AddPath 记录项目包含的原始路径（配置了别名时一个项目可能对应多个路径）
+func (p *ProjectStats) AddPath(path string) {
+	if path == "" {
+		return
+	}
+	if p.SourcePaths == nil {
+		p.SourcePaths = make(map[string]bool)
+	}
+	p.SourcePaths[path] = true
+}
+
+// AllPaths 返回项目包含的全部原始路径，ProjectPath 在最前
+func (p *ProjectStats) AllPaths() []string {
+	paths := []string{p.ProjectPath}
+	others := make([]string, 0, len(p.SourcePaths))
+	for path := range p.SourcePaths {
+		if path != p.ProjectPath {
+			others = append(others, path)
+		}
+	}
+	sort.Strings(others)
+	return append(paths, others...)
+}
+
+// Merge 合并另一份项目统计（累加而非覆盖）
+func (p *ProjectStats) Merge(other ProjectStats) {
+	for sessionID := range other.SessionIDs {
+		p.AddSession(sessionID)
+	}
+	for path := range other.SourcePaths {
+		p.AddPath(path)
+	}
+	if len(other.SessionIDs) == 0 {
+		p.SessionCount += other.SessionCount
+	}
+	p.MessageCount += other.MessageCount
+	p.Tokens.Add(other.Tokens)
+	p.Cost += other.Cost
+	p.Sidechain.Merge(other.Sidechain)
+
+	if len(other.Models) > 0 && p.Models == nil {
+		p.Models = make(map[string]UsageBucket)
+	}
+	for model, bucket := range other.Models {
+		target := p.Models[model]
+		target.Merge(bucket)
+		p.Models[model] = target
+	}
+
+	if len(other.Daily) > 0 && p.Daily == nil {
+		p.Daily = make(map[string]UsageBucket)
+	}
+	for date, bucket := range other.Daily {
+		target := p.Daily[date]
+		target.Merge(bucket)
+		p.Daily[date] = target
+	}
+
+	if !other.FirstActivity.IsZero() && (p.FirstActivity.IsZero() || other.FirstActivity.Before(p.FirstActivity)) {
+		p.FirstActivity = other.FirstActivity
+	}
+	if other.LastActivity.After(p.LastActivity) {
+		p.LastActivity = other.LastActivity
+	}
+}
+
+// Merge 合并同一会话在其他文件中的数据（如单独存放的子代理记录）
+func (s *SessionInfo) Merge(other SessionInfo) {
+	s.MessageCount += other.MessageCount
+	s.Tokens.Add(other.Tokens)
+	s.Cost += other.Cost
+	s.Sidechain.Merge(other.Sidechain)
+	if other.StartTime.Before(s.StartTime) {
+		s.StartTime = other.StartTime
+	}
+	if other.EndTime.After(s.EndTime) {
+		s.EndTime = other.EndTime
+	}
+	if s.Model == "" {
+		s.Model = other.Model
+	}
+	if s.ProjectPath == "" {
+		s.ProjectPath = other.ProjectPath
+	}
+	s.Duration = s.EndTime.Sub(s.StartTime).String()
+}
+
+// AddActivity 记录分支上某个会话的一次活动
+func (b *BranchStats) AddActivity(sessionID string, timestamp time.Time) {
+	if b.FirstActivity.IsZero() || timestamp.Before(b.FirstActivity) {
+		b.FirstActivity = timestamp
+	}
+	if timestamp.After(b.LastActivity) {
+		b.LastActivity = timestamp
+	}
+
+	if sessionID == "" {
+		return
+	}
+	if b.SessionWindows == nil {
+		b.SessionWindows = make(map[string]Period)
+	}
+	window, exists := b.SessionWindows[sessionID]
+	if !exists {
+		window = Period{StartTime: timestamp, EndTime: timestamp}
+		b.SessionCount++
+	}
+	if timestamp.Before(window.StartTime) {
+		window.StartTime = timestamp
+	}
+	if timestamp.After(window.EndTime) {
+		window.EndTime = timestamp
+	}
+	b.SessionWindows[sessionID] = window
+}
+
+// Merge 合并另一份分支统计
+func (b *BranchStats) Merge(other BranchStats) {
+	for sessionID, window := range other.SessionWindows {
+		b.AddActivity(sessionID, window.StartTime)
+		b.AddActivity(sessionID, window.EndTime)
+	}
+	if len(other.SessionWindows) == 0 {
+		b.SessionCount += other.SessionCount
+		if !other.FirstActivity.IsZero() {
+			b.AddActivity("", other.FirstActivity)
+			b.AddActivity("", other.LastActivity)
+		}
+	}
+	b.MessageCount += other.MessageCount
+	b.Tokens.Add(other.Tokens)
+	b.Cost += other.Cost
+	b.Commits = append(b.Commits, other.Commits...)
+}
+
+// AddActivity 记录用户在某个会话、项目和主机上的一次活动
+func (u *UserStats) AddActivity(sessionID, projectKey, host string, t time.Time) {
+	if sessionID != "" {
+		if u.SessionIDs == nil {
+			u.SessionIDs = make(map[string]bool)
+		}
+		if !u.SessionIDs[sessionID] {
+			u.SessionIDs[sessionID] = true
+			u.SessionCount++
+		}
+	}
+	if projectKey != "" {
+		if u.ProjectKeys == nil {
+			u.ProjectKeys = make(map[string]bool)
+		}
+		if !u.ProjectKeys[projectKey] {
+			u.ProjectKeys[projectKey] = true
+			u.ProjectCount++
+		}
+	}
+	if host != "" {
+		found := false
+		for _, existing := range u.Hosts {
+			if existing == host {
+				found = true
+				break
+			}
+		}
+		if !found {
+			u.Hosts = append(u.Hosts, host)
+		}
+	}
+	if !t.IsZero() {
+		if u.FirstActivity.IsZero() || t.Before(u.FirstActivity) {
+			u.FirstActivity = t
+		}
+		if t.After(u.LastActivity) {
+			u.LastActivity = t
+		}
+	}
+}
+
+// Merge 合并同一用户的另一份统计
+func (u *UserStats) Merge(other UserStats) {
+	for sessionID := range other.SessionIDs {
+		u.AddActivity(sessionID, "", "", time.Time{})
+	}
+	for projectKey := range other.ProjectKeys {
+		u.AddActivity("", projectKey, "", time.Time{})
+	}
+	for _, host := range other.Hosts {
+		u.AddActivity("", "", host, time.Time{})
+	}
+	u.AddActivity("", "", "", other.FirstActivity)
+	u.AddActivity("", "", "", other.LastActivity)
+
+	u.MessageCount += other.MessageCount
+	u.Tokens.Add(other.Tokens)
+	u.Cost += other.Cost
+	u.Sidechain.Merge(other.Sidechain)
+
+	if len(other.Models) > 0 && u.Models == nil {
+		u.Models = make(map[string]UsageBucket)
+	}
+	for model, bucket := range other.Models {
+		existing := u.Models[model]
+		existing.Merge(bucket)
+		u.Models[model] = existing
+	}
+}
+
+// ErrorRate 返回工具调用的错误率（0-1）
+func (t *ToolStats) ErrorRate() float64 {
+	if t.CallCount == 0 {
+		return 0
+	}
+	return float64(t.ErrorCount) / float64(t.CallCount)
+}
+
+// Merge 合并另一份工具统计
+func (t *ToolStats) Merge(other ToolStats) {
+	t.CallCount += other.CallCount
+	t.ResultCount += other.ResultCount
+	t.ErrorCount += other.ErrorCount
+	t.Tokens.Add(other.Tokens)
+	t.Cost += other.Cost
+
+	if len(other.Projects) > 0 && t.Projects == nil {
+		t.Projects = make(map[string]int)
+	}
+	for project, calls := range other.Projects {
+		t.Projects[project] += calls
+	}
 }
 
 // IsEmpty 检查是否为空的使用统计
@@ -209,12 +1009,16 @@ func (u *TokenUsage) IsEmpty() bool {
 		   u.CacheCreationTokens == 0 && u.CacheReadTokens == 0
 } 
 
-// EstimateSubscriptionQuota 根据使用情况估算订阅限额
-func (u *UsageStats) EstimateSubscriptionQuota() *SubscriptionQuota {
-	if u.DetectedMode != "subscription" {
-		return nil
-	}
-	
+// 订阅计划名称
+const (
+	PlanPro    = "Pro"
+	PlanMax5x  = "Max5x"
+	PlanMax20x = "Max20x"
+)
+
+// EstimatePlan 未指定订阅计划时，根据平均每个窗口的用户消息数和成本推测计划类型
+// 只是粗略的回退方案，结果会标记为推测值；返回的调试信息说明推测依据
+func (u *UsageStats) EstimatePlan() (string, map[string]interface{}) {
 	// 只计算用户消息数作为真实请求数
 	userMessages := 0
 	if u.MessageTypes != nil {
@@ -236,116 +1040,23 @@ func (u *UsageStats) EstimateSubscriptionQuota() *SubscriptionQuota {
 		windowCount = 1
 	}
 	
-	// 基于成本和用户消息数推断计划类型
-	plan := "Pro"
-	messagesPerWindow := 45
-	modelSwitchPoint := 9
-	
-	// 智能计划检测：同时考虑成本和消息数模式
 	avgMessagesPerWindow := float64(userMessages) / float64(windowCount)
 	
-	// 关键逻辑：基于平均使用量反推真实的计划限制
-	// 特别注意：如果数据是工作期间保存的，可能不完整
-	
-	// 重要修正：如果平均使用量很高，可能说明用户是小计划但经常达到限额
+	// 平均使用量远超 Pro 限制(45条)时更可能是经常触及限额的 Pro 用户，
+	// 只有极高的使用量或中等使用量伴随高成本时才判断为 Max 计划
+	plan := PlanPro
 	if avgMessagesPerWindow > 500 {
-		// 极高使用量，肯定是Max20x
-		plan = "Max20x"
-		messagesPerWindow = 900
-		modelSwitchPoint = 180
+		plan = PlanMax20x
 	} else if avgMessagesPerWindow > 250 {
-		// 高使用量，可能是Max5x
-		plan = "Max5x"
-		messagesPerWindow = 225
-		modelSwitchPoint = 45
-	} else if avgMessagesPerWindow > 60 {
-		// 中等使用量，可能是小计划用户经常达到限额
-		// 关键假设：如果平均使用量远超Pro限制(45条)，很可能是Pro用户经常限额
-		if avgMessagesPerWindow > 300 && u.EstimatedCost.TotalCost > 250 {
-			// 极高使用量且高成本，可能是Max5x
-			plan = "Max5x"
-			messagesPerWindow = 225
-			modelSwitchPoint = 45
-		} else {
-			// 中高使用量，更可能是Pro用户经常达到45条限制
-			// 特别是如果数据跨越多个窗口（工作期间保存）
-			plan = "Pro"
-			messagesPerWindow = 45
-			modelSwitchPoint = 9
-		}
-	} else if avgMessagesPerWindow > 30 {
-		// 中低使用量，基于成本判断
-		if u.EstimatedCost.TotalCost > 80 {
-			plan = "Max5x"
-			messagesPerWindow = 225
-			modelSwitchPoint = 45
-		} else {
-			plan = "Pro"
-			messagesPerWindow = 45
-			modelSwitchPoint = 9
-		}
-	} else {
-		// 低使用量，很可能是Pro用户
-		plan = "Pro"
-		messagesPerWindow = 45
-		modelSwitchPoint = 9
+		plan = PlanMax5x
+	} else if avgMessagesPerWindow > 30 && avgMessagesPerWindow <= 60 && u.EstimatedCost.TotalCost > 80 {
+		plan = PlanMax5x
 	}
 	
-	// 智能推断当前窗口使用情况
-	// 特别考虑：Pro用户平均234条/窗口说明经常达到45条限制
-	
-	var currentWindowUsed int
-	
-	// 情况分析：
-	if avgMessagesPerWindow >= float64(messagesPerWindow)*5 {
-		// 平均使用量是限制的5倍以上，用户肯定是重度用户，经常限额
-		currentWindowUsed = messagesPerWindow // 当前窗口已满
-	} else if avgMessagesPerWindow >= float64(messagesPerWindow)*3 {
-		// 平均使用量是限制的3倍以上，用户经常达到限额
-		currentWindowUsed = messagesPerWindow // 当前窗口已满  
-	} else if avgMessagesPerWindow >= float64(messagesPerWindow)*2 {
-		// 平均使用量是限制的2倍以上，用户很可能当前已限额
-		currentWindowUsed = messagesPerWindow // 当前窗口已满
-	} else if avgMessagesPerWindow >= float64(messagesPerWindow) {
-		// 平均使用量达到或超过计划限制，用户肯定经常限额
-		currentWindowUsed = messagesPerWindow // 当前窗口已满
-	} else if avgMessagesPerWindow >= float64(messagesPerWindow)*0.9 {
-		// 平均使用量接近限制（90%+），用户很可能当前已限额
-		currentWindowUsed = messagesPerWindow // 当前窗口已满
-	} else if avgMessagesPerWindow >= float64(messagesPerWindow)*0.7 {
-		// 平均使用量较高（70-90%），用户当前可能接近限额
-		currentWindowUsed = int(float64(messagesPerWindow) * 0.95) // 95%使用率
-	} else if avgMessagesPerWindow >= float64(messagesPerWindow)*0.5 {
-		// 中度使用（50-70%），稍微高估当前使用
-		currentWindowUsed = int(avgMessagesPerWindow * 1.3)
-	} else {
-		// 轻度使用，使用平均值
-		currentWindowUsed = int(avgMessagesPerWindow)
+	return plan, map[string]interface{}{
+		"total_user_messages": userMessages,
+		"window_count":        windowCount,
+		"avg_per_window":      avgMessagesPerWindow,
+		"duration_hours":      duration.Hours(),
 	}
-	
-	// 确保不超过限制
-	if currentWindowUsed > messagesPerWindow {
-		currentWindowUsed = messagesPerWindow
-	}
-	
-	// 计算剩余数量
-	remaining := messagesPerWindow - currentWindowUsed
-	if remaining < 0 {
-		remaining = 0
-	}
-	
-	return &SubscriptionQuota{
-		Plan:              plan,
-		MessagesPerWindow: messagesPerWindow,
-		EstimatedUsed:     currentWindowUsed,
-		Remaining:         remaining,
-		ModelSwitchPoint:  modelSwitchPoint,
-		// 添加调试信息
-		DebugInfo: map[string]interface{}{
-			"total_user_messages": userMessages,
-			"window_count":        windowCount,
-			"avg_per_window":      avgMessagesPerWindow,
-			"duration_hours":      duration.Hours(),
-		},
-	}
-} 
\ No newline at end of file
+}
@@ -0,0 +1,300 @@
+package parser
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// anomalyMinBaseline 基线中至少需要的样本数，样本太少时不做判断
+const anomalyMinBaseline = 5
+
+// 稳健z分数的换算系数：正态分布下 MAD ≈ 0.6745σ，平均绝对偏差 ≈ 0.7979σ
+const (
+	madScale    = 0.6745
+	meanADScale = 0.7979
+)
+
+// AnomalyOptions 异常检测参数
+type AnomalyOptions struct {
+	Metric     string   // tokens, cost, messages
+	Threshold  float64  // 稳健z分数阈值
+	WindowDays int      // 滚动基线的天数
+	Kinds      []string // 检测的对象类型，为空时检测全部
+	Blocks     []models.BillingBlock
+}
+
+// usageItem 参与异常检测的一个对象（某天、某会话或某计费窗口）
+type usageItem struct {
+	id       string
+	start    time.Time
+	end      time.Time
+	project  string
+	value    float64
+	sessions map[string]float64
+	models   map[string]float64
+}
+
+// add 累加一条 小时|会话|模型 明细
+func (item *usageItem) add(session, model string, value float64) {
+	item.value += value
+	if session != "" {
+		item.sessions[session] += value
+	}
+	item.models[model] += value
+}
+
+// hourlyDetail 解析后的按小时明细
+type hourlyDetail struct {
+	hour    time.Time
+	session string
+	model   string
+	value   float64
+}
+
+// AnalyzeAnomalies 找出用量远超滚动基线的天、会话和计费窗口
+// 基线为此前 WindowDays 天内同类对象（仅统计有用量的对象）的中位数，
+// 分数为稳健z分数 0.6745 × (x − 中位数) / MAD；只报告高于基线的异常
+func (p *ClaudeParser) AnalyzeAnomalies(stats *models.UsageStats, options AnomalyOptions) (*models.AnomalyReport, error) {
+	metric := strings.ToLower(options.Metric)
+	if metric == "" {
+		metric = MetricCost
+	}
+	if _, err := BucketMetric(models.UsageBucket{}, metric); err != nil {
+		return nil, err
+	}
+
+	kinds := map[string]bool{}
+	for _, kind := range options.Kinds {
+		switch kind = strings.ToLower(strings.TrimSpace(kind)); kind {
+		case models.AnomalyDay, models.AnomalySession, models.AnomalyBlock:
+			kinds[kind] = true
+		case "":
+		default:
+			return nil, i18n.Errorf("err.unsupported_anomaly_kind", kind)
+		}
+	}
+	if len(kinds) == 0 {
+		kinds = map[string]bool{models.AnomalyDay: true, models.AnomalySession: true, models.AnomalyBlock: true}
+	}
+
+	details := make([]hourlyDetail, 0, len(stats.HourlyDetail))
+	for key, bucket := range stats.HourlyDetail {
+		parts := strings.SplitN(key, "|", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		hour, err := time.ParseInLocation(models.HourKeyLayout, parts[0], time.UTC)
+		if err != nil {
+			continue
+		}
+		value, _ := BucketMetric(bucket, metric)
+		details = append(details, hourlyDetail{hour: hour, session: parts[1], model: parts[2], value: value})
+	}
+
+	report := &models.AnomalyReport{
+		Type:       "anomalies",
+		Metric:     metric,
+		Threshold:  options.Threshold,
+		WindowDays: options.WindowDays,
+		Checked:    make(map[string]int),
+		Anomalies:  []models.Anomaly{},
+	}
+
+	groups := map[string][]*usageItem{}
+	if kinds[models.AnomalyDay] {
+		groups[models.AnomalyDay] = dayItems(details)
+	}
+	if kinds[models.AnomalySession] {
+		groups[models.AnomalySession] = sessionItems(details, stats.SessionStats)
+	}
+	if kinds[models.AnomalyBlock] {
+		groups[models.AnomalyBlock] = blockItems(details, options.Blocks)
+	}
+
+	window := time.Duration(options.WindowDays) * 24 * time.Hour
+	for kind, items := range groups {
+		report.Checked[kind] = len(items)
+		report.Anomalies = append(report.Anomalies, detectAnomalies(kind, items, window, options.Threshold)...)
+	}
+
+	sort.SliceStable(report.Anomalies, func(i, j int) bool {
+		return report.Anomalies[i].Score > report.Anomalies[j].Score
+	})
+
+	return report, nil
+}
+
+// newUsageItem 创建检测对象
+func newUsageItem(id string, start, end time.Time) *usageItem {
+	return &usageItem{
+		id:       id,
+		start:    start,
+		end:      end,
+		sessions: make(map[string]float64),
+		models:   make(map[string]float64),
+	}
+}
+
+// dayItems 按UTC日期汇总明细
+func dayItems(details []hourlyDetail) []*usageItem {
+	byDay := make(map[string]*usageItem)
+	for _, detail := range details {
+		day := detail.hour.Truncate(24 * time.Hour)
+		key := day.Format("2006-01-02")
+		item, ok := byDay[key]
+		if !ok {
+			item = newUsageItem(key, day, day.Add(24*time.Hour))
+			byDay[key] = item
+		}
+		item.add(detail.session, detail.model, detail.value)
+	}
+	return sortedItems(byDay)
+}
+
+// sessionItems 按会话汇总明细
+func sessionItems(details []hourlyDetail, sessions map[string]models.SessionInfo) []*usageItem {
+	bySession := make(map[string]*usageItem)
+	for _, detail := range details {
+		if detail.session == "" {
+			continue
+		}
+		item, ok := bySession[detail.session]
+		if !ok {
+			item = newUsageItem(detail.session, detail.hour, detail.hour.Add(time.Hour))
+			if session, exists := sessions[detail.session]; exists {
+				item.start, item.end = session.StartTime, session.EndTime
+				item.project = session.ProjectPath
+			}
+			bySession[detail.session] = item
+		}
+		item.add("", detail.model, detail.value)
+	}
+	return sortedItems(bySession)
+}
+
+// blockItems 按5小时计费窗口汇总明细（窗口边界与 blocks 命令一致）
+func blockItems(details []hourlyDetail, blocks []models.BillingBlock) []*usageItem {
+	byHour := make(map[time.Time][]hourlyDetail)
+	for _, detail := range details {
+		byHour[detail.hour] = append(byHour[detail.hour], detail)
+	}
+
+	byBlock := make(map[string]*usageItem)
+	for _, block := range blocks {
+		item := newUsageItem(block.ID, block.StartTime, block.EndTime)
+		for hour := block.StartTime.UTC().Truncate(time.Hour); hour.Before(block.EndTime); hour = hour.Add(time.Hour) {
+			for _, detail := range byHour[hour] {
+				item.add(detail.session, detail.model, detail.value)
+			}
+		}
+		if item.value > 0 {
+			byBlock[block.ID] = item
+		}
+	}
+	return sortedItems(byBlock)
+}
+
+// sortedItems 按开始时间排序，只保留有用量的对象
+func sortedItems(byKey map[string]*usageItem) []*usageItem {
+	items := make([]*usageItem, 0, len(byKey))
+	for _, item := range byKey {
+		if item.value > 0 {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].start.Equal(items[j].start) {
+			return items[i].start.Before(items[j].start)
+		}
+		return items[i].id < items[j].id
+	})
+	return items
+}
+
+// detectAnomalies 用此前窗口内的同类对象作为基线逐个打分
+func detectAnomalies(kind string, items []*usageItem, window time.Duration, threshold float64) []models.Anomaly {
+	var anomalies []models.Anomaly
+	first := 0
+	for i, item := range items {
+		for first < i && items[first].start.Before(item.start.Add(-window)) {
+			first++
+		}
+		if i-first < anomalyMinBaseline {
+			continue
+		}
+
+		baseline := make([]float64, 0, i-first)
+		for _, previous := range items[first:i] {
+			baseline = append(baseline, previous.value)
+		}
+		median, mad, score, ok := robustScore(item.value, baseline)
+		if !ok || score < threshold {
+			continue
+		}
+
+		anomaly := models.Anomaly{
+			Kind:         kind,
+			ID:           item.id,
+			Start:        item.start,
+			End:          item.end,
+			Project:      item.project,
+			Observed:     item.value,
+			Baseline:     median,
+			MAD:          mad,
+			Score:        score,
+			BaselineSize: len(baseline),
+			TopSession:   topContributor(item.sessions, item.value),
+			TopModel:     topContributor(item.models, item.value),
+		}
+		if median > 0 {
+			anomaly.Ratio = item.value / median
+		}
+		anomalies = append(anomalies, anomaly)
+	}
+	return anomalies
+}
+
+// robustScore 计算稳健z分数；MAD为0时退回到平均绝对偏差，两者都为0时无法判断
+func robustScore(value float64, baseline []float64) (median, mad, score float64, ok bool) {
+	sorted := append([]float64{}, baseline...)
+	sort.Float64s(sorted)
+	median = percentile(sorted, 0.5)
+
+	deviations := make([]float64, len(sorted))
+	var sumDeviation float64
+	for i, v := range sorted {
+		deviations[i] = math.Abs(v - median)
+		sumDeviation += deviations[i]
+	}
+	sort.Float64s(deviations)
+	mad = percentile(deviations, 0.5)
+
+	switch {
+	case mad > 0:
+		score = madScale * (value - median) / mad
+	case sumDeviation > 0:
+		score = meanADScale * (value - median) / (sumDeviation / float64(len(deviations)))
+	default:
+		return median, mad, 0, false
+	}
+	return median, mad, score, true
+}
+
+// topContributor 找出贡献最大的会话或模型
+func topContributor(values map[string]float64, total float64) *models.AnomalyContributor {
+	var top *models.AnomalyContributor
+	for name, value := range values {
+		if top == nil || value > top.Value || (value == top.Value && name < top.Name) {
+			top = &models.AnomalyContributor{Name: name, Value: value}
+		}
+	}
+	if top != nil && total > 0 {
+		top.Share = top.Value / total
+	}
+	return top
+}
@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// rememberUsage 记录实时日志中带 usage 的条目键
+func (p *ClaudeParser) rememberUsage(entry *models.ConversationEntry) {
+	if entry.ExtractedUsage == nil || entry.ExtractedUsage.IsEmpty() {
+		return
+	}
+	if p.liveUsage == nil {
+		p.liveUsage = make(map[string]bool)
+	}
+	key := models.UsageRecord{
+		RequestID: entry.RequestID,
+		SessionID: entry.SessionID,
+		Timestamp: entry.Timestamp,
+	}.Key()
+	p.liveUsage[key] = true
+}
+
+// ReplayRecords 将归档或团队快照中的记录重放为统计数据，与实时日志合并使用
+// 必须在解析完所有实时日志目录之后调用：已在实时日志或之前的重放中出现的记录（按 requestId 去重）会被跳过，
+// 因此同一份数据被多次导入或同时出现在归档和快照中时只统计一次。
+// 归档只保存用量记录，因此重放结果不包含工具调用、时延等需要完整对话内容的统计
+// 返回统计数据和实际重放的记录数
+func (p *ClaudeParser) ReplayRecords(each func(emit func(models.UsageRecord) error) error) (*models.UsageStats, int, error) {
+	stats := &models.UsageStats{
+		ModelStats:   make(map[string]models.TokenUsage),
+		DailyStats:   make(map[string]models.TokenUsage),
+		SessionStats: make(map[string]models.SessionInfo),
+		ProjectStats: make(map[string]models.ProjectStats),
+		BranchStats:  make(map[string]models.BranchStats),
+		ToolStats:    make(map[string]models.ToolStats),
+		CacheStats:   make(map[string]models.CacheStats),
+		HourlyStats:  make(map[string]models.UsageBucket),
+		HourlyDetail: make(map[string]models.UsageBucket),
+		MessageTypes: make(map[string]int),
+	}
+	p.pendingToolUses = make(map[string]string)
+	p.pendingCacheWrites = make(map[string][]pendingCacheWrite)
+	p.turnNodes = make(map[string][]*turnNode)
+
+	replayed := 0
+	err := each(func(record models.UsageRecord) error {
+		key := record.Key()
+		if p.liveUsage[key] {
+			return nil
+		}
+
+		entry := replayEntry(record)
+		if !p.shouldInclude(entry) {
+			return nil
+		}
+
+		if p.liveUsage == nil {
+			p.liveUsage = make(map[string]bool)
+		}
+		p.liveUsage[key] = true
+		p.processEntry(stats, entry)
+		replayed++
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	p.flushCacheWrites(stats)
+	p.flushTurnNodes(stats)
+	p.calculatePeriod(stats)
+	p.calculateCost(stats)
+
+	return stats, replayed, nil
+}
+
+// replayEntry 将归档或快照中的用量记录还原为日志条目
+func replayEntry(record models.UsageRecord) *models.ConversationEntry {
+	usage := &models.TokenUsage{
+		InputTokens:         record.InputTokens,
+		OutputTokens:        record.OutputTokens,
+		CacheCreationTokens: record.CacheCreationTokens,
+		CacheReadTokens:     record.CacheReadTokens,
+	}
+	usage.TotalTokens = usage.GetTotalTokens()
+
+	return &models.ConversationEntry{
+		Type:        "assistant",
+		Timestamp:   record.Timestamp,
+		SessionID:   record.SessionID,
+		RequestID:   record.RequestID,
+		CWD:         record.ProjectPath,
+		GitBranch:   record.GitBranch,
+		IsSidechain: record.IsSidechain,
+		User:        record.User,
+		Host:        record.Host,
+		ParsedMessage: &models.ParsedMessage{
+			Role:  "assistant",
+			Model: record.Model,
+			Usage: usage,
+		},
+		ExtractedUsage: usage,
+	}
+}
+
+// StreamReplay 将归档或团队快照中的记录按过滤条件逐条输出，供 export 合并 --snapshot 快照
+// 与 ReplayRecords 相同，必须在读取完实时日志之后调用，已出现过的记录（按 requestId 去重）会被跳过
+// 返回实际输出的记录数
+func (p *ClaudeParser) StreamReplay(each func(emit func(models.UsageRecord) error) error, emit func(models.UsageRecord) error) (int, error) {
+	streamed := 0
+	err := each(func(record models.UsageRecord) error {
+		key := record.Key()
+		if p.liveUsage[key] || !p.shouldInclude(replayEntry(record)) {
+			return nil
+		}
+
+		if p.liveUsage == nil {
+			p.liveUsage = make(map[string]bool)
+		}
+		p.liveUsage[key] = true
+		streamed++
+		return emit(p.Redactor.Record(record))
+	})
+	return streamed, err
+}
@@ -0,0 +1,23 @@
+package parser
+
+import (
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// AnalyzeBranches 生成分支报告（项目+分支维度）
+func (p *ClaudeParser) AnalyzeBranches(stats *models.UsageStats) *models.BranchesReport {
+	report := &models.BranchesReport{
+		Type:     "branches",
+		Branches: []models.BranchStats{},
+		Summary: models.BranchStats{
+			ProjectName: "total",
+		},
+	}
+
+	for _, branch := range stats.BranchStats {
+		report.Branches = append(report.Branches, branch)
+		report.Summary.Merge(branch)
+	}
+
+	return report
+}
@@ -0,0 +1,199 @@
+package parser
+
+import (
+	"strings"
+	"time"
+
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// 提示缓存的两种TTL
+const (
+	cacheTTLShort = 5 * time.Minute
+	cacheTTLLong  = time.Hour
+)
+
+// cacheLongWriteMultiplier 1小时缓存写入相对输入价格的倍数（5分钟写入为1.25倍，已在定价表中）
+const cacheLongWriteMultiplier = 2.0
+
+// 缓存报告支持的分组维度
+const (
+	CacheByDay     = "day"
+	CacheByProject = "project"
+	CacheBySession = "session"
+	CacheByModel   = "model"
+)
+
+// pendingCacheWrite 尚未被后续读取的缓存写入
+type pendingCacheWrite struct {
+	cellKey string
+	time    time.Time
+	tokens  int
+	pricing ModelPricing
+}
+
+// processCache 统计单条记录的缓存用量，并用本条的缓存读取结算之前的写入
+func (p *ClaudeParser) processCache(stats *models.UsageStats, entry *models.ConversationEntry) {
+	usage := entry.ExtractedUsage
+	model := entryModel(entry)
+	pricing := p.costs().PricingFor(model)
+
+	projectKey := ""
+	if entry.CWD != "" {
+		projectKey = p.resolveProject(entry.CWD).Key
+	}
+	date := entry.Timestamp.Format("2006-01-02")
+	cellKey := strings.Join([]string{date, projectKey, entry.SessionID, model}, "|")
+
+	cell, exists := stats.CacheStats[cellKey]
+	if !exists {
+		cell = models.CacheStats{
+			Date:      date,
+			Project:   projectKey,
+			SessionID: entry.SessionID,
+			Model:     model,
+		}
+	}
+	cell.MessageCount++
+	cell.InputTokens += usage.InputTokens
+	cell.CacheCreationTokens += usage.CacheCreationTokens
+	cell.CacheReadTokens += usage.CacheReadTokens
+	cell.SavingsUSD += float64(usage.CacheReadTokens) * (pricing.InputPricePerMToken - pricing.CacheReadPricePerMToken) / 1_000_000
+	cell.WritePremiumUSD += float64(usage.CacheCreationTokens) * (pricing.CacheWritePricePerMToken - pricing.InputPricePerMToken) / 1_000_000
+	cell.Refresh()
+	stats.CacheStats[cellKey] = cell
+
+	if p.pendingCacheWrites == nil {
+		return
+	}
+	if entry.Timestamp.After(p.lastCacheEntry) {
+		p.lastCacheEntry = entry.Timestamp
+	}
+
+	// 先结算之前的写入（本条读取的是之前写入的缓存），再登记本条的写入
+	p.resolveCacheWrites(stats, entry.SessionID, entry.Timestamp, usage.CacheReadTokens > 0)
+
+	if usage.CacheCreationTokens > 0 {
+		p.pendingCacheWrites[entry.SessionID] = append(p.pendingCacheWrites[entry.SessionID], pendingCacheWrite{
+			cellKey: cellKey,
+			time:    entry.Timestamp,
+			tokens:  usage.CacheCreationTokens,
+			pricing: pricing,
+		})
+	}
+}
+
+// resolveCacheWrites 结算会话中已被读取或已超过最长TTL的缓存写入
+func (p *ClaudeParser) resolveCacheWrites(stats *models.UsageStats, sessionID string, now time.Time, read bool) {
+	pending := p.pendingCacheWrites[sessionID]
+	remaining := pending[:0]
+
+	for _, write := range pending {
+		gap := now.Sub(write.time)
+		switch {
+		case gap < 0:
+			remaining = append(remaining, write)
+		case read && gap <= cacheTTLLong:
+			// 在1小时内被读取；超过5分钟才读取时，5分钟缓存已经过期
+			settleCacheWrite(stats, write, gap > cacheTTLShort, false)
+		case gap > cacheTTLLong:
+			settleCacheWrite(stats, write, true, true)
+		default:
+			remaining = append(remaining, write)
+		}
+	}
+
+	if len(remaining) == 0 {
+		delete(p.pendingCacheWrites, sessionID)
+		return
+	}
+	p.pendingCacheWrites[sessionID] = remaining
+}
+
+// flushCacheWrites 结算文件结束时仍未被读取的缓存写入
+// 只有超过TTL（以最后一条记录和当前时间中较晚者为准）的写入才记为该TTL的浪费，仍在进行的会话中未过期的写入之后还可能被读取
+func (p *ClaudeParser) flushCacheWrites(stats *models.UsageStats) {
+	end := p.lastCacheEntry
+	if now := p.now(); now.After(end) {
+		end = now
+	}
+	for sessionID, pending := range p.pendingCacheWrites {
+		for _, write := range pending {
+			age := end.Sub(write.time)
+			settleCacheWrite(stats, write, age > cacheTTLShort, age > cacheTTLLong)
+		}
+		delete(p.pendingCacheWrites, sessionID)
+	}
+	p.lastCacheEntry = time.Time{}
+}
+
+// now 返回当前时间
+func (p *ClaudeParser) now() time.Time {
+	if p.clock != nil {
+		return p.clock()
+	}
+	return time.Now()
+}
+
+// settleCacheWrite 将未被读取的缓存写入计入其所在的明细
+func settleCacheWrite(stats *models.UsageStats, write pendingCacheWrite, wastedShort, wastedLong bool) {
+	cell, ok := stats.CacheStats[write.cellKey]
+	if !ok {
+		return
+	}
+	if wastedShort {
+		cell.Wasted5mTokens += write.tokens
+		cell.Wasted5mUSD += float64(write.tokens) * write.pricing.CacheWritePricePerMToken / 1_000_000
+	}
+	if wastedLong {
+		cell.Wasted1hTokens += write.tokens
+		cell.Wasted1hUSD += float64(write.tokens) * write.pricing.InputPricePerMToken * cacheLongWriteMultiplier / 1_000_000
+	}
+	stats.CacheStats[write.cellKey] = cell
+}
+
+// AnalyzeCache 按指定维度生成缓存效率报告
+func (p *ClaudeParser) AnalyzeCache(stats *models.UsageStats, by string) (*models.CacheReport, error) {
+	dimension := strings.ToLower(by)
+	var keyOf func(cell models.CacheStats) string
+
+	switch dimension {
+	case CacheByDay, "":
+		dimension = CacheByDay
+		keyOf = func(cell models.CacheStats) string { return cell.Date }
+	case CacheByProject:
+		keyOf = func(cell models.CacheStats) string { return cell.Project }
+	case CacheBySession:
+		keyOf = func(cell models.CacheStats) string { return cell.SessionID }
+	case CacheByModel:
+		keyOf = func(cell models.CacheStats) string { return cell.Model }
+	default:
+		return nil, i18n.Errorf("err.unsupported_cache_by", by)
+	}
+
+	groups := make(map[string]*models.CacheStats)
+	report := &models.CacheReport{
+		Type:      "cache",
+		Dimension: dimension,
+		Items:     []models.CacheStats{},
+		Summary:   models.CacheStats{Name: "total"},
+	}
+
+	for _, cell := range stats.CacheStats {
+		key := keyOf(cell)
+		group, ok := groups[key]
+		if !ok {
+			group = &models.CacheStats{Name: key}
+			groups[key] = group
+		}
+		group.Merge(cell)
+		report.Summary.Merge(cell)
+	}
+
+	for _, group := range groups {
+		report.Items = append(report.Items, *group)
+	}
+
+	return report, nil
+}
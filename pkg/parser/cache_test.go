@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// writeLog 将若干行写入临时目录中的 JSONL 文件并返回路径
+func writeLog(t *testing.T, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatalf("写入测试日志失败: %v", err)
+	}
+	return path
+}
+
+// assistantLine 生成一条带缓存用量的助手消息
+func assistantLine(uuid, parent string, timestamp time.Time, cacheCreation, cacheRead int) string {
+	parentUUID := "null"
+	if parent != "" {
+		parentUUID = fmt.Sprintf("%q", parent)
+	}
+	return fmt.Sprintf(`{"type": "assistant", "sessionId": "s1", "cwd": "/work/api", "timestamp": %q, "uuid": %q, "parentUuid": %s, "requestId": "req-%s", "message": {"id": "msg-%s", "role": "assistant", "model": "claude-sonnet-4-20250514", "content": [{"type": "text", "text": "ok"}], "usage": {"input_tokens": 10, "output_tokens": 20, "cache_creation_input_tokens": %d, "cache_read_input_tokens": %d}}}`,
+		timestamp.Format(time.RFC3339), uuid, parentUUID, uuid, uuid, cacheCreation, cacheRead)
+}
+
+// wastedTokens 汇总所有明细中浪费的缓存写入
+func wastedTokens(stats *models.UsageStats) (short, long int) {
+	for _, cell := range stats.CacheStats {
+		short += cell.Wasted5mTokens
+		long += cell.Wasted1hTokens
+	}
+	return short, long
+}
+
+// TestCacheWritesInActiveSession 会话仍在进行时，未过期的缓存写入不计为浪费
+func TestCacheWritesInActiveSession(t *testing.T) {
+	start := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	path := writeLog(t,
+		assistantLine("a1", "", start, 1000, 0),
+		assistantLine("a2", "a1", start.Add(time.Minute), 0, 1000),
+		assistantLine("a3", "a2", start.Add(2*time.Minute), 500, 0),
+	)
+
+	cases := []struct {
+		name     string
+		now      time.Time
+		wanted5m int
+		wanted1h int
+	}{
+		{"5分钟内", start.Add(4 * time.Minute), 0, 0},
+		{"5分钟后1小时内", start.Add(30 * time.Minute), 500, 0},
+		{"1小时后", start.Add(2 * time.Hour), 500, 500},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewClaudeParser()
+			p.clock = func() time.Time { return tc.now }
+			stats, err := p.ParseFile(path)
+			if err != nil {
+				t.Fatalf("解析失败: %v", err)
+			}
+			short, long := wastedTokens(stats)
+			if short != tc.wanted5m || long != tc.wanted1h {
+				t.Errorf("浪费的缓存写入 5m=%d 1h=%d，期望 5m=%d 1h=%d", short, long, tc.wanted5m, tc.wanted1h)
+			}
+		})
+	}
+}
+
+// TestCacheWritesAfterLogEnd 当前时间早于日志（时钟偏差）时按最后一条记录的时间判断
+func TestCacheWritesAfterLogEnd(t *testing.T) {
+	start := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	path := writeLog(t,
+		assistantLine("a1", "", start, 1000, 0),
+		assistantLine("a2", "a1", start.Add(10*time.Minute), 0, 0),
+	)
+
+	p := NewClaudeParser()
+	p.clock = func() time.Time { return start }
+	stats, err := p.ParseFile(path)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if short, long := wastedTokens(stats); short != 1000 || long != 0 {
+		t.Errorf("浪费的缓存写入 5m=%d 1h=%d，期望 5m=1000 1h=0", short, long)
+	}
+}
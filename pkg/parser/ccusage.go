@@ -0,0 +1,521 @@
+package parser
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+	"github.com/zhuiye8/claude-stats/pkg/redact"
+)
+
+// ccusage 报告类型
+const (
+	CCUsageDaily   = "daily"
+	CCUsageMonthly = "monthly"
+	CCUsageSession = "session"
+	CCUsageBlocks  = "blocks"
+
+	// CCUsagePriceVersion 由 ccusage 报告换算出的记录使用的价格版本标记
+	CCUsagePriceVersion = "ccusage"
+	// ccusageProject 未按项目分组的 ccusage 报告导入后使用的项目路径
+	ccusageProject = "ccusage"
+	// ccusageRequestPrefix 换算记录的 requestId 前缀，其后依次为报告类型、周期起止时间、会话ID、项目和模型
+	ccusageRequestPrefix = "ccusage|"
+)
+
+// CCUsageModel ccusage 报告行中单个模型的用量
+type CCUsageModel struct {
+	Model   string
+	Tokens  models.TokenUsage
+	CostUSD float64
+}
+
+// CCUsageRow 规范化后的 ccusage 报告行，代表一个统计周期 [Start, End)
+// ccusage 的日期按其运行时的时区划分，daily/monthly/session 的日期按报告的 Location 解释
+type CCUsageRow struct {
+	Period    string
+	Start     time.Time
+	End       time.Time
+	SessionID string
+	Project   string
+	Tokens    models.TokenUsage
+	CostUSD   float64
+	Models    []CCUsageModel
+}
+
+// CCUsageReport 规范化后的 ccusage --json 报告
+type CCUsageReport struct {
+	Kind     string
+	Rows     []CCUsageRow
+	Location *time.Location // 生成报告时 ccusage 使用的时区，对比时按该时区划分日期和月份
+}
+
+// ccusageTokens ccusage JSON 中的 token 字段；blocks 的 tokenCounts 使用 API 的缓存字段名
+type ccusageTokens struct {
+	InputTokens              int `json:"inputTokens"`
+	OutputTokens             int `json:"outputTokens"`
+	CacheCreationTokens      int `json:"cacheCreationTokens"`
+	CacheReadTokens          int `json:"cacheReadTokens"`
+	CacheCreationInputTokens int `json:"cacheCreationInputTokens"`
+	CacheReadInputTokens     int `json:"cacheReadInputTokens"`
+}
+
+// usage 转换为 TokenUsage
+func (t ccusageTokens) usage() models.TokenUsage {
+	usage := models.TokenUsage{
+		InputTokens:         t.InputTokens,
+		OutputTokens:        t.OutputTokens,
+		CacheCreationTokens: t.CacheCreationTokens + t.CacheCreationInputTokens,
+		CacheReadTokens:     t.CacheReadTokens + t.CacheReadInputTokens,
+	}
+	usage.TotalTokens = usage.GetTotalTokens()
+	return usage
+}
+
+// ccusageBreakdown modelBreakdowns 中的一项
+type ccusageBreakdown struct {
+	ccusageTokens
+	ModelName string  `json:"modelName"`
+	Cost      float64 `json:"cost"`
+}
+
+// ccusageEntry daily/monthly/session/blocks 各报告行的字段并集
+type ccusageEntry struct {
+	ccusageTokens
+	Date            string             `json:"date"`
+	Month           string             `json:"month"`
+	SessionID       string             `json:"sessionId"`
+	ProjectPath     string             `json:"projectPath"`
+	LastActivity    string             `json:"lastActivity"`
+	TotalCost       float64            `json:"totalCost"`
+	ModelsUsed      []string           `json:"modelsUsed"`
+	ModelBreakdowns []ccusageBreakdown `json:"modelBreakdowns"`
+
+	StartTime   string        `json:"startTime"`
+	EndTime     string        `json:"endTime"`
+	IsGap       bool          `json:"isGap"`
+	TokenCounts ccusageTokens `json:"tokenCounts"`
+	CostUSD     float64       `json:"costUSD"`
+	Models      []string      `json:"models"`
+}
+
+// ccusageFile ccusage --json 输出的顶层结构；daily --instances 按项目分组输出到 projects
+type ccusageFile struct {
+	Daily    []ccusageEntry            `json:"daily"`
+	Monthly  []ccusageEntry            `json:"monthly"`
+	Sessions []ccusageEntry            `json:"sessions"`
+	Blocks   []ccusageEntry            `json:"blocks"`
+	Projects map[string][]ccusageEntry `json:"projects"`
+}
+
+// ReadCCUsage 读取 ccusage daily/monthly/session/blocks 的 --json 输出，按报告类型规范化为统计周期
+// loc 为生成报告时 ccusage 使用的时区（ccusage 默认使用本地时区），为 nil 时使用 time.Local
+func ReadCCUsage(path string, loc *time.Location) (*CCUsageReport, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file ccusageFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	report := &CCUsageReport{Location: loc}
+	var rowErr error
+	add := func(entry ccusageEntry, project string) {
+		if rowErr != nil || entry.IsGap {
+			return
+		}
+		row, err := ccusageRow(report.Kind, entry, project, loc)
+		if err != nil {
+			rowErr = err
+			return
+		}
+		report.Rows = append(report.Rows, row)
+	}
+
+	switch {
+	case file.Daily != nil:
+		report.Kind = CCUsageDaily
+		for _, entry := range file.Daily {
+			add(entry, "")
+		}
+	case file.Projects != nil:
+		report.Kind = CCUsageDaily
+		for project, entries := range file.Projects {
+			for _, entry := range entries {
+				add(entry, project)
+			}
+		}
+	case file.Monthly != nil:
+		report.Kind = CCUsageMonthly
+		for _, entry := range file.Monthly {
+			add(entry, "")
+		}
+	case file.Sessions != nil:
+		report.Kind = CCUsageSession
+		for _, entry := range file.Sessions {
+			add(entry, entry.ProjectPath)
+		}
+	case file.Blocks != nil:
+		report.Kind = CCUsageBlocks
+		for _, entry := range file.Blocks {
+			add(entry, "")
+		}
+	default:
+		return nil, i18n.Errorf("err.ccusage_format")
+	}
+	if rowErr != nil {
+		return nil, rowErr
+	}
+
+	sort.SliceStable(report.Rows, func(i, j int) bool {
+		a, b := report.Rows[i], report.Rows[j]
+		if !a.Start.Equal(b.Start) {
+			return a.Start.Before(b.Start)
+		}
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		return a.Period < b.Period
+	})
+	return report, nil
+}
+
+// ccusageRow 将一行 ccusage 数据转换为统计周期，日期和月份按 loc 解释
+func ccusageRow(kind string, entry ccusageEntry, project string, loc *time.Location) (CCUsageRow, error) {
+	row := CCUsageRow{
+		Project: project,
+		Tokens:  entry.usage(),
+		CostUSD: entry.TotalCost,
+	}
+	modelNames := entry.ModelsUsed
+
+	var err error
+	switch kind {
+	case CCUsageDaily:
+		row.Period = entry.Date
+		row.Start, err = time.ParseInLocation("2006-01-02", entry.Date, loc)
+		row.End = row.Start.AddDate(0, 0, 1)
+	case CCUsageMonthly:
+		row.Period = entry.Month
+		row.Start, err = time.ParseInLocation("2006-01", entry.Month, loc)
+		row.End = row.Start.AddDate(0, 1, 0)
+	case CCUsageSession:
+		// ccusage 只给出会话的最后活动日期，周期取该日
+		row.Period = entry.SessionID
+		row.SessionID = entry.SessionID
+		row.Start, err = parseCCUsageTime(entry.LastActivity, loc)
+		year, month, day := row.Start.In(loc).Date()
+		row.Start = time.Date(year, month, day, 0, 0, 0, 0, loc)
+		row.End = row.Start.AddDate(0, 0, 1)
+	case CCUsageBlocks:
+		row.Start, err = parseCCUsageTime(entry.StartTime, loc)
+		if err == nil {
+			row.End, err = parseCCUsageTime(entry.EndTime, loc)
+		}
+		row.Start, row.End = row.Start.UTC(), row.End.UTC()
+		row.Period = row.Start.Format("2006-01-02 15:04")
+		row.Tokens = entry.TokenCounts.usage()
+		row.CostUSD = entry.CostUSD
+		modelNames = entry.Models
+	}
+	if err != nil {
+		return row, i18n.Errorf("err.ccusage_row", kind, err)
+	}
+
+	for _, breakdown := range entry.ModelBreakdowns {
+		row.Models = append(row.Models, CCUsageModel{
+			Model:   breakdown.ModelName,
+			Tokens:  breakdown.usage(),
+			CostUSD: breakdown.Cost,
+		})
+	}
+	if len(row.Models) == 0 {
+		// 没有按模型拆分时（如 blocks），整行归到列出的第一个模型
+		model := "unknown"
+		if len(modelNames) > 0 {
+			model = modelNames[0]
+		}
+		row.Models = []CCUsageModel{{Model: model, Tokens: row.Tokens, CostUSD: row.CostUSD}}
+	}
+	return row, nil
+}
+
+// parseCCUsageTime 解析 ccusage 输出的时间，支持 RFC3339 和纯日期（按 loc 解释）
+func parseCCUsageTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, loc)
+}
+
+// Records 将报告换算为用量记录：每个周期的每个模型一条，requestId 由周期和模型确定，重复导入时去重
+// 非 blocks 的记录时间取周期起点当天的正午（报告时区），避免换算后日期落到相邻一天
+func (r *CCUsageReport) Records(user string) []models.UsageRecord {
+	var records []models.UsageRecord
+	for _, row := range r.Rows {
+		timestamp := row.Start
+		if r.Kind != CCUsageBlocks {
+			timestamp = timestamp.Add(12 * time.Hour)
+		}
+		sessionID := row.SessionID
+		if sessionID == "" {
+			sessionID = "ccusage-" + strings.ReplaceAll(row.Period, " ", "T")
+		}
+		projectPath := row.Project
+		if projectPath == "" {
+			projectPath = ccusageProject
+		}
+
+		for _, model := range row.Models {
+			records = append(records, models.UsageRecord{
+				Timestamp: timestamp,
+				SessionID: sessionID,
+				RequestID: ccusageRequestPrefix + strings.Join([]string{
+					r.Kind, row.Start.Format(time.RFC3339), row.End.Format(time.RFC3339),
+					row.SessionID, row.Project, model.Model,
+				}, "|"),
+				ProjectPath:         projectPath,
+				Model:               model.Model,
+				InputTokens:         model.Tokens.InputTokens,
+				OutputTokens:        model.Tokens.OutputTokens,
+				CacheCreationTokens: model.Tokens.CacheCreationTokens,
+				CacheReadTokens:     model.Tokens.CacheReadTokens,
+				CostUSD:             model.CostUSD,
+				PriceVersion:        CCUsagePriceVersion,
+				User:                user,
+			})
+		}
+	}
+	return records
+}
+
+// ccusageSpan 返回由 ccusage 换算出的记录所代表的周期
+func ccusageSpan(record models.UsageRecord) (time.Time, time.Time, bool) {
+	if !strings.HasPrefix(record.RequestID, ccusageRequestPrefix) {
+		return time.Time{}, time.Time{}, false
+	}
+	parts := strings.SplitN(record.RequestID, "|", 5)
+	if len(parts) < 5 {
+		return time.Time{}, time.Time{}, false
+	}
+	start, err := time.Parse(time.RFC3339, parts[2])
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	end, err := time.Parse(time.RFC3339, parts[3])
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, end, true
+}
+
+// CCUsageCoverage 记录已有数据覆盖的时间，导入时跳过与已有数据重叠的 ccusage 周期，避免重复计数
+// 实时日志和归档记录按时间点计，之前导入的 ccusage 记录按其代表的整个周期计
+type CCUsageCoverage struct {
+	points []time.Time
+	spans  [][2]time.Time
+	sorted bool
+}
+
+// Add 记录一条已有的用量记录
+func (c *CCUsageCoverage) Add(record models.UsageRecord) error {
+	if start, end, ok := ccusageSpan(record); ok {
+		c.spans = append(c.spans, [2]time.Time{start, end})
+		return nil
+	}
+	c.points = append(c.points, record.Timestamp)
+	c.sorted = false
+	return nil
+}
+
+// Covers 检查周期内是否已有数据
+func (c *CCUsageCoverage) Covers(row CCUsageRow) bool {
+	if !c.sorted {
+		sort.Slice(c.points, func(i, j int) bool { return c.points[i].Before(c.points[j]) })
+		c.sorted = true
+	}
+	i := sort.Search(len(c.points), func(i int) bool { return !c.points[i].Before(row.Start) })
+	if i < len(c.points) && c.points[i].Before(row.End) {
+		return true
+	}
+	for _, span := range c.spans {
+		if span[0].Before(row.End) && row.Start.Before(span[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// ccusageMatcher 将用量记录归入 ccusage 报告的统计周期
+type ccusageMatcher struct {
+	kind     string
+	rows     []CCUsageRow
+	byKey    map[string]int
+	grouped  bool           // daily --instances 按项目分组
+	location *time.Location // 划分日期和月份的时区，与生成报告时 ccusage 使用的一致
+}
+
+func newCCUsageMatcher(report *CCUsageReport) *ccusageMatcher {
+	location := report.Location
+	if location == nil {
+		location = time.Local
+	}
+	m := &ccusageMatcher{kind: report.Kind, rows: report.Rows, byKey: make(map[string]int), location: location}
+	for i, row := range report.Rows {
+		if row.Project != "" && report.Kind == CCUsageDaily {
+			m.grouped = true
+		}
+		m.byKey[m.rowKey(row)] = i
+	}
+	return m
+}
+
+// rowKey 报告行的匹配键
+func (m *ccusageMatcher) rowKey(row CCUsageRow) string {
+	switch m.kind {
+	case CCUsageSession:
+		return row.SessionID
+	case CCUsageDaily:
+		return row.Period + "|" + row.Project
+	default:
+		return row.Period
+	}
+}
+
+// match 返回记录所属报告行的下标，不属于任何周期时返回 -1
+func (m *ccusageMatcher) match(record models.UsageRecord) int {
+	var key string
+	switch m.kind {
+	case CCUsageSession:
+		key = record.SessionID
+	case CCUsageDaily:
+		key = record.Timestamp.In(m.location).Format("2006-01-02") + "|"
+		if m.grouped {
+			key += redact.EncodeProjectDir(record.ProjectPath)
+		}
+	case CCUsageMonthly:
+		key = record.Timestamp.In(m.location).Format("2006-01")
+	case CCUsageBlocks:
+		// 窗口互不重叠：取开始时间不晚于记录时间的最后一个窗口
+		i := sort.Search(len(m.rows), func(i int) bool { return m.rows[i].Start.After(record.Timestamp) }) - 1
+		if i >= 0 && record.Timestamp.Before(m.rows[i].End) {
+			return i
+		}
+		return -1
+	}
+	if i, ok := m.byKey[key]; ok {
+		return i
+	}
+	return -1
+}
+
+// VerifyCCUsage 将 ccusage 报告与本工具对同一周期的计算结果逐行对比
+// each 依次提供本工具的用量记录；由 ccusage 换算导入的记录会被忽略，成本按当前价格表重新计算
+// tolerance 为允许的 token 和成本相对差异（百分比），超出即视为不一致
+func (p *ClaudeParser) VerifyCCUsage(report *CCUsageReport, each func(emit func(models.UsageRecord) error) error, tolerance float64) (*models.CCUsageVerifyReport, error) {
+	matcher := newCCUsageMatcher(report)
+	result := &models.CCUsageVerifyReport{
+		Type:         "ccusage_verify",
+		Kind:         report.Kind,
+		Tolerance:    tolerance,
+		PriceVersion: p.costs().Version,
+		Rows:         make([]models.CCUsageVerifyRow, len(report.Rows)),
+	}
+	for i, row := range report.Rows {
+		period := row.Period
+		if matcher.grouped {
+			period += " " + row.Project
+		}
+		result.Rows[i] = models.CCUsageVerifyRow{
+			Period:        period,
+			StartTime:     row.Start,
+			EndTime:       row.End,
+			CCUsageTokens: ccusageTotalTokens(row.Tokens),
+			CCUsageCost:   row.CostUSD,
+		}
+	}
+
+	err := each(func(record models.UsageRecord) error {
+		if record.PriceVersion == CCUsagePriceVersion {
+			return nil
+		}
+		i := matcher.match(record)
+		if i < 0 {
+			return nil
+		}
+		usage := &models.TokenUsage{
+			InputTokens:         record.InputTokens,
+			OutputTokens:        record.OutputTokens,
+			CacheCreationTokens: record.CacheCreationTokens,
+			CacheReadTokens:     record.CacheReadTokens,
+		}
+		row := &result.Rows[i]
+		row.Entries++
+		row.Tokens += ccusageTotalTokens(*usage)
+		row.CostUSD += p.costs().CalculateModelCost(record.Model, usage)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &result.Summary
+	for i := range result.Rows {
+		row := &result.Rows[i]
+		finishVerifyRow(row, tolerance)
+		if row.Mismatch {
+			result.Mismatches++
+		}
+		summary.CCUsageTokens += row.CCUsageTokens
+		summary.Tokens += row.Tokens
+		summary.CCUsageCost += row.CCUsageCost
+		summary.CostUSD += row.CostUSD
+		summary.Entries += row.Entries
+	}
+	if len(result.Rows) > 0 {
+		summary.StartTime = result.Rows[0].StartTime
+		summary.EndTime = result.Rows[len(result.Rows)-1].EndTime
+	}
+	finishVerifyRow(summary, tolerance)
+	return result, nil
+}
+
+// ccusageTotalTokens 与 ccusage 的 totalTokens 口径一致：输入、输出和两类缓存 token 之和
+func ccusageTotalTokens(usage models.TokenUsage) int {
+	return usage.InputTokens + usage.OutputTokens + usage.CacheCreationTokens + usage.CacheReadTokens
+}
+
+// finishVerifyRow 计算差异并按容差判断是否一致
+func finishVerifyRow(row *models.CCUsageVerifyRow, tolerance float64) {
+	row.TokenDiff = row.Tokens - row.CCUsageTokens
+	// 逐条累加的浮点误差不计为差异
+	row.CostDiff = math.Round((row.CostUSD-row.CCUsageCost)*1e6) / 1e6
+	if row.CostDiff == 0 {
+		row.CostDiff = 0 // 去掉 -0
+	}
+	row.CostDiffPct = relativeDiff(row.CCUsageCost+row.CostDiff, row.CCUsageCost)
+	tokenDiffPct := relativeDiff(float64(row.Tokens), float64(row.CCUsageTokens))
+	row.Mismatch = math.Abs(row.CostDiffPct) > tolerance || math.Abs(tokenDiffPct) > tolerance
+}
+
+// relativeDiff 返回 value 相对 reference 的差异百分比；reference 为0时，有差异即视为100%
+func relativeDiff(value, reference float64) float64 {
+	if reference == 0 {
+		if math.Abs(value) < 1e-9 {
+			return 0
+		}
+		return 100
+	}
+	return (value - reference) / reference * 100
+}
@@ -0,0 +1,32 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestReadCCUsageLocation daily 报告的日期按生成报告时的时区解析，而不是 UTC
+func TestReadCCUsageLocation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daily.json")
+	report := `{"daily": [{"date": "2026-08-20", "inputTokens": 10, "outputTokens": 20, "totalCost": 0.1}]}`
+	if err := os.WriteFile(path, []byte(report), 0o644); err != nil {
+		t.Fatalf("写入测试报告失败: %v", err)
+	}
+
+	shanghai := time.FixedZone("UTC+8", 8*3600)
+	for _, loc := range []*time.Location{time.UTC, shanghai} {
+		parsed, err := ReadCCUsage(path, loc)
+		if err != nil {
+			t.Fatalf("读取报告失败: %v", err)
+		}
+		if len(parsed.Rows) != 1 {
+			t.Fatalf("期望 1 行，实际 %d 行", len(parsed.Rows))
+		}
+		want := time.Date(2026, 8, 20, 0, 0, 0, 0, loc)
+		if row := parsed.Rows[0]; !row.Start.Equal(want) || !row.End.Equal(want.AddDate(0, 0, 1)) {
+			t.Errorf("%s: 周期为 [%s, %s)，期望从 %s 开始", loc, row.Start, row.End, want)
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// ChargebackShare 拆分规则中分给某个成本中心的百分比
+type ChargebackShare struct {
+	Center  string  `mapstructure:"center" json:"center"`
+	Percent float64 `mapstructure:"percent" json:"percent"`
+}
+
+// ChargebackRule 成本分摊规则（配置文件 chargeback 列表）
+// Match 与项目规则相同（完整路径、前缀或通配符），Regex 为项目路径正则；
+// Remote 匹配规范化后的 git 远程地址（如 github.com/acme/*，也可写组织前缀），RemoteRegex 为远程地址正则。
+// 同一规则中设置的条件需全部满足。Center 将成本全部分给一个成本中心，
+// Split 按百分比拆分给多个成本中心，合计不足100%的部分计为未分摊
+type ChargebackRule struct {
+	Name        string            `mapstructure:"name" json:"name,omitempty"`
+	Match       string            `mapstructure:"match" json:"match,omitempty"`
+	Regex       string            `mapstructure:"regex" json:"regex,omitempty"`
+	Remote      string            `mapstructure:"remote" json:"remote,omitempty"`
+	RemoteRegex string            `mapstructure:"remote_regex" json:"remote_regex,omitempty"`
+	Center      string            `mapstructure:"center" json:"center,omitempty"`
+	Split       []ChargebackShare `mapstructure:"split" json:"split,omitempty"`
+
+	regex       *regexp.Regexp
+	remoteRegex *regexp.Regexp
+}
+
+// ChargebackRules 分摊规则集合，按配置顺序匹配，第一条命中的规则生效
+type ChargebackRules []ChargebackRule
+
+// Compile 校验规则并编译其中的正则表达式，必须在分摊前调用
+func (r ChargebackRules) Compile() error {
+	for i := range r {
+		rule := &r[i]
+		label := rule.Label(i)
+
+		if rule.Match == "" && rule.Regex == "" && rule.Remote == "" && rule.RemoteRegex == "" {
+			return i18n.Errorf("err.chargeback_match", label)
+		}
+		if (rule.Center == "") == (len(rule.Split) == 0) {
+			return i18n.Errorf("err.chargeback_target", label)
+		}
+
+		total := 0.0
+		for _, share := range rule.Split {
+			if share.Center == "" || share.Percent <= 0 {
+				return i18n.Errorf("err.chargeback_share", label)
+			}
+			total += share.Percent
+		}
+		if total > 100+1e-9 {
+			return i18n.Errorf("err.chargeback_split_total", label, total)
+		}
+
+		var err error
+		if rule.Regex != "" {
+			if rule.regex, err = regexp.Compile(rule.Regex); err != nil {
+				return i18n.Errorf("err.chargeback_regex", label, err)
+			}
+		}
+		if rule.RemoteRegex != "" {
+			if rule.remoteRegex, err = regexp.Compile(rule.RemoteRegex); err != nil {
+				return i18n.Errorf("err.chargeback_regex", label, err)
+			}
+		}
+	}
+	return nil
+}
+
+// NeedsRemote 检查是否有规则按 git 远程地址匹配
+func (r ChargebackRules) NeedsRemote() bool {
+	for _, rule := range r {
+		if rule.Remote != "" || rule.RemoteRegex != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Label 返回规则在报告中的名称：未设置 name 时使用配置中的序号（从1开始）
+func (rule ChargebackRule) Label(index int) string {
+	if rule.Name != "" {
+		return rule.Name
+	}
+	return fmt.Sprintf("#%d", index+1)
+}
+
+// matches 检查项目路径和远程地址是否满足规则的全部条件
+func (rule ChargebackRule) matches(projectPath, remote string) bool {
+	if rule.Match != "" && !matchProjectPattern(projectPath, rule.Match) {
+		return false
+	}
+	if rule.regex != nil && !rule.regex.MatchString(projectPath) {
+		return false
+	}
+	if rule.Remote != "" && (remote == "" || !matchRemotePattern(remote, NormalizeRemote(rule.Remote))) {
+		return false
+	}
+	if rule.remoteRegex != nil && (remote == "" || !rule.remoteRegex.MatchString(remote)) {
+		return false
+	}
+	return true
+}
+
+// shares 返回规则的分摊比例
+func (rule ChargebackRule) shares() []ChargebackShare {
+	if rule.Center != "" {
+		return []ChargebackShare{{Center: rule.Center, Percent: 100}}
+	}
+	return rule.Split
+}
+
+// NormalizeRemote 将 git 远程地址规范化为 host/owner/repo 形式
+// git@github.com:acme/api.git、https://github.com/acme/api 和 ssh://git@github.com/acme/api.git 都得到 github.com/acme/api
+func NormalizeRemote(url string) string {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return ""
+	}
+
+	if i := strings.Index(url, "://"); i >= 0 {
+		url = url[i+3:]
+	} else if i := strings.Index(url, ":"); i >= 0 && !strings.Contains(url[:i], "/") {
+		// scp 形式：[user@]host:owner/repo
+		url = url[:i] + "/" + url[i+1:]
+	}
+	if at := strings.Index(url, "@"); at >= 0 && at < strings.Index(url+"/", "/") {
+		url = url[at+1:]
+	}
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git"))
+}
+
+// matchRemotePattern 远程地址完全相同、以 pattern 为前缀（组织或分组）或匹配通配符时返回 true
+func matchRemotePattern(remote, pattern string) bool {
+	if remote == pattern || strings.HasPrefix(remote, pattern+"/") {
+		return true
+	}
+	matched, _ := path.Match(pattern, remote)
+	return matched
+}
+
+// AnalyzeChargeback 按分摊规则将各项目的每月成本分配到成本中心
+// remotes 为项目路径到 git 远程地址的映射，没有按远程地址匹配的规则时可以为 nil。
+// 规则按原始路径匹配，因此 stats 应在不脱敏的情况下解析，明细中的路径和远程地址由 p.Redactor 脱敏
+func (p *ClaudeParser) AnalyzeChargeback(stats *models.UsageStats, rules ChargebackRules, remotes map[string]string) *models.ChargebackReport {
+	report := &models.ChargebackReport{
+		Type:         "chargeback",
+		PriceVersion: p.costCalculator.Version,
+		Months:       []models.ChargebackMonth{},
+		Rules:        make([]models.ChargebackRuleHit, len(rules)),
+		Lines:        []models.ChargebackLine{},
+	}
+	for i, rule := range rules {
+		report.Rules[i].Rule = rule.Label(i)
+	}
+
+	keys := make([]string, 0, len(stats.ProjectStats))
+	for key := range stats.ProjectStats {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		project := stats.ProjectStats[key]
+		remote := NormalizeRemote(remotes[project.ProjectPath])
+
+		// 配置了别名的项目包含多个路径，任一路径满足规则即视为匹配
+		ruleIndex := -1
+	match:
+		for i, rule := range rules {
+			for _, projectPath := range project.AllPaths() {
+				pathRemote := NormalizeRemote(remotes[projectPath])
+				if rule.matches(projectPath, pathRemote) {
+					ruleIndex = i
+					if pathRemote != "" {
+						remote = pathRemote
+					}
+					break match
+				}
+			}
+		}
+
+		// 项目每日成本按月汇总
+		monthly := make(map[string]float64)
+		for date, bucket := range project.Daily {
+			if len(date) >= 7 {
+				monthly[date[:7]] += bucket.CostUSD
+			}
+		}
+
+		// 报告中输出的项目标识
+		projectName, projectPath := project.ProjectName, p.Redactor.Path(project.ProjectPath)
+		if p.Redactor.Enabled() && projectName == filepath.Base(project.ProjectPath) {
+			projectName = filepath.Base(projectPath)
+		}
+		outputRemote := p.Redactor.Remote(remote)
+
+		var shares []ChargebackShare
+		ruleName := ""
+		if ruleIndex >= 0 {
+			shares = rules[ruleIndex].shares()
+			ruleName = report.Rules[ruleIndex].Rule
+			report.Rules[ruleIndex].Projects++
+		}
+
+		for month, cost := range monthly {
+			if ruleIndex >= 0 {
+				report.Rules[ruleIndex].Cost += cost
+			}
+
+			allocated := 0.0
+			for _, share := range shares {
+				report.Lines = append(report.Lines, models.ChargebackLine{
+					Month:       month,
+					CostCenter:  share.Center,
+					Rule:        ruleName,
+					ProjectName: projectName,
+					ProjectPath: projectPath,
+					Remote:      outputRemote,
+					Percent:     share.Percent,
+					Cost:        cost * share.Percent / 100,
+				})
+				allocated += share.Percent
+			}
+
+			// 没有匹配规则或拆分比例不足100%的剩余部分
+			if remaining := 100 - allocated; remaining > 1e-9 {
+				report.Lines = append(report.Lines, models.ChargebackLine{
+					Month:       month,
+					Rule:        ruleName,
+					ProjectName: projectName,
+					ProjectPath: projectPath,
+					Remote:      outputRemote,
+					Percent:     math.Round(remaining*1e6) / 1e6,
+					Cost:        cost * remaining / 100,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(report.Lines, func(i, j int) bool {
+		a, b := report.Lines[i], report.Lines[j]
+		if a.Month != b.Month {
+			return a.Month < b.Month
+		}
+		// 未分摊的明细排在每月最后
+		if (a.CostCenter == "") != (b.CostCenter == "") {
+			return b.CostCenter == ""
+		}
+		if a.CostCenter != b.CostCenter {
+			return a.CostCenter < b.CostCenter
+		}
+		return a.ProjectPath < b.ProjectPath
+	})
+
+	// 按月和全部月份汇总成本中心
+	months := make(map[string]*chargebackTotals)
+	summary := newChargebackTotals("total")
+	for _, line := range report.Lines {
+		totals, ok := months[line.Month]
+		if !ok {
+			totals = newChargebackTotals(line.Month)
+			months[line.Month] = totals
+		}
+		totals.add(line)
+		summary.add(line)
+	}
+
+	monthKeys := make([]string, 0, len(months))
+	for month := range months {
+		monthKeys = append(monthKeys, month)
+	}
+	sort.Strings(monthKeys)
+	for _, month := range monthKeys {
+		report.Months = append(report.Months, months[month].result())
+	}
+	report.Summary = summary.result()
+
+	return report
+}
+
+// chargebackTotals 汇总分摊明细时的中间状态
+type chargebackTotals struct {
+	month    models.ChargebackMonth
+	costs    map[string]float64
+	projects map[string]map[string]bool
+}
+
+func newChargebackTotals(month string) *chargebackTotals {
+	return &chargebackTotals{
+		month:    models.ChargebackMonth{Month: month},
+		costs:    make(map[string]float64),
+		projects: make(map[string]map[string]bool),
+	}
+}
+
+// add 累加一条分摊明细
+func (t *chargebackTotals) add(line models.ChargebackLine) {
+	t.month.TotalCost += line.Cost
+	if line.CostCenter == "" {
+		t.month.UnallocatedCost += line.Cost
+		return
+	}
+	t.month.AllocatedCost += line.Cost
+	t.costs[line.CostCenter] += line.Cost
+	if t.projects[line.CostCenter] == nil {
+		t.projects[line.CostCenter] = make(map[string]bool)
+	}
+	t.projects[line.CostCenter][line.ProjectPath] = true
+}
+
+// result 返回汇总结果，成本中心按成本降序排列
+func (t *chargebackTotals) result() models.ChargebackMonth {
+	month := t.month
+	month.Centers = []models.ChargebackCenter{}
+	for center, cost := range t.costs {
+		month.Centers = append(month.Centers, models.ChargebackCenter{
+			CostCenter: center,
+			Projects:   len(t.projects[center]),
+			Cost:       cost,
+		})
+	}
+	sort.Slice(month.Centers, func(i, j int) bool {
+		if month.Centers[i].Cost != month.Centers[j].Cost {
+			return month.Centers[i].Cost > month.Centers[j].Cost
+		}
+		return month.Centers[i].CostCenter < month.Centers[j].CostCenter
+	})
+	return month
+}
@@ -11,7 +11,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
 	"github.com/zhuiye8/claude-stats/pkg/models"
+	"github.com/zhuiye8/claude-stats/pkg/redact"
 )
 
 // ClaudeParser 用于解析Claude JSONL日志文件
@@ -20,6 +22,35 @@ type ClaudeParser struct {
 	SkipErrors   bool
 	Verbose      bool
 	DateFilter   *DateFilter
+
+	// 项目别名/分组规则，以及 --project 过滤条件
+	Projects      ProjectRules
+	ProjectFilter string
+
+	// 子代理（sidechain）记录的处理方式：include/exclude/only
+	SidechainMode string
+
+	// 实时日志所属的用户标签和主机名，以及 --user 过滤条件
+	User       string
+	Host       string
+	UserFilter string
+
+	// 报告、导出和调试输出中的脱敏方式，nil 表示不脱敏
+	Redactor *redact.Redactor
+
+	costCalculator *CostCalculator
+	// 当前文件中尚未匹配到结果的工具调用（tool_use_id -> 工具名）
+	pendingToolUses map[string]string
+	// 当前文件中尚未被读取的缓存写入（会话ID -> 写入记录）
+	pendingCacheWrites map[string][]pendingCacheWrite
+	// 当前文件中最后一条记录的时间，文件结束时据此判断未读取的缓存写入是否已过期
+	lastCacheEntry time.Time
+	// 当前时间，为 nil 时使用 time.Now（测试中固定时间）
+	clock func() time.Time
+	// 当前文件中的消息树节点（会话ID -> 节点），文件结束时计算时延
+	turnNodes map[string][]*turnNode
+	// 已解析的实时日志和已重放的归档、快照中出现过的用量记录键，用于去重
+	liveUsage map[string]bool
 }
 
 // DateFilter 用于过滤日期范围
@@ -31,8 +62,11 @@ type DateFilter struct {
 // NewClaudeParser 创建新的解析器实例
 func NewClaudeParser() *ClaudeParser {
 	return &ClaudeParser{
-		SkipErrors: true,
-		Verbose:    false,
+		SkipErrors:     true,
+		Verbose:        false,
+		User:           DefaultUserLabel(),
+		Host:           DefaultHostname(),
+		costCalculator: NewCostCalculator(),
 	}
 }
 
@@ -43,6 +77,11 @@ func (p *ClaudeParser) ParseDirectory(dirPath string) (*models.UsageStats, error
 		DailyStats:   make(map[string]models.TokenUsage),
 		SessionStats: make(map[string]models.SessionInfo),
 		ProjectStats: make(map[string]models.ProjectStats),
+		BranchStats:  make(map[string]models.BranchStats),
+		ToolStats:    make(map[string]models.ToolStats),
+		CacheStats:   make(map[string]models.CacheStats),
+		HourlyStats:  make(map[string]models.UsageBucket),
+		HourlyDetail: make(map[string]models.UsageBucket),
 		MessageTypes: make(map[string]int),
 		DetectedMode: p.detectMode(dirPath),
 	}
@@ -54,16 +93,16 @@ func (p *ClaudeParser) ParseDirectory(dirPath string) (*models.UsageStats, error
 
 		if strings.HasSuffix(strings.ToLower(info.Name()), ".jsonl") {
 			if p.Verbose {
-				fmt.Printf("📂 处理文件: %s\n", path)
+				fmt.Println(i18n.T("parser.processing_file", p.Redactor.File(path)))
 			}
 			
 			fileStats, err := p.ParseFile(path)
 			if err != nil {
 				if p.SkipErrors {
-					fmt.Printf("⚠️  跳过文件 %s: %v\n", path, err)
+					fmt.Println(i18n.T("parser.skip_file", p.Redactor.File(path), p.Redactor.Text(err.Error())))
 					return nil
 				}
-				return fmt.Errorf("解析文件 %s 失败: %w", path, err)
+				return i18n.Errorf("parser.err_parse_file", p.Redactor.File(path), p.Redactor.Error(err))
 			}
 
 			p.mergeStats(stats, fileStats)
@@ -85,7 +124,7 @@ func (p *ClaudeParser) ParseDirectory(dirPath string) (*models.UsageStats, error
 func (p *ClaudeParser) ParseFile(filePath string) (*models.UsageStats, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("打开文件失败: %w", err)
+		return nil, i18n.Errorf("parser.err_open_file", err)
 	}
 	defer file.Close()
 
@@ -94,8 +133,16 @@ func (p *ClaudeParser) ParseFile(filePath string) (*models.UsageStats, error) {
 		DailyStats:   make(map[string]models.TokenUsage),
 		SessionStats: make(map[string]models.SessionInfo),
 		ProjectStats: make(map[string]models.ProjectStats),
+		BranchStats:  make(map[string]models.BranchStats),
+		ToolStats:    make(map[string]models.ToolStats),
+		CacheStats:   make(map[string]models.CacheStats),
+		HourlyStats:  make(map[string]models.UsageBucket),
+		HourlyDetail: make(map[string]models.UsageBucket),
 		MessageTypes: make(map[string]int),
 	}
+	p.pendingToolUses = make(map[string]string)
+	p.pendingCacheWrites = make(map[string][]pendingCacheWrite)
+	p.turnNodes = make(map[string][]*turnNode)
 
 	scanner := bufio.NewScanner(file)
 	// 增加扫描器缓冲区大小以处理长行（Claude日志可能包含大量代码）
@@ -115,11 +162,16 @@ func (p *ClaudeParser) ParseFile(filePath string) (*models.UsageStats, error) {
 		if err != nil {
 			if p.SkipErrors {
 				if p.Verbose {
-					fmt.Printf("⚠️  行 %d 解析错误: %v\n", lineNum, err)
+					fmt.Println(i18n.T("parser.line_error", lineNum, err))
 				}
 				continue
 			}
-			return nil, fmt.Errorf("行 %d 解析失败: %w", lineNum, err)
+			return nil, i18n.Errorf("parser.err_parse_line", lineNum, err)
+		}
+
+		if entry != nil {
+			// 记录实时日志中出现过的用量，合并归档时据此去重
+			p.rememberUsage(entry)
 		}
 
 		if entry != nil && p.shouldInclude(entry) {
@@ -128,9 +180,15 @@ func (p *ClaudeParser) ParseFile(filePath string) (*models.UsageStats, error) {
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("读取文件失败: %w", err)
+		return nil, i18n.Errorf("parser.err_read_file", err)
 	}
 
+	// 文件结束时仍未被读取的缓存写入视为浪费
+	p.flushCacheWrites(stats)
+
+	// 重建消息树并计算时延样本
+	p.flushTurnNodes(stats)
+
 	return stats, nil
 }
 
@@ -139,7 +197,7 @@ func (p *ClaudeParser) parseLine(line string) (*models.ConversationEntry, error)
 	// 先解析到map以处理未知字段
 	var rawData map[string]interface{}
 	if err := json.Unmarshal([]byte(line), &rawData); err != nil {
-		return nil, fmt.Errorf("JSON解析失败: %w", err)
+		return nil, i18n.Errorf("parser.err_json", err)
 	}
 
 	// 创建entry并设置原始数据
@@ -172,6 +230,18 @@ func (p *ClaudeParser) parseLine(line string) (*models.ConversationEntry, error)
 		entry.CWD = cwd
 	}
 
+	if gitBranch, ok := rawData["gitBranch"].(string); ok {
+		entry.GitBranch = gitBranch
+	}
+
+	if isSidechain, ok := rawData["isSidechain"].(bool); ok {
+		entry.IsSidechain = isSidechain
+	}
+
+	if isMeta, ok := rawData["isMeta"].(bool); ok {
+		entry.IsMeta = isMeta
+	}
+
 	if version, ok := rawData["version"].(string); ok {
 		entry.Version = version
 	}
@@ -230,6 +300,7 @@ func (p *ClaudeParser) parseMessage(messageData interface{}) *models.ParsedMessa
 		
 		if content, ok := msg["content"]; ok {
 			parsedMsg.Content = content
+			p.extractToolBlocks(parsedMsg, content)
 		}
 		
 		if model, ok := msg["model"].(string); ok {
@@ -253,6 +324,36 @@ func (p *ClaudeParser) parseMessage(messageData interface{}) *models.ParsedMessa
 	return parsedMsg
 }
 
+// extractToolBlocks 从content数组中提取 tool_use 和 tool_result 内容块
+func (p *ClaudeParser) extractToolBlocks(parsedMsg *models.ParsedMessage, content interface{}) {
+	blocks, ok := content.([]interface{})
+	if !ok {
+		return
+	}
+
+	for _, item := range blocks {
+		block, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		switch block["type"] {
+		case "tool_use":
+			toolUse := models.ToolUse{}
+			toolUse.ID, _ = block["id"].(string)
+			toolUse.Name, _ = block["name"].(string)
+			if toolUse.Name != "" {
+				parsedMsg.ToolUses = append(parsedMsg.ToolUses, toolUse)
+			}
+		case "tool_result":
+			result := models.ToolResult{}
+			result.ToolUseID, _ = block["tool_use_id"].(string)
+			result.IsError, _ = block["is_error"].(bool)
+			parsedMsg.ToolResults = append(parsedMsg.ToolResults, result)
+		}
+	}
+}
+
 // extractModelFromString 从字符串中提取模型信息
 func (p *ClaudeParser) extractModelFromString(content string) string {
 	// 常见的Claude模型名称模式
@@ -405,11 +506,30 @@ func (p *ClaudeParser) parseTimestamp(timestampStr string) (time.Time, error) {
 		}
 	}
 
-	return time.Time{}, fmt.Errorf("无法解析时间戳: %s", timestampStr)
+	return time.Time{}, i18n.Errorf("parser.err_timestamp", timestampStr)
 }
 
 // shouldInclude 检查条目是否应该包含在统计中
 func (p *ClaudeParser) shouldInclude(entry *models.ConversationEntry) bool {
+	if p.ProjectFilter != "" && !p.Projects.MatchesFilter(entry.CWD, p.ProjectFilter) {
+		return false
+	}
+
+	if p.UserFilter != "" && p.entryUser(entry) != p.UserFilter {
+		return false
+	}
+
+	switch p.SidechainMode {
+	case SidechainExclude:
+		if entry.IsSidechain {
+			return false
+		}
+	case SidechainOnly:
+		if !entry.IsSidechain {
+			return false
+		}
+	}
+
 	if p.DateFilter == nil {
 		return true
 	}
@@ -434,9 +554,15 @@ func (p *ClaudeParser) processEntry(stats *models.UsageStats, entry *models.Conv
 		stats.MessageTypes[entry.Type]++
 	}
 
+	// 脱敏：会话ID和分支名在所有统计中统一替换，项目路径在解析项目标识时处理
+	if p.Redactor.Enabled() {
+		entry.SessionID = p.Redactor.Session(entry.SessionID)
+		entry.GitBranch = p.Redactor.Branch(entry.GitBranch)
+	}
+
 	// 调试：显示前几条记录的结构
 	if stats.TotalMessages <= 3 && p.Verbose {
-		fmt.Printf("🔍 调试信息 - 记录 #%d:\n", stats.TotalMessages)
+		fmt.Println(i18n.T("parser.debug_record", stats.TotalMessages))
 		fmt.Printf("  Type: %s\n", entry.Type)
 		
 		model := ""
@@ -446,17 +572,39 @@ func (p *ClaudeParser) processEntry(stats *models.UsageStats, entry *models.Conv
 		fmt.Printf("  Model: %s\n", model)
 		
 		fmt.Printf("  Usage: %+v\n", entry.ExtractedUsage)
-		fmt.Printf("  RawData字段: %v\n", getMapKeys(entry.RawData))
+		fmt.Printf("  RawData: %v\n", getMapKeys(entry.RawData))
 		
 		if entry.ParsedMessage != nil {
 			fmt.Printf("  ParsedMessage.Role: %s\n", entry.ParsedMessage.Role)
-			if contentStr, ok := entry.ParsedMessage.Content.(string); ok && len(contentStr) > 50 {
-				fmt.Printf("  Content预览: %s...\n", contentStr[:50])
+			// 脱敏时不输出任何消息内容
+			if contentStr, ok := entry.ParsedMessage.Content.(string); ok && len(contentStr) > 50 && !p.Redactor.Enabled() {
+				fmt.Println(i18n.T("parser.debug_content_preview", contentStr[:50]))
 			}
 		}
 		fmt.Println("  ---")
 	}
 
+	// 按小时统计（所有消息都计入消息数）
+	if !entry.Timestamp.IsZero() {
+		hourKey := entry.Timestamp.UTC().Format(models.HourKeyLayout)
+		hourly := stats.HourlyStats[hourKey]
+		if entry.ExtractedUsage != nil && !entry.ExtractedUsage.IsEmpty() {
+			cost := p.entryCost(entry)
+			hourly.Add(*entry.ExtractedUsage, cost)
+
+			detailKey := strings.Join([]string{hourKey, entry.SessionID, entryModel(entry)}, "|")
+			detail := stats.HourlyDetail[detailKey]
+			detail.Add(*entry.ExtractedUsage, cost)
+			stats.HourlyDetail[detailKey] = detail
+		} else {
+			hourly.MessageCount++
+		}
+		stats.HourlyStats[hourKey] = hourly
+	}
+
+	// 按小时统计用户提问数，用于订阅限额
+	p.processPrompt(stats, entry)
+
 	// 统计解析成功的消息
 	if entry.ParsedMessage != nil {
 		stats.ParsedMessages++
@@ -468,13 +616,8 @@ func (p *ClaudeParser) processEntry(stats *models.UsageStats, entry *models.Conv
 		stats.TotalTokens.Add(*entry.ExtractedUsage)
 
 		// 按模型统计
-		model := ""
-		if entry.ParsedMessage != nil && entry.ParsedMessage.Model != "" {
-			model = entry.ParsedMessage.Model
-		} else {
-			model = "unknown" // 默认模型
-		}
-		
+		model := entryModel(entry)
+
 		modelUsage := stats.ModelStats[model]
 		modelUsage.Add(*entry.ExtractedUsage)
 		stats.ModelStats[model] = modelUsage
@@ -484,6 +627,20 @@ func (p *ClaudeParser) processEntry(stats *models.UsageStats, entry *models.Conv
 		dailyUsage := stats.DailyStats[dateKey]
 		dailyUsage.Add(*entry.ExtractedUsage)
 		stats.DailyStats[dateKey] = dailyUsage
+
+		p.processCache(stats, entry)
+
+		// 子代理用量单独累计，便于与主线程对比
+		if entry.IsSidechain {
+			cost := p.entryCost(entry)
+			stats.Sidechain.Add(*entry.ExtractedUsage, cost)
+			if stats.SidechainDaily == nil {
+				stats.SidechainDaily = make(map[string]models.UsageBucket)
+			}
+			sidechainDaily := stats.SidechainDaily[dateKey]
+			sidechainDaily.Add(*entry.ExtractedUsage, cost)
+			stats.SidechainDaily[dateKey] = sidechainDaily
+		}
 	}
 
 	// 处理会话信息
@@ -494,7 +651,7 @@ func (p *ClaudeParser) processEntry(stats *models.UsageStats, entry *models.Conv
 				ID:        entry.SessionID,
 				StartTime: entry.Timestamp,
 				EndTime:   entry.Timestamp,
-				ProjectPath: entry.CWD,
+				ProjectPath: p.Redactor.Path(entry.CWD),
 			}
 			if entry.ParsedMessage != nil && entry.ParsedMessage.Model != "" {
 				session.Model = entry.ParsedMessage.Model
@@ -509,37 +666,188 @@ func (p *ClaudeParser) processEntry(stats *models.UsageStats, entry *models.Conv
 		if entry.Timestamp.Before(session.StartTime) {
 			session.StartTime = entry.Timestamp
 		}
+		// 会话通常以用户消息开始，模型取第一条带模型信息的记录
+		if session.Model == "" && entry.ParsedMessage != nil && entry.ParsedMessage.Model != "" {
+			session.Model = entry.ParsedMessage.Model
+		}
 
-		if entry.ExtractedUsage != nil {
+		if entry.ExtractedUsage != nil && !entry.ExtractedUsage.IsEmpty() {
+			cost := p.entryCost(entry)
 			session.Tokens.Add(*entry.ExtractedUsage)
+			session.Cost += cost
+			if entry.IsSidechain {
+				session.Sidechain.Add(*entry.ExtractedUsage, cost)
+			}
 		}
 
 		session.Duration = session.EndTime.Sub(session.StartTime).String()
 		stats.SessionStats[entry.SessionID] = session
 	}
 
-	// 处理项目统计
+	// 处理项目统计（以完整路径区分项目，避免同名目录合并）
 	if entry.CWD != "" {
-		projectKey := filepath.Base(entry.CWD)
-		project, exists := stats.ProjectStats[projectKey]
+		identity := p.resolveProject(entry.CWD)
+		project, exists := stats.ProjectStats[identity.Key]
 		if !exists {
 			project = models.ProjectStats{
-				ProjectName: projectKey,
-				ProjectPath: entry.CWD,
-				LastActivity: entry.Timestamp,
+				ProjectName:   identity.Name,
+				ProjectPath:   identity.Path,
+				Group:         identity.Group,
+				Models:        make(map[string]models.UsageBucket),
+				Daily:         make(map[string]models.UsageBucket),
+				FirstActivity: entry.Timestamp,
+				LastActivity:  entry.Timestamp,
 			}
 		}
 
+		if entry.Timestamp.Before(project.FirstActivity) {
+			project.FirstActivity = entry.Timestamp
+		}
 		if entry.Timestamp.After(project.LastActivity) {
 			project.LastActivity = entry.Timestamp
 		}
 
-		if entry.ExtractedUsage != nil {
+		project.AddSession(entry.SessionID)
+		project.AddPath(identity.Path)
+		project.MessageCount++
+
+		if entry.ExtractedUsage != nil && !entry.ExtractedUsage.IsEmpty() {
+			model := entryModel(entry)
+			cost := p.entryCost(entry)
+
 			project.Tokens.Add(*entry.ExtractedUsage)
+			project.Cost += cost
+			if entry.IsSidechain {
+				project.Sidechain.Add(*entry.ExtractedUsage, cost)
+			}
+
+			modelBucket := project.Models[model]
+			modelBucket.Add(*entry.ExtractedUsage, cost)
+			project.Models[model] = modelBucket
+
+			dateKey := entry.Timestamp.Format("2006-01-02")
+			dailyBucket := project.Daily[dateKey]
+			dailyBucket.Add(*entry.ExtractedUsage, cost)
+			project.Daily[dateKey] = dailyBucket
+		}
+
+		stats.ProjectStats[identity.Key] = project
+
+		// 按项目+分支统计
+		branchKey := identity.Key + "@" + entry.GitBranch
+		branch, exists := stats.BranchStats[branchKey]
+		if !exists {
+			branch = models.BranchStats{
+				ProjectName: identity.Name,
+				ProjectPath: identity.Path,
+				Branch:      entry.GitBranch,
+			}
+		}
+
+		branch.AddActivity(entry.SessionID, entry.Timestamp)
+		branch.MessageCount++
+
+		if entry.ExtractedUsage != nil && !entry.ExtractedUsage.IsEmpty() {
+			branch.Tokens.Add(*entry.ExtractedUsage)
+			branch.Cost += p.entryCost(entry)
+		}
+
+		stats.BranchStats[branchKey] = branch
+	}
+
+	// 按用户统计
+	p.processUser(stats, entry)
+
+	// 处理工具调用统计
+	if entry.ParsedMessage != nil {
+		p.processToolBlocks(stats, entry)
+	}
+
+	// 记录消息树节点
+	p.recordTurnNode(entry)
+}
+
+// processToolBlocks 统计条目中的工具调用和工具结果
+func (p *ClaudeParser) processToolBlocks(stats *models.UsageStats, entry *models.ConversationEntry) {
+	toolUses := entry.ParsedMessage.ToolUses
+	if len(toolUses) > 0 {
+		// 一条消息调用多个工具时平均分摊该消息的用量
+		var share models.TokenUsage
+		var shareCost float64
+		if entry.ExtractedUsage != nil && !entry.ExtractedUsage.IsEmpty() {
+			n := len(toolUses)
+			share = models.TokenUsage{
+				InputTokens:         entry.ExtractedUsage.InputTokens / n,
+				OutputTokens:        entry.ExtractedUsage.OutputTokens / n,
+				CacheCreationTokens: entry.ExtractedUsage.CacheCreationTokens / n,
+				CacheReadTokens:     entry.ExtractedUsage.CacheReadTokens / n,
+			}
+			shareCost = p.entryCost(entry) / float64(n)
 		}
 
-		stats.ProjectStats[projectKey] = project
+		projectKey := ""
+		if entry.CWD != "" {
+			projectKey = p.resolveProject(entry.CWD).Key
+		}
+
+		for _, toolUse := range toolUses {
+			tool := stats.ToolStats[toolUse.Name]
+			tool.Name = toolUse.Name
+			tool.CallCount++
+			tool.Tokens.Add(share)
+			tool.Cost += shareCost
+			if projectKey != "" {
+				if tool.Projects == nil {
+					tool.Projects = make(map[string]int)
+				}
+				tool.Projects[projectKey]++
+			}
+			stats.ToolStats[toolUse.Name] = tool
+
+			if toolUse.ID != "" && p.pendingToolUses != nil {
+				p.pendingToolUses[toolUse.ID] = toolUse.Name
+			}
+		}
+	}
+
+	for _, result := range entry.ParsedMessage.ToolResults {
+		name, ok := p.pendingToolUses[result.ToolUseID]
+		if !ok {
+			continue
+		}
+		delete(p.pendingToolUses, result.ToolUseID)
+
+		tool := stats.ToolStats[name]
+		tool.ResultCount++
+		if result.IsError {
+			tool.ErrorCount++
+		}
+		stats.ToolStats[name] = tool
+	}
+}
+
+// entryCost 计算单条记录的成本
+func (p *ClaudeParser) entryCost(entry *models.ConversationEntry) float64 {
+	if entry.ExtractedUsage == nil || entry.ExtractedUsage.IsEmpty() {
+		return 0
 	}
+	return p.costs().CalculateModelCost(entryModel(entry), entry.ExtractedUsage)
+}
+
+// entryModel 获取条目的模型名称，缺失时返回 unknown
+func entryModel(entry *models.ConversationEntry) string {
+	if entry.ParsedMessage != nil && entry.ParsedMessage.Model != "" {
+		return entry.ParsedMessage.Model
+	}
+	return "unknown"
+}
+
+// costs 返回解析器使用的成本计算器
+func (p *ClaudeParser) costs() *CostCalculator {
+	if p.costCalculator == nil {
+		p.costCalculator = NewCostCalculator()
+	}
+	return p.costCalculator
 }
 
 // detectMode 检测使用模式（API vs 订阅）
@@ -552,7 +860,6 @@ func (p *ClaudeParser) detectMode(dirPath string) string {
 // mergeStats 合并统计数据
 func (p *ClaudeParser) mergeStats(target, source *models.UsageStats) {
 	target.TotalMessages += source.TotalMessages
-	target.TotalSessions += source.TotalSessions
 	target.ParsedMessages += source.ParsedMessages
 	target.ExtractedTokens += source.ExtractedTokens
 	target.TotalTokens.Add(source.TotalTokens)
@@ -571,14 +878,85 @@ func (p *ClaudeParser) mergeStats(target, source *models.UsageStats) {
 		target.DailyStats[date] = targetUsage
 	}
 
-	// 合并会话统计
+	// 合并会话统计（子代理记录可能与主会话分属不同文件，需要累加而不是覆盖）
 	for sessionID, session := range source.SessionStats {
-		target.SessionStats[sessionID] = session
+		if existing, exists := target.SessionStats[sessionID]; exists {
+			existing.Merge(session)
+			target.SessionStats[sessionID] = existing
+		} else {
+			target.SessionStats[sessionID] = session
+			target.TotalSessions++
+		}
 	}
 
-	// 合并项目统计
+	// 合并子代理统计
+	target.Sidechain.Merge(source.Sidechain)
+	for date, bucket := range source.SidechainDaily {
+		if target.SidechainDaily == nil {
+			target.SidechainDaily = make(map[string]models.UsageBucket)
+		}
+		existing := target.SidechainDaily[date]
+		existing.Merge(bucket)
+		target.SidechainDaily[date] = existing
+	}
+
+	// 合并项目统计（同一项目可能分布在多个会话文件中，需要累加）
 	for projectKey, project := range source.ProjectStats {
-		target.ProjectStats[projectKey] = project
+		if existing, exists := target.ProjectStats[projectKey]; exists {
+			existing.Merge(project)
+			target.ProjectStats[projectKey] = existing
+		} else {
+			target.ProjectStats[projectKey] = project
+		}
+	}
+
+	// 合并分支统计
+	for branchKey, branch := range source.BranchStats {
+		if existing, exists := target.BranchStats[branchKey]; exists {
+			existing.Merge(branch)
+			target.BranchStats[branchKey] = existing
+		} else {
+			target.BranchStats[branchKey] = branch
+		}
+	}
+
+	// 合并工具统计
+	for name, tool := range source.ToolStats {
+		existing := target.ToolStats[name]
+		existing.Name = name
+		existing.Merge(tool)
+		target.ToolStats[name] = existing
+	}
+
+	// 合并用户统计
+	MergeUserStats(target, source)
+
+	// 合并按小时统计
+	for hour, bucket := range source.HourlyStats {
+		existing := target.HourlyStats[hour]
+		existing.Merge(bucket)
+		target.HourlyStats[hour] = existing
+	}
+	for key, bucket := range source.HourlyDetail {
+		existing := target.HourlyDetail[key]
+		existing.Merge(bucket)
+		target.HourlyDetail[key] = existing
+	}
+
+	MergeHourlyPrompts(target, source)
+
+	// 合并时延样本
+	target.LatencySamples = append(target.LatencySamples, source.LatencySamples...)
+
+	// 合并缓存统计
+	for key, cache := range source.CacheStats {
+		existing, exists := target.CacheStats[key]
+		if !exists {
+			target.CacheStats[key] = cache
+			continue
+		}
+		existing.Merge(cache)
+		target.CacheStats[key] = existing
 	}
 
 	// 合并消息类型统计
@@ -691,7 +1069,7 @@ func (p *ClaudeParser) generateBillingBlocks(startTime, endTime time.Time, stats
 		blockStart = blockStart.Add(-5 * time.Hour)
 	}
 	
-	currentTime := time.Now()
+	currentTime := p.now()
 	
 	for blockStart.Before(endTime.Add(5 * time.Hour)) {
 		blockEnd := blockStart.Add(5 * time.Hour)
@@ -0,0 +1,134 @@
+package parser
+
+import (
+	"math"
+	"sort"
+
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// ComparePeriods 对比两个时间段的用量，按模型和项目分解并按成本变化排序
+func (p *ClaudeParser) ComparePeriods(a, b *models.UsageStats, periodA, periodB models.Period) *models.CompareReport {
+	projectsA := p.AnalyzeProjects(a, false)
+	projectsB := p.AnalyzeProjects(b, false)
+
+	report := &models.CompareReport{
+		Type:    "compare",
+		PeriodA: periodA,
+		PeriodB: periodB,
+		Summary: newCompareItem("total", projectTotals(projectsA.Summary), projectTotals(projectsB.Summary)),
+	}
+
+	// 按项目
+	byProject := make(map[string]*[2]models.CompareTotals)
+	for i, projects := range []*models.ProjectsReport{projectsA, projectsB} {
+		for _, project := range projects.Projects {
+			key := project.ProjectPath
+			if key == "" {
+				key = project.ProjectName
+			}
+			if byProject[key] == nil {
+				byProject[key] = &[2]models.CompareTotals{}
+			}
+			byProject[key][i] = projectTotals(project)
+		}
+	}
+	for name, totals := range byProject {
+		report.Projects = append(report.Projects, newCompareItem(name, totals[0], totals[1]))
+	}
+
+	// 按模型
+	byModel := make(map[string]*[2]models.CompareTotals)
+	for i, stats := range []*models.UsageStats{a, b} {
+		for model, totals := range modelTotals(stats) {
+			if byModel[model] == nil {
+				byModel[model] = &[2]models.CompareTotals{}
+			}
+			byModel[model][i] = totals
+		}
+	}
+	for name, totals := range byModel {
+		report.Models = append(report.Models, newCompareItem(name, totals[0], totals[1]))
+	}
+
+	// 计算对总成本变化的贡献，并按贡献大小排序
+	for _, items := range [][]models.CompareItem{report.Models, report.Projects} {
+		for i := range items {
+			if report.Summary.Delta.CostUSD != 0 {
+				items[i].Share = items[i].Delta.CostUSD / report.Summary.Delta.CostUSD
+			}
+		}
+		sort.SliceStable(items, func(i, j int) bool {
+			di, dj := math.Abs(items[i].Delta.CostUSD), math.Abs(items[j].Delta.CostUSD)
+			if di != dj {
+				return di > dj
+			}
+			return items[i].Name < items[j].Name
+		})
+	}
+
+	return report
+}
+
+// projectTotals 取项目统计中的合计值
+func projectTotals(project models.ProjectStats) models.CompareTotals {
+	return models.CompareTotals{
+		Tokens:   project.Tokens.GetTotalTokens(),
+		CostUSD:  project.Cost,
+		Sessions: project.SessionCount,
+		Messages: project.MessageCount,
+	}
+}
+
+// modelTotals 按模型汇总（来自各项目的模型分布，会话按会话的主模型计）
+func modelTotals(stats *models.UsageStats) map[string]models.CompareTotals {
+	result := make(map[string]models.CompareTotals)
+	for _, project := range stats.ProjectStats {
+		for model, bucket := range project.Models {
+			totals := result[model]
+			totals.Tokens += bucket.Tokens.GetTotalTokens()
+			totals.CostUSD += bucket.CostUSD
+			totals.Messages += bucket.MessageCount
+			result[model] = totals
+		}
+	}
+	for _, session := range stats.SessionStats {
+		if session.Model == "" {
+			continue
+		}
+		totals := result[session.Model]
+		totals.Sessions++
+		result[session.Model] = totals
+	}
+	return result
+}
+
+// newCompareItem 计算两个时间段之间的差值和百分比变化
+func newCompareItem(name string, a, b models.CompareTotals) models.CompareItem {
+	return models.CompareItem{
+		Name: name,
+		A:    a,
+		B:    b,
+		Delta: models.CompareTotals{
+			Tokens:   b.Tokens - a.Tokens,
+			CostUSD:  b.CostUSD - a.CostUSD,
+			Sessions: b.Sessions - a.Sessions,
+			Messages: b.Messages - a.Messages,
+		},
+		Change: models.CompareChange{
+			Tokens:   percentChange(float64(a.Tokens), float64(b.Tokens)),
+			CostUSD:  percentChange(a.CostUSD, b.CostUSD),
+			Sessions: percentChange(float64(a.Sessions), float64(b.Sessions)),
+			Messages: percentChange(float64(a.Messages), float64(b.Messages)),
+		},
+	}
+}
+
+// percentChange 计算百分比变化，基数为0时返回nil
+func percentChange(a, b float64) *float64 {
+	if a == 0 {
+		return nil
+	}
+	change := (b - a) / a * 100
+	return &change
+}
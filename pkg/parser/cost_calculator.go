@@ -1,6 +1,9 @@
 package parser
 
 import (
+	"strings"
+
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
 	"github.com/zhuiye8/claude-stats/pkg/models"
 )
 
@@ -12,15 +15,21 @@ type ModelPricing struct {
 	CacheReadPricePerMToken  float64 // 每百万缓存读取/刷新token的价格
 }
 
+// PricingVersion 内置价格表的版本，随导出记录一起输出，便于追溯成本口径
+const PricingVersion = "2025-08"
+
 // CostCalculator 用于计算使用成本
 type CostCalculator struct {
 	// 定价来源: https://docs.anthropic.com/en/docs/about-claude/pricing (2025年8月)
 	ModelPrices map[string]ModelPricing
+	// 价格表版本
+	Version string
 }
 
 // NewCostCalculator 创建新的成本计算器
 func NewCostCalculator() *CostCalculator {
 	return &CostCalculator{
+		Version: PricingVersion,
 		ModelPrices: map[string]ModelPricing{
 			// Claude 4 / Opus
 			"claude-opus-4": {
@@ -92,13 +101,13 @@ func (c *CostCalculator) Calculate(totalUsage *models.TokenUsage, modelStats map
 	// 如果有按模型的统计，分别计算
 	if len(modelStats) > 0 {
 		for model, usage := range modelStats {
-			cost := c.calculateModelCost(model, &usage)
+			cost := c.CalculateModelCost(model, &usage)
 			breakdown.ModelCosts[model] = cost
 			breakdown.TotalCost += cost
 		}
 	} else {
 		// 如果没有模型信息，使用默认定价（Claude 3.5 Sonnet）
-		cost := c.calculateModelCost("claude-3-5-sonnet", totalUsage)
+		cost := c.CalculateModelCost("claude-3-5-sonnet", totalUsage)
 		breakdown.TotalCost = cost
 	}
 
@@ -108,8 +117,20 @@ func (c *CostCalculator) Calculate(totalUsage *models.TokenUsage, modelStats map
 	return breakdown
 }
 
-// calculateModelCost 计算单个模型的成本
-func (c *CostCalculator) calculateModelCost(model string, usage *models.TokenUsage) float64 {
+// CalculateModelCost 计算单个模型的成本
+func (c *CostCalculator) CalculateModelCost(model string, usage *models.TokenUsage) float64 {
+	pricing := c.PricingFor(model)
+
+	inputCost := float64(usage.InputTokens) * pricing.InputPricePerMToken / 1_000_000
+	outputCost := float64(usage.OutputTokens) * pricing.OutputPricePerMToken / 1_000_000
+	cacheCreationCost := float64(usage.CacheCreationTokens) * pricing.CacheWritePricePerMToken / 1_000_000
+	cacheReadCost := float64(usage.CacheReadTokens) * pricing.CacheReadPricePerMToken / 1_000_000
+
+	return inputCost + outputCost + cacheCreationCost + cacheReadCost
+}
+
+// PricingFor 获取模型定价，未知模型按模型族或默认定价
+func (c *CostCalculator) PricingFor(model string) ModelPricing {
 	pricing, exists := c.ModelPrices[model]
 	if !exists {
 		// 尝试匹配模型族
@@ -125,13 +146,7 @@ func (c *CostCalculator) calculateModelCost(model string, usage *models.TokenUsa
 			pricing = c.ModelPrices["default"]
 		}
 	}
-
-	inputCost := float64(usage.InputTokens) * pricing.InputPricePerMToken / 1_000_000
-	outputCost := float64(usage.OutputTokens) * pricing.OutputPricePerMToken / 1_000_000
-	cacheCreationCost := float64(usage.CacheCreationTokens) * pricing.CacheWritePricePerMToken / 1_000_000
-	cacheReadCost := float64(usage.CacheReadTokens) * pricing.CacheReadPricePerMToken / 1_000_000
-
-	return inputCost + outputCost + cacheCreationCost + cacheReadCost
+	return pricing
 }
 
 // breakdownCosts 分解总成本
@@ -165,7 +180,7 @@ func (c *CostCalculator) GetSubscriptionEquivalent(stats *models.UsageStats) Sub
 		analysis.RecommendedPlan = "Max 20× ($200)"
 		analysis.MonthlySavings = 200.0 - totalCost
 	} else {
-		analysis.RecommendedPlan = "API模式更经济"
+		analysis.RecommendedPlan = i18n.T("cost.api_cheaper")
 		analysis.MonthlySavings = 0
 	}
 
@@ -0,0 +1,208 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// StreamDirectory 逐行读取目录中的所有JSONL文件，对每条带 usage 的记录调用 emit
+// 与 ParseDirectory 不同，这里不做任何聚合，内存占用与日志总量无关
+// 诊断信息写到 stderr，避免混入写往 stdout 的导出数据
+func (p *ClaudeParser) StreamDirectory(dirPath string, emit func(models.UsageRecord) error) error {
+	return filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(strings.ToLower(info.Name()), ".jsonl") {
+			return nil
+		}
+
+		if p.Verbose {
+			fmt.Fprintln(os.Stderr, i18n.T("parser.processing_file", p.Redactor.File(path)))
+		}
+		if err := p.StreamFile(path, emit); err != nil {
+			if e, ok := err.(emitError); ok {
+				return e.err
+			}
+			if p.SkipErrors {
+				fmt.Fprintln(os.Stderr, i18n.T("parser.skip_file", p.Redactor.File(path), p.Redactor.Text(err.Error())))
+				return nil
+			}
+			return i18n.Errorf("parser.err_parse_file", p.Redactor.File(path), p.Redactor.Error(err))
+		}
+		return nil
+	})
+}
+
+// emitError 包装 emit 回调返回的错误（例如写入失败），这类错误不受 SkipErrors 影响
+type emitError struct {
+	err error
+}
+
+func (e emitError) Error() string {
+	return e.err.Error()
+}
+
+// StreamFile 逐行读取单个JSONL文件，对每条通过过滤且带 usage 的记录调用 emit
+func (p *ClaudeParser) StreamFile(filePath string, emit func(models.UsageRecord) error) error {
+	_, err := p.StreamFileFrom(filePath, 0, func(record models.UsageRecord, _ int64) error {
+		return emit(record)
+	})
+	return err
+}
+
+// StreamFileFrom 从 offset 字节处开始读取JSONL文件，emit 额外接收该行在文件中的起始位置
+// 返回已完整处理到的位置；末尾没有换行且无法解析的行视为仍在写入，不计入返回值，供增量同步下次重读
+func (p *ClaudeParser) StreamFileFrom(filePath string, offset int64, emit func(record models.UsageRecord, lineOffset int64) error) (int64, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return offset, i18n.Errorf("parser.err_open_file", err)
+	}
+	defer file.Close()
+
+	if offset > 0 {
+		if _, err := file.Seek(offset, io.SeekStart); err != nil {
+			return offset, i18n.Errorf("parser.err_read_file", err)
+		}
+	}
+
+	reader := bufio.NewReaderSize(file, 64*1024)
+	position := offset
+	lineNum := 0
+
+	for {
+		raw, readErr := reader.ReadBytes('\n')
+		if len(raw) > 0 {
+			lineNum++
+			complete := raw[len(raw)-1] == '\n'
+
+			if line := strings.TrimSpace(string(raw)); line != "" {
+				entry, err := p.parseLine(line)
+				if err == nil && entry != nil {
+					// 记录出现过的用量，之后合并快照时据此去重
+					p.rememberUsage(entry)
+				}
+				switch {
+				case err != nil && !complete:
+					// 最后一行尚未写完，留到下次读取
+					return position, nil
+				case err != nil && p.SkipErrors:
+					if p.Verbose {
+						fmt.Fprintln(os.Stderr, i18n.T("parser.line_error", lineNum, err))
+					}
+				case err != nil:
+					return position, i18n.Errorf("parser.err_parse_line", lineNum, err)
+				case entry != nil && entry.ExtractedUsage != nil && !entry.ExtractedUsage.IsEmpty() && p.shouldInclude(entry):
+					if err := emit(p.usageRecord(entry), position); err != nil {
+						return position, emitError{err}
+					}
+				}
+			}
+			position += int64(len(raw))
+		}
+
+		if readErr == io.EOF {
+			return position, nil
+		}
+		if readErr != nil {
+			return position, i18n.Errorf("parser.err_read_file", readErr)
+		}
+	}
+}
+
+// usageRecord 将日志条目转换为规范化的导出记录，并按解析器的设置脱敏
+func (p *ClaudeParser) usageRecord(entry *models.ConversationEntry) models.UsageRecord {
+	usage := entry.ExtractedUsage
+	return p.Redactor.Record(models.UsageRecord{
+		Timestamp:           entry.Timestamp,
+		SessionID:           entry.SessionID,
+		RequestID:           entry.RequestID,
+		ProjectPath:         normalizeProjectPath(entry.CWD),
+		GitBranch:           entry.GitBranch,
+		Model:               entryModel(entry),
+		InputTokens:         usage.InputTokens,
+		OutputTokens:        usage.OutputTokens,
+		CacheCreationTokens: usage.CacheCreationTokens,
+		CacheReadTokens:     usage.CacheReadTokens,
+		CostUSD:             p.entryCost(entry),
+		PriceVersion:        p.costs().Version,
+		IsSidechain:         entry.IsSidechain,
+	})
+}
+
+// rollupKey 每日汇总的分组键
+type rollupKey struct {
+	date        string
+	projectPath string
+	model       string
+	isSidechain bool
+}
+
+// DailyRollup 将导出记录累加为每日汇总；内存占用只与分组数量相关
+type DailyRollup struct {
+	rows map[rollupKey]*models.UsageRollup
+}
+
+// NewDailyRollup 创建每日汇总累加器
+func NewDailyRollup() *DailyRollup {
+	return &DailyRollup{rows: make(map[rollupKey]*models.UsageRollup)}
+}
+
+// Add 累加一条导出记录
+func (r *DailyRollup) Add(record models.UsageRecord) error {
+	key := rollupKey{
+		date:        record.Timestamp.Format("2006-01-02"),
+		projectPath: record.ProjectPath,
+		model:       record.Model,
+		isSidechain: record.IsSidechain,
+	}
+	row, ok := r.rows[key]
+	if !ok {
+		row = &models.UsageRollup{
+			Date:         key.date,
+			ProjectPath:  key.projectPath,
+			Model:        key.model,
+			IsSidechain:  key.isSidechain,
+			PriceVersion: record.PriceVersion,
+		}
+		r.rows[key] = row
+	}
+
+	row.Entries++
+	row.InputTokens += record.InputTokens
+	row.OutputTokens += record.OutputTokens
+	row.CacheCreationTokens += record.CacheCreationTokens
+	row.CacheReadTokens += record.CacheReadTokens
+	row.CostUSD += record.CostUSD
+	return nil
+}
+
+// Rows 返回按日期、项目、模型排序的汇总行
+func (r *DailyRollup) Rows() []models.UsageRollup {
+	rows := make([]models.UsageRollup, 0, len(r.rows))
+	for _, row := range r.rows {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		if a.ProjectPath != b.ProjectPath {
+			return a.ProjectPath < b.ProjectPath
+		}
+		if a.Model != b.Model {
+			return a.Model < b.Model
+		}
+		return !a.IsSidechain && b.IsSidechain
+	})
+	return rows
+}
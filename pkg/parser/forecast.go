@@ -0,0 +1,255 @@
+package parser
+
+import (
+	"math"
+	"time"
+
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// forecastConfidence 置信区间的置信水平及对应的正态分位数
+const (
+	forecastConfidence = 0.90
+	forecastZ          = 1.6449
+)
+
+// 预测的目标周期
+const (
+	ForecastMonth   = "month"
+	ForecastQuarter = "quarter"
+)
+
+// ForecastOptions 成本预测参数
+type ForecastOptions struct {
+	Today           time.Time // 预测基准日（UTC日期）
+	HistoryDays     int       // 用于拟合的历史天数（不含今天）
+	Quarter         bool      // 同时预测季末花费
+	MonthlyBudget   float64
+	QuarterlyBudget float64
+}
+
+// forecastModel 由历史数据拟合出的预测模型
+type forecastModel struct {
+	start     time.Time  // 拟合窗口的第一天，对应 x = 0
+	intercept float64    // 线性趋势截距
+	slope     float64    // 线性趋势斜率（美元/天）
+	weekday   [7]float64 // 各星期的平均花费（按 time.Weekday 索引）
+	factor    [7]float64 // 星期系数 = 该星期平均花费 / 总平均花费
+	sigma     float64    // 组合模型的单日残差标准差
+}
+
+// linear 线性趋势在某天的预测值（不小于0）
+func (m *forecastModel) linear(day time.Time) float64 {
+	x := day.Sub(m.start).Hours() / 24
+	return math.Max(0, m.intercept+m.slope*x)
+}
+
+// combined 线性趋势乘以星期系数的组合预测值
+func (m *forecastModel) combined(day time.Time) float64 {
+	return m.linear(day) * m.factor[day.Weekday()]
+}
+
+// AnalyzeForecast 基于每日成本预测月末（及季末）花费
+// 每日成本按 UTC 日期汇总逐条记录的成本（按各自的模型定价），与 compare、projects 等报告一致
+// 组合模型为 线性趋势 × 星期系数，置信区间由历史残差估计，假设各日误差独立
+func (p *ClaudeParser) AnalyzeForecast(stats *models.UsageStats, options ForecastOptions) *models.ForecastReport {
+	today := time.Date(options.Today.Year(), options.Today.Month(), options.Today.Day(), 0, 0, 0, 0, time.UTC)
+
+	costs := make(map[string]float64)
+	for hourKey, bucket := range stats.HourlyStats {
+		if len(hourKey) >= len("2006-01-02") {
+			costs[hourKey[:len("2006-01-02")]] += bucket.CostUSD
+		}
+	}
+	costOn := func(day time.Time) float64 { return costs[day.Format("2006-01-02")] }
+
+	model, history := fitForecastModel(costOn, today, options.HistoryDays)
+
+	report := &models.ForecastReport{
+		Type:        "forecast",
+		AsOf:        today.Format("2006-01-02"),
+		HistoryDays: len(history),
+		Confidence:  forecastConfidence,
+		Trend:       model.slope,
+		Targets:     []models.ForecastTarget{},
+		Series:      []models.ForecastPoint{},
+	}
+	for i := range report.Weekday {
+		report.Weekday[i] = model.factor[(i+1)%7]
+	}
+
+	// 目标周期
+	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	targets := []models.ForecastTarget{
+		newForecastTarget(ForecastMonth, monthStart, monthStart.AddDate(0, 1, -1), options.MonthlyBudget),
+	}
+	if options.Quarter {
+		quarterStart := time.Date(today.Year(), time.Month((int(today.Month())-1)/3*3+1), 1, 0, 0, 0, 0, time.UTC)
+		targets = append(targets, newForecastTarget(ForecastQuarter, quarterStart, quarterStart.AddDate(0, 3, -1), options.QuarterlyBudget))
+	}
+	for i := range targets {
+		projectTarget(&targets[i], model, costOn, today)
+	}
+	report.Targets = targets
+
+	// 序列：历史实际值 + 直到最远目标结束日的预测值
+	seriesStart := today
+	if len(history) > 0 {
+		seriesStart = history[0]
+	}
+	seriesEnd := targets[len(targets)-1].EndDate
+	for day := seriesStart; day.Format("2006-01-02") <= seriesEnd; day = day.AddDate(0, 0, 1) {
+		point := models.ForecastPoint{Date: day.Format("2006-01-02")}
+		if !day.After(today) {
+			actual := costOn(day)
+			point.ActualUSD = &actual
+		}
+		if !day.Before(today) {
+			forecast := model.combined(day)
+			lower := math.Max(0, forecast-forecastZ*model.sigma)
+			upper := forecast + forecastZ*model.sigma
+			point.Forecast, point.Lower, point.Upper = &forecast, &lower, &upper
+		}
+		report.Series = append(report.Series, point)
+	}
+
+	return report
+}
+
+// fitForecastModel 用今天之前的完整天拟合线性趋势和星期系数
+// 拟合窗口从有数据的第一天开始，避免开始使用之前的空白期拉低趋势
+func fitForecastModel(costOn func(time.Time) float64, today time.Time, historyDays int) (*forecastModel, []time.Time) {
+	var history []time.Time
+	for i := historyDays; i >= 1; i-- {
+		day := today.AddDate(0, 0, -i)
+		if len(history) == 0 && costOn(day) == 0 {
+			continue
+		}
+		history = append(history, day)
+	}
+
+	model := &forecastModel{start: today}
+	for i := range model.factor {
+		model.factor[i] = 1
+	}
+	if len(history) == 0 {
+		return model, history
+	}
+	model.start = history[0]
+
+	// 线性趋势（最小二乘）
+	n := float64(len(history))
+	var sumX, sumY, sumXY, sumXX float64
+	for i, day := range history {
+		x, y := float64(i), costOn(day)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	mean := sumY / n
+	if denominator := n*sumXX - sumX*sumX; denominator != 0 {
+		model.slope = (n*sumXY - sumX*sumY) / denominator
+	}
+	model.intercept = mean - model.slope*sumX/n
+
+	// 星期平均值和系数（未出现过的星期使用总平均值）
+	var weekdaySum [7]float64
+	var weekdayCount [7]int
+	for _, day := range history {
+		weekdaySum[day.Weekday()] += costOn(day)
+		weekdayCount[day.Weekday()]++
+	}
+	for i := range model.weekday {
+		model.weekday[i] = mean
+		if weekdayCount[i] > 0 {
+			model.weekday[i] = weekdaySum[i] / float64(weekdayCount[i])
+		}
+		if mean > 0 {
+			model.factor[i] = model.weekday[i] / mean
+		}
+	}
+
+	// 残差标准差
+	if len(history) > 2 {
+		var sumSquares float64
+		for _, day := range history {
+			residual := costOn(day) - model.combined(day)
+			sumSquares += residual * residual
+		}
+		model.sigma = math.Sqrt(sumSquares / (n - 2))
+	}
+
+	return model, history
+}
+
+// newForecastTarget 创建目标周期
+func newForecastTarget(name string, start, end time.Time, budget float64) models.ForecastTarget {
+	return models.ForecastTarget{
+		Name:         name,
+		StartDate:    start.Format("2006-01-02"),
+		EndDate:      end.Format("2006-01-02"),
+		BudgetUSD:    budget,
+		BudgetStatus: models.BudgetNone,
+	}
+}
+
+// projectTarget 计算目标周期的实际花费、预测值、置信区间和预算状态
+// 今天尚未结束，今天的预测值取实际花费和模型预测中的较大者
+func projectTarget(target *models.ForecastTarget, model *forecastModel, costOn func(time.Time) float64, today time.Time) {
+	start, _ := time.Parse("2006-01-02", target.StartDate)
+	end, _ := time.Parse("2006-01-02", target.EndDate)
+
+	var cumulative float64
+	var remaining int
+	var linearRest, seasonalRest, combinedRest float64
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		var spend float64
+		switch {
+		case day.Before(today):
+			spend = costOn(day)
+			target.ActualUSD += spend
+		case day.Equal(today):
+			actual := costOn(day)
+			target.ActualUSD += actual
+			spend = math.Max(actual, model.combined(day))
+			linearRest += math.Max(0, model.linear(day)-actual)
+			seasonalRest += math.Max(0, model.weekday[day.Weekday()]-actual)
+			combinedRest += spend - actual
+			remaining++
+		default:
+			spend = model.combined(day)
+			linearRest += model.linear(day)
+			seasonalRest += model.weekday[day.Weekday()]
+			combinedRest += spend
+			remaining++
+		}
+
+		cumulative += spend
+		if target.BudgetUSD > 0 && target.CrossDate == "" && cumulative > target.BudgetUSD {
+			target.CrossDate = day.Format("2006-01-02")
+		}
+	}
+
+	band := forecastZ * model.sigma * math.Sqrt(float64(remaining))
+	target.RemainingDays = remaining
+	target.LinearUSD = target.ActualUSD + linearRest
+	target.SeasonalUSD = target.ActualUSD + seasonalRest
+	target.ProjectedUSD = target.ActualUSD + combinedRest
+	target.LowerUSD = math.Max(target.ActualUSD, target.ProjectedUSD-band)
+	target.UpperUSD = target.ProjectedUSD + band
+
+	if target.BudgetUSD <= 0 {
+		return
+	}
+	switch {
+	case target.ActualUSD > target.BudgetUSD:
+		target.BudgetStatus = models.BudgetOver
+	case target.ProjectedUSD > target.BudgetUSD:
+		target.BudgetStatus = models.BudgetProjected
+	case target.UpperUSD > target.BudgetUSD:
+		target.BudgetStatus = models.BudgetAtRisk
+	default:
+		target.BudgetStatus = models.BudgetUnder
+	}
+}
@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"strings"
+	"time"
+
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// 热力图支持的指标
+const (
+	MetricTokens   = "tokens"
+	MetricCost     = "cost"
+	MetricMessages = "messages"
+)
+
+// heatmapWeekdays 热力图的行顺序（从周一开始）
+var heatmapWeekdays = []time.Weekday{
+	time.Monday, time.Tuesday, time.Wednesday, time.Thursday,
+	time.Friday, time.Saturday, time.Sunday,
+}
+
+// BucketMetric 按指标取用量汇总中的数值
+func BucketMetric(bucket models.UsageBucket, metric string) (float64, error) {
+	switch strings.ToLower(metric) {
+	case MetricTokens, "":
+		return float64(bucket.Tokens.GetTotalTokens()), nil
+	case MetricCost:
+		return bucket.CostUSD, nil
+	case MetricMessages:
+		return float64(bucket.MessageCount), nil
+	default:
+		return 0, i18n.Errorf("err.unsupported_metric", metric)
+	}
+}
+
+// AnalyzeHeatmap 按本地时区的星期和小时汇总用量
+func (p *ClaudeParser) AnalyzeHeatmap(stats *models.UsageStats, metric string, loc *time.Location) (*models.HeatmapReport, error) {
+	metric = strings.ToLower(metric)
+	if metric == "" {
+		metric = MetricTokens
+	}
+	if _, err := BucketMetric(models.UsageBucket{}, metric); err != nil {
+		return nil, err
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+
+	timezone := loc.String()
+	if timezone == "Local" {
+		timezone, _ = time.Now().In(loc).Zone()
+	}
+
+	report := &models.HeatmapReport{
+		Type:     "heatmap",
+		Metric:   metric,
+		Timezone: timezone,
+	}
+	row := make(map[time.Weekday]int, len(heatmapWeekdays))
+	for i, day := range heatmapWeekdays {
+		row[day] = i
+		report.Weekdays = append(report.Weekdays, day.String())
+	}
+
+	for hourKey, bucket := range stats.HourlyStats {
+		hour, err := time.ParseInLocation(models.HourKeyLayout, hourKey, time.UTC)
+		if err != nil {
+			continue
+		}
+		local := hour.In(loc)
+		value, _ := BucketMetric(bucket, metric)
+		report.Matrix[row[local.Weekday()]][local.Hour()] += value
+		report.Total += value
+	}
+
+	for _, hours := range report.Matrix {
+		for _, value := range hours {
+			if value > report.Max {
+				report.Max = value
+			}
+		}
+	}
+
+	return report, nil
+}
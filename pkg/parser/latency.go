@@ -0,0 +1,246 @@
+package parser
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// 时延报告支持的分组维度
+const (
+	LatencyByModel   = "model"
+	LatencyByVersion = "version"
+)
+
+// 消息树节点的类型
+const (
+	nodePrompt     = "prompt"      // 用户输入的提问
+	nodeToolResult = "tool_result" // 工具结果（以用户消息形式记录）
+	nodeAssistant  = "assistant"
+	nodeOther      = "other"
+)
+
+// turnNode 消息树中的一个节点
+type turnNode struct {
+	uuid     string
+	parent   string
+	kind     string
+	time     time.Time
+	model    string
+	version  string
+	children []*turnNode
+}
+
+// recordTurnNode 记录条目在消息树中的位置
+func (p *ClaudeParser) recordTurnNode(entry *models.ConversationEntry) {
+	if p.turnNodes == nil || entry.UUID == "" || entry.Timestamp.IsZero() {
+		return
+	}
+
+	node := &turnNode{
+		uuid:    entry.UUID,
+		parent:  entry.ParentUUID,
+		kind:    nodeOther,
+		time:    entry.Timestamp,
+		version: entry.Version,
+	}
+
+	switch entry.Type {
+	case "assistant":
+		node.kind = nodeAssistant
+		node.model = entryModel(entry)
+	case "user":
+		switch {
+		case entry.ParsedMessage != nil && len(entry.ParsedMessage.ToolResults) > 0:
+			node.kind = nodeToolResult
+		case !entry.IsMeta:
+			node.kind = nodePrompt
+		}
+	}
+
+	p.turnNodes[entry.SessionID] = append(p.turnNodes[entry.SessionID], node)
+}
+
+// flushTurnNodes 按会话重建消息树并生成时延样本
+func (p *ClaudeParser) flushTurnNodes(stats *models.UsageStats) {
+	for sessionID, nodes := range p.turnNodes {
+		byUUID := make(map[string]*turnNode, len(nodes))
+		for _, node := range nodes {
+			byUUID[node.uuid] = node
+		}
+		for _, node := range nodes {
+			if parent, ok := byUUID[node.parent]; ok {
+				parent.children = append(parent.children, node)
+			}
+		}
+
+		for _, node := range nodes {
+			if node.kind != nodePrompt {
+				continue
+			}
+
+			// 人工空闲时间：上一条助手消息到本次提问
+			if parent, ok := byUUID[node.parent]; ok && parent.kind == nodeAssistant {
+				stats.LatencySamples = append(stats.LatencySamples, models.LatencySample{
+					Kind:      models.LatencyIdle,
+					Model:     parent.model,
+					Version:   node.version,
+					SessionID: sessionID,
+					Time:      node.time,
+					Duration:  node.time.Sub(parent.time),
+				})
+			}
+
+			first, last, usedTools := walkTurn(node)
+			if first == nil {
+				continue
+			}
+
+			// 响应时延：提问到助手首次回复
+			stats.LatencySamples = append(stats.LatencySamples, models.LatencySample{
+				Kind:      models.LatencyResponse,
+				Model:     first.model,
+				Version:   node.version,
+				SessionID: sessionID,
+				Time:      node.time,
+				Duration:  first.time.Sub(node.time),
+			})
+
+			// 工具循环：首次回复到本轮最后一条助手消息
+			if usedTools && last != first {
+				stats.LatencySamples = append(stats.LatencySamples, models.LatencySample{
+					Kind:      models.LatencyToolLoop,
+					Model:     first.model,
+					Version:   node.version,
+					SessionID: sessionID,
+					Time:      node.time,
+					Duration:  last.time.Sub(first.time),
+				})
+			}
+		}
+		delete(p.turnNodes, sessionID)
+	}
+}
+
+// walkTurn 沿消息链向下遍历一轮对话，直到下一次用户提问
+// 返回首条和最后一条助手消息，以及本轮是否包含工具结果
+// 手工编辑或损坏的日志中可能出现 parentUuid 指向自身或成环，遇到已访问的节点即停止
+func walkTurn(prompt *turnNode) (first, last *turnNode, usedTools bool) {
+	visited := map[*turnNode]bool{prompt: true}
+	current := prompt
+	for len(current.children) > 0 {
+		// 存在分支（如重新生成）时沿最早的回复继续
+		next := current.children[0]
+		for _, child := range current.children[1:] {
+			if child.time.Before(next.time) {
+				next = child
+			}
+		}
+		if next.kind == nodePrompt || visited[next] {
+			break
+		}
+		visited[next] = true
+
+		switch next.kind {
+		case nodeAssistant:
+			if first == nil {
+				first = next
+			}
+			last = next
+		case nodeToolResult:
+			usedTools = true
+		}
+		current = next
+	}
+	return first, last, usedTools
+}
+
+// AnalyzeLatency 按模型或客户端版本生成时延报告
+func (p *ClaudeParser) AnalyzeLatency(stats *models.UsageStats, by string) (*models.LatencyReport, error) {
+	dimension := strings.ToLower(by)
+	var keyOf func(sample models.LatencySample) string
+
+	switch dimension {
+	case LatencyByModel, "":
+		dimension = LatencyByModel
+		keyOf = func(sample models.LatencySample) string { return sample.Model }
+	case LatencyByVersion:
+		keyOf = func(sample models.LatencySample) string { return sample.Version }
+	default:
+		return nil, i18n.Errorf("err.unsupported_latency_by", by)
+	}
+
+	groups := make(map[string][]models.LatencySample)
+	for _, sample := range stats.LatencySamples {
+		key := keyOf(sample)
+		groups[key] = append(groups[key], sample)
+	}
+
+	report := &models.LatencyReport{
+		Type:      "latency",
+		Dimension: dimension,
+		Items:     []models.LatencyStats{},
+		Summary:   latencyStats("total", stats.LatencySamples),
+	}
+	for name, samples := range groups {
+		report.Items = append(report.Items, latencyStats(name, samples))
+	}
+
+	return report, nil
+}
+
+// latencyStats 计算一组样本中各类时延的分布
+func latencyStats(name string, samples []models.LatencySample) models.LatencyStats {
+	values := make(map[string][]float64)
+	for _, sample := range samples {
+		values[sample.Kind] = append(values[sample.Kind], sample.Duration.Seconds())
+	}
+
+	return models.LatencyStats{
+		Name:     name,
+		Response: durationStats(values[models.LatencyResponse]),
+		ToolLoop: durationStats(values[models.LatencyToolLoop]),
+		Idle:     durationStats(values[models.LatencyIdle]),
+	}
+}
+
+// durationStats 计算均值和百分位数（线性插值）
+func durationStats(values []float64) models.DurationStats {
+	result := models.DurationStats{Count: len(values)}
+	if len(values) == 0 {
+		return result
+	}
+
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	result.Mean = sum / float64(len(sorted))
+	result.P50 = percentile(sorted, 0.50)
+	result.P90 = percentile(sorted, 0.90)
+	result.P95 = percentile(sorted, 0.95)
+	result.P99 = percentile(sorted, 0.99)
+	result.Max = sorted[len(sorted)-1]
+	return result
+}
+
+// percentile 计算已排序数据的百分位数
+func percentile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+}
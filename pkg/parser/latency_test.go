@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// promptLine 生成一条用户提问
+func promptLine(uuid, parent string, timestamp time.Time) string {
+	return fmt.Sprintf(`{"type": "user", "sessionId": "s1", "cwd": "/work/api", "timestamp": %q, "uuid": %q, "parentUuid": %q, "message": {"role": "user", "content": "hi"}}`,
+		timestamp.Format(time.RFC3339), uuid, parent)
+}
+
+// parseWithin 在限定时间内解析日志，超时说明遍历消息树时陷入了死循环
+func parseWithin(t *testing.T, path string) {
+	t.Helper()
+	done := make(chan error, 1)
+	go func() {
+		_, err := NewClaudeParser().ParseFile(path)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("解析失败: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("解析超时：消息树遍历没有结束")
+	}
+}
+
+// TestLatencySelfParentedEntry parentUuid 指向自身的条目不会让时延计算卡住
+func TestLatencySelfParentedEntry(t *testing.T) {
+	start := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	parseWithin(t, writeLog(t,
+		promptLine("p1", "p1", start),
+		assistantLine("a1", "a1", start.Add(time.Second), 0, 0),
+		promptLine("p2", "a1", start.Add(time.Minute)),
+	))
+}
+
+// TestWalkTurnCycle 消息链成环（A→B→A）时遍历在回到已访问节点处停止
+func TestWalkTurnCycle(t *testing.T) {
+	start := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	prompt := &turnNode{uuid: "p", kind: nodePrompt, time: start}
+	a := &turnNode{uuid: "a", parent: "b", kind: nodeAssistant, time: start.Add(time.Second)}
+	b := &turnNode{uuid: "b", parent: "a", kind: nodeToolResult, time: start.Add(2 * time.Second)}
+	prompt.children = []*turnNode{a}
+	a.children = []*turnNode{b}
+	b.children = []*turnNode{a}
+
+	done := make(chan struct{})
+	var first, last *turnNode
+	var usedTools bool
+	go func() {
+		first, last, usedTools = walkTurn(prompt)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("walkTurn 在成环的消息链上没有结束")
+	}
+
+	if first != a || last != a || !usedTools {
+		t.Errorf("walkTurn = (%v, %v, %v)，期望首条和最后一条均为 a 且包含工具结果", first, last, usedTools)
+	}
+}
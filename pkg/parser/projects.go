@@ -0,0 +1,153 @@
+package parser
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// ProjectRule 项目别名/分组规则
+// Match 支持完整路径、路径前缀（匹配其子目录）和通配符（filepath.Match语法）
+type ProjectRule struct {
+	Match string `mapstructure:"match" json:"match"`
+	Alias string `mapstructure:"alias" json:"alias,omitempty"`
+	Group string `mapstructure:"group" json:"group,omitempty"`
+}
+
+// ProjectRules 项目规则集合，按配置顺序匹配
+type ProjectRules []ProjectRule
+
+// ProjectIdentity 项目的标识信息
+type ProjectIdentity struct {
+	Key   string // 聚合键：别名优先，否则为完整路径
+	Name  string // 显示名称
+	Path  string // 规范化后的完整路径
+	Group string // 所属分组
+}
+
+// Resolve 根据规则解析项目路径对应的标识
+// 别名和分组分别取第一条设置了该字段的匹配规则；相同别名的路径会合并为同一项目
+func (r ProjectRules) Resolve(cwd string) ProjectIdentity {
+	path := normalizeProjectPath(cwd)
+	identity := ProjectIdentity{
+		Key:  path,
+		Name: filepath.Base(path),
+		Path: path,
+	}
+
+	aliasFound, groupFound := false, false
+	for _, rule := range r {
+		if aliasFound && groupFound {
+			break
+		}
+		if !matchProjectPattern(path, rule.Match) {
+			continue
+		}
+		if !aliasFound && rule.Alias != "" {
+			identity.Key = rule.Alias
+			identity.Name = rule.Alias
+			aliasFound = true
+		}
+		if !groupFound && rule.Group != "" {
+			identity.Group = rule.Group
+			groupFound = true
+		}
+	}
+
+	return identity
+}
+
+// MatchesFilter 检查项目路径是否匹配 --project 过滤条件
+// 过滤条件可以是完整路径、路径前缀、通配符、别名、分组名或目录名
+func (r ProjectRules) MatchesFilter(cwd, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	if cwd == "" {
+		return false
+	}
+
+	identity := r.Resolve(cwd)
+	if filter == identity.Name || filter == identity.Group {
+		return true
+	}
+	return matchProjectPattern(identity.Path, filter)
+}
+
+// matchProjectPattern 检查路径是否匹配规则中的模式
+func matchProjectPattern(path, pattern string) bool {
+	if pattern == "" {
+		return false
+	}
+
+	// 不含路径分隔符的模式按目录名匹配
+	if !strings.ContainsAny(pattern, `/\`) {
+		matched, _ := filepath.Match(pattern, filepath.Base(path))
+		return matched
+	}
+
+	pattern = normalizeProjectPath(pattern)
+	if path == pattern || strings.HasPrefix(path, pattern+string(filepath.Separator)) {
+		return true
+	}
+	matched, _ := filepath.Match(pattern, path)
+	return matched
+}
+
+// normalizeProjectPath 规范化项目路径，去除多余的分隔符和末尾斜杠
+func normalizeProjectPath(path string) string {
+	if path == "" {
+		return path
+	}
+	return filepath.Clean(path)
+}
+
+// AnalyzeProjects 生成项目报告
+// byGroup 为 true 时将同一分组的项目合并为一行，未分组的项目保持独立
+func (p *ClaudeParser) AnalyzeProjects(stats *models.UsageStats, byGroup bool) *models.ProjectsReport {
+	report := &models.ProjectsReport{
+		Type:     "projects",
+		Projects: []models.ProjectStats{},
+		Summary: models.ProjectStats{
+			ProjectName: "total",
+		},
+	}
+
+	merged := make(map[string]*models.ProjectStats)
+	var order []string
+
+	for key, project := range stats.ProjectStats {
+		if byGroup && project.Group != "" {
+			key = "group:" + project.Group
+		}
+
+		target, exists := merged[key]
+		if !exists {
+			target = &models.ProjectStats{
+				ProjectName: project.ProjectName,
+				ProjectPath: project.ProjectPath,
+				Group:       project.Group,
+			}
+			if byGroup && project.Group != "" {
+				target.ProjectName = project.Group
+				target.ProjectPath = ""
+			}
+			merged[key] = target
+			order = append(order, key)
+		}
+
+		target.Merge(project)
+		if byGroup && project.Group != "" {
+			target.Paths = append(target.Paths, project.ProjectPath)
+		}
+
+		report.Summary.Merge(project)
+	}
+
+	for _, key := range order {
+		report.Projects = append(report.Projects, *merged[key])
+	}
+
+	return report
+}
@@ -0,0 +1,156 @@
+package parser
+
+import (
+	"strings"
+	"time"
+
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// 订阅计划名称（定义在 models 中，供 EstimatePlan 使用）
+const (
+	PlanPro    = models.PlanPro
+	PlanMax5x  = models.PlanMax5x
+	PlanMax20x = models.PlanMax20x
+)
+
+// 计划来源
+const (
+	PlanSourceConfigured = "configured" // 配置文件 plan: 或 --plan 指定
+	PlanSourceEstimated  = "estimated"  // 未指定计划，按历史用量推测
+)
+
+// PlanBudget 一个订阅计划在每个5小时窗口内的额度
+// 配置文件中可以覆盖内置的默认值，未填写的字段沿用默认值：
+//
+//	plan: max5x
+//	plans:
+//	  max5x:
+//	    messages: 250
+//	    tokens: 100000
+type PlanBudget struct {
+	Messages int `mapstructure:"messages"` // 每个窗口可发送的提问数
+	Tokens   int `mapstructure:"tokens"`   // 每个窗口的 token 额度（输入+输出，不含缓存）
+}
+
+// DefaultPlans 内置的各计划默认额度，均为社区观察到的近似值，官方并未公布确切数字
+var DefaultPlans = map[string]PlanBudget{
+	PlanPro:    {Messages: 45, Tokens: 19000},
+	PlanMax5x:  {Messages: 225, Tokens: 88000},
+	PlanMax20x: {Messages: 900, Tokens: 220000},
+}
+
+// QuotaOptions 计算订阅限额的参数
+type QuotaOptions struct {
+	Plan  string                // 指定的计划，为空时按历史用量推测
+	Plans map[string]PlanBudget // 配置文件中对各计划额度的覆盖，键不区分大小写
+	// 计费窗口，与 blocks 命令使用相同的划分（AnalyzeBlocks 的结果）；其中的活跃窗口即限额窗口
+	Blocks []models.BillingBlock
+}
+
+// ResolvePlan 将不区分大小写的计划名规范化为内置名称（pro、max5x、max-20x 等写法均可）
+func ResolvePlan(name string) (string, error) {
+	normalized := strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(name))
+	for plan := range DefaultPlans {
+		if strings.ToLower(plan) == normalized {
+			return plan, nil
+		}
+	}
+	return "", i18n.Errorf("err.unknown_plan", name)
+}
+
+// budget 取计划的额度，配置文件中的非零值覆盖默认值
+func (o QuotaOptions) budget(plan string) PlanBudget {
+	budget := DefaultPlans[plan]
+	for name, override := range o.Plans {
+		if resolved, err := ResolvePlan(name); err != nil || resolved != plan {
+			continue
+		}
+		if override.Messages > 0 {
+			budget.Messages = override.Messages
+		}
+		if override.Tokens > 0 {
+			budget.Tokens = override.Tokens
+		}
+	}
+	return budget
+}
+
+// AnalyzeQuota 按当前活跃窗口内的实际用量计算订阅限额
+// 未指定计划时才使用 EstimatePlan 的推测结果，并在 PlanSource 中注明
+func (p *ClaudeParser) AnalyzeQuota(stats *models.UsageStats, options QuotaOptions) (*models.SubscriptionQuota, error) {
+	quota := &models.SubscriptionQuota{PlanSource: PlanSourceConfigured}
+	if options.Plan != "" {
+		plan, err := ResolvePlan(options.Plan)
+		if err != nil {
+			return nil, err
+		}
+		quota.Plan = plan
+	} else {
+		quota.Plan, quota.DebugInfo = stats.EstimatePlan()
+		quota.PlanSource = PlanSourceEstimated
+	}
+
+	budget := options.budget(quota.Plan)
+	quota.MessagesPerWindow = budget.Messages
+	quota.TokensPerWindow = budget.Tokens
+	quota.ModelSwitchPoint = budget.Messages / 5 // 用量达到20%后切换到 Sonnet
+
+	if block := activeBlock(options.Blocks); block != nil {
+		start, end := block.StartTime, block.EndTime
+		quota.WindowStart = &start
+		quota.WindowEnd = &end
+		// 计费窗口的边界都在整点上，按小时汇总窗口内的提问数和 token
+		for hour := start.UTC(); hour.Before(end); hour = hour.Add(time.Hour) {
+			key := hour.Format(models.HourKeyLayout)
+			bucket := stats.HourlyStats[key]
+			quota.MessagesUsed += stats.HourlyPrompts[key]
+			quota.TokensUsed += bucket.Tokens.GetTotalTokens()
+		}
+	}
+
+	quota.Remaining = quota.MessagesPerWindow - quota.MessagesUsed
+	if quota.Remaining < 0 {
+		quota.Remaining = 0
+	}
+	quota.TokensRemaining = quota.TokensPerWindow - quota.TokensUsed
+	if quota.TokensRemaining < 0 {
+		quota.TokensRemaining = 0
+	}
+	return quota, nil
+}
+
+// activeBlock 返回当前所在的计费窗口，没有活跃窗口时返回 nil
+func activeBlock(blocks []models.BillingBlock) *models.BillingBlock {
+	for i := range blocks {
+		if blocks[i].IsActive {
+			return &blocks[i]
+		}
+	}
+	return nil
+}
+
+// processPrompt 按小时统计用户提问数（不含工具结果和元消息），作为限额中的消息用量
+func (p *ClaudeParser) processPrompt(stats *models.UsageStats, entry *models.ConversationEntry) {
+	if entry.Type != "user" || entry.IsMeta || entry.Timestamp.IsZero() {
+		return
+	}
+	if entry.ParsedMessage != nil && len(entry.ParsedMessage.ToolResults) > 0 {
+		return
+	}
+	if stats.HourlyPrompts == nil {
+		stats.HourlyPrompts = make(map[string]int)
+	}
+	stats.HourlyPrompts[entry.Timestamp.UTC().Format(models.HourKeyLayout)]++
+}
+
+// MergeHourlyPrompts 合并按小时统计的提问数
+func MergeHourlyPrompts(target, source *models.UsageStats) {
+	if len(source.HourlyPrompts) > 0 && target.HourlyPrompts == nil {
+		target.HourlyPrompts = make(map[string]int)
+	}
+	for hour, count := range source.HourlyPrompts {
+		target.HourlyPrompts[hour] += count
+	}
+}
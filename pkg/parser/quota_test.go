@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// TestQuotaUsesActiveBlock 限额窗口取自计费窗口中的活跃窗口，只统计该窗口内的提问数和 token
+func TestQuotaUsesActiveBlock(t *testing.T) {
+	start := time.Date(2026, 10, 18, 20, 0, 0, 0, time.UTC)
+	hour := func(offset int) string {
+		return start.Add(time.Duration(offset) * time.Hour).Format(models.HourKeyLayout)
+	}
+	stats := &models.UsageStats{
+		HourlyStats: map[string]models.UsageBucket{
+			hour(-1): {Tokens: models.TokenUsage{InputTokens: 1000}},
+			hour(0):  {Tokens: models.TokenUsage{InputTokens: 100, OutputTokens: 200}},
+			hour(4):  {Tokens: models.TokenUsage{OutputTokens: 50}},
+			hour(5):  {Tokens: models.TokenUsage{InputTokens: 7000}},
+		},
+		HourlyPrompts: map[string]int{hour(-1): 9, hour(0): 2, hour(4): 1, hour(5): 4},
+	}
+	blocks := []models.BillingBlock{
+		{StartTime: start.Add(-5 * time.Hour), EndTime: start},
+		{StartTime: start, EndTime: start.Add(5 * time.Hour), IsActive: true},
+	}
+
+	quota, err := NewClaudeParser().AnalyzeQuota(stats, QuotaOptions{Plan: "pro", Blocks: blocks})
+	if err != nil {
+		t.Fatalf("计算限额失败: %v", err)
+	}
+	if quota.WindowStart == nil || !quota.WindowStart.Equal(start) || !quota.WindowEnd.Equal(start.Add(5*time.Hour)) {
+		t.Fatalf("限额窗口 = %v - %v，期望从 %s 开始的活跃计费窗口", quota.WindowStart, quota.WindowEnd, start)
+	}
+	if quota.MessagesUsed != 3 || quota.TokensUsed != 350 {
+		t.Errorf("窗口内用量 = %d 条提问、%d token，期望 3 条、350 token", quota.MessagesUsed, quota.TokensUsed)
+	}
+
+	// 没有活跃的计费窗口时不显示窗口，剩余额度为整个计划额度
+	blocks[1].IsActive = false
+	quota, err = NewClaudeParser().AnalyzeQuota(stats, QuotaOptions{Plan: "pro", Blocks: blocks})
+	if err != nil {
+		t.Fatalf("计算限额失败: %v", err)
+	}
+	if quota.WindowStart != nil || quota.MessagesUsed != 0 || quota.Remaining != quota.MessagesPerWindow {
+		t.Errorf("没有活跃窗口时 = %+v，期望没有窗口和用量", quota)
+	}
+}
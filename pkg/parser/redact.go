@@ -0,0 +1,22 @@
+package parser
+
+import (
+	"path/filepath"
+)
+
+// resolveProject 解析项目标识；脱敏时按原始路径匹配别名/分组规则，再替换输出的路径
+// 配置中设置的别名和分组名是用户自己取的标签，保持不变
+func (p *ClaudeParser) resolveProject(cwd string) ProjectIdentity {
+	identity := p.Projects.Resolve(cwd)
+	if !p.Redactor.Enabled() {
+		return identity
+	}
+
+	aliased := identity.Key != identity.Path
+	identity.Path = p.Redactor.Path(identity.Path)
+	if !aliased {
+		identity.Key = identity.Path
+		identity.Name = filepath.Base(identity.Path)
+	}
+	return identity
+}
@@ -0,0 +1,28 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+)
+
+// 子代理（sidechain）记录的过滤方式
+const (
+	SidechainInclude = "include" // 包含主线程和子代理（默认）
+	SidechainExclude = "exclude" // 仅统计主线程
+	SidechainOnly    = "only"    // 仅统计子代理
+)
+
+// ParseSidechainMode 校验并规范化 --sidechain 参数
+func ParseSidechainMode(mode string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", SidechainInclude:
+		return SidechainInclude, nil
+	case SidechainExclude:
+		return SidechainExclude, nil
+	case SidechainOnly:
+		return SidechainOnly, nil
+	default:
+		return "", i18n.Errorf("err.unsupported_sidechain", mode)
+	}
+}
@@ -0,0 +1,23 @@
+package parser
+
+import (
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// AnalyzeTools 生成工具使用报告
+func (p *ClaudeParser) AnalyzeTools(stats *models.UsageStats) *models.ToolsReport {
+	report := &models.ToolsReport{
+		Type:  "tools",
+		Tools: []models.ToolStats{},
+		Summary: models.ToolStats{
+			Name: "total",
+		},
+	}
+
+	for _, tool := range stats.ToolStats {
+		report.Tools = append(report.Tools, tool)
+		report.Summary.Merge(tool)
+	}
+
+	return report
+}
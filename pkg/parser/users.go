@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"os"
+	"os/user"
+	"sort"
+
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// DefaultUserLabel 返回当前系统用户名，作为实时日志的默认用户标签
+func DefaultUserLabel() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
+
+// DefaultHostname 返回当前主机名
+func DefaultHostname() string {
+	host, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	return host
+}
+
+// entryUser 返回条目所属的用户标签：快照/归档记录自带标签，实时日志使用解析器的标签
+func (p *ClaudeParser) entryUser(entry *models.ConversationEntry) string {
+	if entry.User != "" {
+		return entry.User
+	}
+	return p.User
+}
+
+// entryHost 返回条目来源的主机名
+func (p *ClaudeParser) entryHost(entry *models.ConversationEntry) string {
+	if entry.User != "" {
+		return entry.Host
+	}
+	return p.Host
+}
+
+// processUser 更新用户维度的统计
+func (p *ClaudeParser) processUser(stats *models.UsageStats, entry *models.ConversationEntry) {
+	name := p.entryUser(entry)
+	if name == "" {
+		return
+	}
+	if stats.UserStats == nil {
+		stats.UserStats = make(map[string]models.UserStats)
+	}
+
+	userStats := stats.UserStats[name]
+	userStats.User = name
+	projectKey := ""
+	if entry.CWD != "" {
+		projectKey = p.resolveProject(entry.CWD).Key
+	}
+	userStats.AddActivity(entry.SessionID, projectKey, p.Redactor.Host(p.entryHost(entry)), entry.Timestamp)
+	userStats.MessageCount++
+
+	if entry.ExtractedUsage != nil && !entry.ExtractedUsage.IsEmpty() {
+		cost := p.entryCost(entry)
+		userStats.Tokens.Add(*entry.ExtractedUsage)
+		userStats.Cost += cost
+		if entry.IsSidechain {
+			userStats.Sidechain.Add(*entry.ExtractedUsage, cost)
+		}
+
+		if userStats.Models == nil {
+			userStats.Models = make(map[string]models.UsageBucket)
+		}
+		model := entryModel(entry)
+		bucket := userStats.Models[model]
+		bucket.Add(*entry.ExtractedUsage, cost)
+		userStats.Models[model] = bucket
+	}
+
+	stats.UserStats[name] = userStats
+}
+
+// MergeUserStats 将 source 中的用户统计合并到 target
+func MergeUserStats(target, source *models.UsageStats) {
+	if len(source.UserStats) > 0 && target.UserStats == nil {
+		target.UserStats = make(map[string]models.UserStats)
+	}
+	for name, userStats := range source.UserStats {
+		existing := target.UserStats[name]
+		existing.User = name
+		existing.Merge(userStats)
+		target.UserStats[name] = existing
+	}
+}
+
+// AnalyzeUsers 生成用户报告，按成本降序排列
+func (p *ClaudeParser) AnalyzeUsers(stats *models.UsageStats) *models.UsersReport {
+	report := &models.UsersReport{
+		Type:  "users",
+		Users: []models.UserStats{},
+		Summary: models.UserStats{
+			User: "total",
+		},
+	}
+
+	for _, userStats := range stats.UserStats {
+		sort.Strings(userStats.Hosts)
+		report.Users = append(report.Users, userStats)
+		report.Summary.Merge(userStats)
+	}
+	report.Summary.User = "total"
+
+	sort.Slice(report.Users, func(i, j int) bool {
+		if report.Users[i].Cost != report.Users[j].Cost {
+			return report.Users[i].Cost > report.Users[j].Cost
+		}
+		return report.Users[i].User < report.Users[j].User
+	})
+	return report
+}
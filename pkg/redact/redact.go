@@ -0,0 +1,232 @@
+// Package redact 在导出、快照、报告和调试输出中隐藏项目路径、会话ID等可识别信息
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// 脱敏方式
+const (
+	ModeNone  = "none"  // 不脱敏
+	ModePaths = "paths" // 去掉路径中的主目录和用户名，其余保持原样
+	ModeHash  = "hash"  // 项目路径、会话ID、分支和主机名替换为哈希
+)
+
+// hashLength 哈希标识保留的十六进制字符数
+const hashLength = 12
+
+// homePattern 匹配常见系统中其他用户的主目录前缀
+var homePattern = regexp.MustCompile(`^(/home/[^/]+|/Users/[^/]+|/root|[A-Za-z]:\\Users\\[^\\]+)`)
+
+// homeTextPattern 匹配文本中任意位置的主目录（含用户名的完整一段，用户名中可以有 - 和 .）
+var homeTextPattern = regexp.MustCompile(`(/home/|/Users/)[^/\\\s'":]+`)
+
+// encodedHomePattern 匹配 Claude Code 项目目录名中其他用户主目录的编码形式（-home-alice-work-api）
+// 编码后无法区分用户名和后续路径，因此替换整个目录名
+var encodedHomePattern = regexp.MustCompile(`(^|[/\\\s'"=])-(?:home|Users)-[^/\\\s'":]+`)
+
+// projectDirPattern Claude Code 生成项目目录名时替换为 - 的字符
+var projectDirPattern = regexp.MustCompile(`[^A-Za-z0-9-]`)
+
+// EncodeProjectDir 按 Claude Code 的规则将项目路径编码为 ~/.claude/projects 下的目录名（/home/alice/api → -home-alice-api）
+func EncodeProjectDir(path string) string {
+	return projectDirPattern.ReplaceAllString(path, "-")
+}
+
+// hashedBranchPattern 匹配 hash 模式脱敏后的分支名
+var hashedBranchPattern = regexp.MustCompile(`^branch-[0-9a-f]{12}$`)
+
+// Redactor 脱敏器；nil 或 ModeNone 时所有方法原样返回
+// hash 模式使用每次运行随机生成的密钥，同一次导出中相同的值得到相同的哈希，不同导出之间无法关联
+type Redactor struct {
+	Mode string
+
+	logsOnly bool // 只处理调试输出，数据保持原样
+	home     string
+	user     string
+	key      []byte
+}
+
+// New 创建指定方式的脱敏器，mode 为空时等同于 none
+func New(mode string) (*Redactor, error) {
+	mode = strings.ToLower(mode)
+	switch mode {
+	case "", ModeNone:
+		return &Redactor{Mode: ModeNone}, nil
+	case ModePaths, ModeHash:
+	default:
+		return nil, i18n.Errorf("err.unsupported_redact", mode)
+	}
+
+	r := &Redactor{Mode: mode}
+	if home, err := os.UserHomeDir(); err == nil {
+		r.home = filepath.Clean(home)
+	}
+	if current, err := user.Current(); err == nil {
+		r.user = current.Username
+	}
+	if mode == ModeHash {
+		r.key = make([]byte, 32)
+		if _, err := rand.Read(r.key); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Enabled 是否需要脱敏（调试输出中不显示消息内容等也以此为准）
+func (r *Redactor) Enabled() bool {
+	return r != nil && r.Mode != ModeNone && r.Mode != ""
+}
+
+// LogsOnly 返回只处理调试输出的副本：路径、会话ID等数据保持原样，供需要按原始数据匹配规则的解析使用
+func (r *Redactor) LogsOnly() *Redactor {
+	if r == nil {
+		return nil
+	}
+	copied := *r
+	copied.logsOnly = true
+	return &copied
+}
+
+// redactsData 是否需要对报告和导出数据脱敏
+func (r *Redactor) redactsData() bool {
+	return r.Enabled() && !r.logsOnly
+}
+
+// Path 脱敏项目路径：paths 模式将主目录替换为 ~，hash 模式替换为 project-<哈希>
+func (r *Redactor) Path(path string) string {
+	if !r.redactsData() || path == "" {
+		return path
+	}
+	if r.Mode == ModeHash {
+		return "project-" + r.hash("path", filepath.Clean(path))
+	}
+	return r.stripHome(path)
+}
+
+// File 脱敏日志文件路径（调试输出用）；hash 模式只保留扩展名
+func (r *Redactor) File(path string) string {
+	if !r.Enabled() || path == "" {
+		return path
+	}
+	if r.Mode == ModeHash {
+		return "file-" + r.hash("file", path) + filepath.Ext(path)
+	}
+	return r.Text(path)
+}
+
+// Session 脱敏会话ID（仅 hash 模式）
+func (r *Redactor) Session(id string) string {
+	return r.identifier("session", id)
+}
+
+// Branch 脱敏Git分支名（仅 hash 模式）
+func (r *Redactor) Branch(branch string) string {
+	return r.identifier("branch", branch)
+}
+
+// IsHashedBranch 分支名是否为 hash 模式脱敏后的结果（例如来自脱敏的团队快照），这类名称无法在仓库中解析
+func IsHashedBranch(branch string) bool {
+	return hashedBranchPattern.MatchString(branch)
+}
+
+// Remote 脱敏Git远程地址（仅 hash 模式）
+func (r *Redactor) Remote(remote string) string {
+	return r.identifier("remote", remote)
+}
+
+// Host 脱敏主机名（仅 hash 模式）
+func (r *Redactor) Host(host string) string {
+	return r.identifier("host", host)
+}
+
+// Text 从任意文本中去掉主目录和用户名，用于调试输出中的路径和错误信息
+// Claude Code 的项目目录名由路径中的 / 替换为 - 得到，因此同时替换这种编码形式
+func (r *Redactor) Text(text string) string {
+	if !r.Enabled() || text == "" {
+		return text
+	}
+	if r.home != "" && r.home != string(filepath.Separator) {
+		text = strings.ReplaceAll(text, r.home, "~")
+		// 当前用户的主目录已知，编码形式中只替换主目录部分，保留项目路径
+		text = strings.ReplaceAll(text, EncodeProjectDir(r.home), "-~")
+	}
+	if r.user != "" {
+		text = strings.ReplaceAll(text, string(filepath.Separator)+r.user+string(filepath.Separator), string(filepath.Separator)+"~user"+string(filepath.Separator))
+	}
+	text = homeTextPattern.ReplaceAllString(text, "~")
+	return encodedHomePattern.ReplaceAllString(text, "${1}-~")
+}
+
+// Error 脱敏错误信息中的路径；不需要脱敏时原样返回，保留错误链
+func (r *Redactor) Error(err error) error {
+	if !r.Enabled() || err == nil {
+		return err
+	}
+	return errors.New(r.Text(err.Error()))
+}
+
+// Record 脱敏一条导出记录；requestId 是 API 生成的随机标识，保留用于导入时去重
+func (r *Redactor) Record(record models.UsageRecord) models.UsageRecord {
+	if !r.redactsData() {
+		return record
+	}
+	record.ProjectPath = r.Path(record.ProjectPath)
+	record.SessionID = r.Session(record.SessionID)
+	record.GitBranch = r.Branch(record.GitBranch)
+	record.Host = r.Host(record.Host)
+	return record
+}
+
+// identifier hash 模式下将标识替换为 <类别>-<哈希>，其他模式原样返回
+func (r *Redactor) identifier(kind, value string) string {
+	if !r.redactsData() || r.Mode != ModeHash || value == "" {
+		return value
+	}
+	return kind + "-" + r.hash(kind, value)
+}
+
+// stripHome 将路径开头的主目录替换为 ~，路径中与当前用户名相同的目录名替换为 ~user
+func (r *Redactor) stripHome(path string) string {
+	clean := filepath.Clean(path)
+	switch {
+	case r.home != "" && r.home != string(filepath.Separator) &&
+		(clean == r.home || strings.HasPrefix(clean, r.home+string(filepath.Separator))):
+		clean = "~" + clean[len(r.home):]
+	case homePattern.MatchString(clean):
+		clean = homePattern.ReplaceAllString(clean, "~")
+	}
+
+	if r.user != "" {
+		parts := strings.Split(clean, string(filepath.Separator))
+		for i, part := range parts {
+			if part == r.user {
+				parts[i] = "~user"
+			}
+		}
+		clean = strings.Join(parts, string(filepath.Separator))
+	}
+	return clean
+}
+
+// hash 计算带密钥的哈希，不同类别的相同值得到不同结果
+func (r *Redactor) hash(kind, value string) string {
+	mac := hmac.New(sha256.New, r.key)
+	mac.Write([]byte(kind))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))[:hashLength]
+}
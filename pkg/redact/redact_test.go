@@ -0,0 +1,185 @@
+package redact
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// 测试用的当前用户和另一位用户；用户名中带 - 和 .，覆盖只匹配到第一个 - 的旧问题
+const (
+	testHome      = "/home/john-doe"
+	testUser      = "john-doe"
+	otherUserHome = "/home/alice.smith-jr"
+)
+
+// leakedParts 脱敏结果中不应出现的片段
+var leakedParts = []string{"john", "doe", "alice", "smith"}
+
+// newTestRedactor 创建主目录和用户名固定的脱敏器，不依赖运行测试的机器
+func newTestRedactor(t *testing.T, mode string) *Redactor {
+	t.Helper()
+	r, err := New(mode)
+	if err != nil {
+		t.Fatalf("New(%q) 失败: %v", mode, err)
+	}
+	r.home = testHome
+	r.user = testUser
+	return r
+}
+
+// assertNoLeak 检查脱敏结果中没有用户名的任何部分
+func assertNoLeak(t *testing.T, label, input, output string) {
+	t.Helper()
+	for _, part := range leakedParts {
+		if strings.Contains(output, part) {
+			t.Errorf("%s(%q) = %q，泄露了 %q", label, input, output, part)
+		}
+	}
+}
+
+func TestPath(t *testing.T) {
+	paths := []string{
+		testHome,
+		testHome + "/work/api",
+		otherUserHome + "/oss/cli",
+		"/Users/john-doe/Projects/app",
+		"/srv/john-doe/data",
+	}
+
+	t.Run("paths", func(t *testing.T) {
+		r := newTestRedactor(t, ModePaths)
+		expected := map[string]string{
+			testHome:                       "~",
+			testHome + "/work/api":         "~/work/api",
+			otherUserHome + "/oss/cli":     "~/oss/cli",
+			"/Users/john-doe/Projects/app": "~/Projects/app",
+			"/srv/john-doe/data":           "/srv/~user/data",
+		}
+		for _, path := range paths {
+			got := r.Path(path)
+			assertNoLeak(t, "Path", path, got)
+			if got != expected[path] {
+				t.Errorf("Path(%q) = %q，期望 %q", path, got, expected[path])
+			}
+		}
+	})
+
+	t.Run("hash", func(t *testing.T) {
+		r := newTestRedactor(t, ModeHash)
+		for _, path := range paths {
+			got := r.Path(path)
+			assertNoLeak(t, "Path", path, got)
+			if !strings.HasPrefix(got, "project-") {
+				t.Errorf("Path(%q) = %q，期望 project-<哈希>", path, got)
+			}
+			if again := r.Path(path); again != got {
+				t.Errorf("Path(%q) 两次结果不同: %q, %q", path, got, again)
+			}
+		}
+	})
+}
+
+func TestText(t *testing.T) {
+	texts := []string{
+		"open " + testHome + "/work/api/main.go: permission denied",
+		"git -C " + otherUserHome + "/oss/cli log: fatal",
+		"projects/-home-john-doe-work-api/session.jsonl",
+		"projects/-home-alice-smith-jr-oss-cli/session.jsonl",
+		"-home-alice-smith-jr-oss-cli",
+		"'/Users/alice.smith-jr/Library' not found",
+		"cwd=/srv/john-doe/tmp",
+	}
+
+	for _, mode := range []string{ModePaths, ModeHash} {
+		t.Run(mode, func(t *testing.T) {
+			r := newTestRedactor(t, mode)
+			for _, text := range texts {
+				assertNoLeak(t, "Text", text, r.Text(text))
+			}
+			// 当前用户主目录的编码形式只替换主目录部分
+			if got := r.Text("projects/-home-john-doe-work-api/x"); got != "projects/-~-work-api/x" {
+				t.Errorf("Text 编码目录 = %q，期望 projects/-~-work-api/x", got)
+			}
+			// 不含主目录的文本保持不变
+			if got := r.Text("/work/my-home-page/index.html"); got != "/work/my-home-page/index.html" {
+				t.Errorf("Text 修改了不含主目录的路径: %q", got)
+			}
+		})
+	}
+}
+
+func TestFile(t *testing.T) {
+	file := testHome + "/.claude/projects/-home-john-doe-work-api/0a1b2c.jsonl"
+
+	t.Run("paths", func(t *testing.T) {
+		got := newTestRedactor(t, ModePaths).File(file)
+		assertNoLeak(t, "File", file, got)
+		if got != "~/.claude/projects/-~-work-api/0a1b2c.jsonl" {
+			t.Errorf("File(%q) = %q", file, got)
+		}
+	})
+
+	t.Run("hash", func(t *testing.T) {
+		got := newTestRedactor(t, ModeHash).File(file)
+		assertNoLeak(t, "File", file, got)
+		if !strings.HasPrefix(got, "file-") || !strings.HasSuffix(got, ".jsonl") || strings.Contains(got, "/") {
+			t.Errorf("File(%q) = %q，期望 file-<哈希>.jsonl", file, got)
+		}
+	})
+}
+
+func TestRecord(t *testing.T) {
+	record := models.UsageRecord{
+		Timestamp:    time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+		SessionID:    "3f0c2a9e-john-doe-session",
+		RequestID:    "req_011CXYZ",
+		ProjectPath:  otherUserHome + "/work/api",
+		GitBranch:    "john-doe/feature",
+		Model:        "claude-sonnet-4-20250514",
+		InputTokens:  100,
+		OutputTokens: 200,
+		CostUSD:      0.5,
+		Host:         "john-doe-laptop",
+	}
+
+	t.Run("paths", func(t *testing.T) {
+		got := newTestRedactor(t, ModePaths).Record(record)
+		assertNoLeak(t, "Record.ProjectPath", record.ProjectPath, got.ProjectPath)
+		if got.ProjectPath != "~/work/api" {
+			t.Errorf("ProjectPath = %q，期望 ~/work/api", got.ProjectPath)
+		}
+		// paths 模式只处理路径
+		if got.SessionID != record.SessionID || got.GitBranch != record.GitBranch || got.Host != record.Host {
+			t.Errorf("paths 模式修改了路径以外的字段: %+v", got)
+		}
+	})
+
+	t.Run("hash", func(t *testing.T) {
+		got := newTestRedactor(t, ModeHash).Record(record)
+		for label, value := range map[string]string{
+			"ProjectPath": got.ProjectPath,
+			"SessionID":   got.SessionID,
+			"GitBranch":   got.GitBranch,
+			"Host":        got.Host,
+		} {
+			assertNoLeak(t, "Record."+label, "", value)
+		}
+		if !IsHashedBranch(got.GitBranch) {
+			t.Errorf("GitBranch = %q，期望 branch-<哈希>", got.GitBranch)
+		}
+		// requestId 用于导入去重，数值字段不受影响
+		if got.RequestID != record.RequestID || got.CostUSD != record.CostUSD || got.InputTokens != record.InputTokens {
+			t.Errorf("hash 模式修改了不应脱敏的字段: %+v", got)
+		}
+	})
+
+	t.Run("logs only", func(t *testing.T) {
+		got := newTestRedactor(t, ModeHash).LogsOnly().Record(record)
+		if got != record {
+			t.Errorf("LogsOnly 修改了数据: %+v", got)
+		}
+	})
+}
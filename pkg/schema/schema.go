@@ -0,0 +1,304 @@
+// Package schema 由 models 中的报告结构生成 JSON Schema 文档（draft 2020-12），
+// 并与 golden 目录中提交的文档对比：报告结构变化而 models.SchemaVersion 没有递增时检查失败
+package schema
+
+//go:generate go run ../.. schema --write golden
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+const (
+	draft   = "https://json-schema.org/draft/2020-12/schema"
+	idBase  = "https://github.com/zhuiye8/claude-stats/schema/"
+	fileExt = ".schema.json"
+)
+
+// Report 一种 JSON 输出
+type Report struct {
+	Name    string // schema 命令中使用的名称，也是 golden 文件名
+	Command string // 产生该输出的命令
+	Type    reflect.Type
+}
+
+// Reports 全部 JSON 输出，按名称排序
+// export 的 NDJSON 每行是一条 record（--rollup daily 时为 rollup），每行带 schema_version
+var Reports = []Report{
+	{"analyze", "analyze --format json", reflect.TypeOf(models.UsageStats{})},
+	{"anomalies", "anomalies --format json", reflect.TypeOf(models.AnomalyReport{})},
+	{"blocks", "blocks --format json", reflect.TypeOf(models.BlocksReport{})},
+	{"branches", "branches --format json", reflect.TypeOf(models.BranchesReport{})},
+	{"cache", "cache --format json", reflect.TypeOf(models.CacheReport{})},
+	{"ccusage-verify", "import-ccusage --verify --format json", reflect.TypeOf(models.CCUsageVerifyReport{})},
+	{"chargeback", "chargeback --format json", reflect.TypeOf(models.ChargebackReport{})},
+	{"compare", "compare --format json", reflect.TypeOf(models.CompareReport{})},
+	{"daily", "daily --format json", reflect.TypeOf(models.DailyReport{})},
+	{"forecast", "forecast --format json", reflect.TypeOf(models.ForecastReport{})},
+	{"heatmap", "heatmap --format json", reflect.TypeOf(models.HeatmapReport{})},
+	{"latency", "latency --format json", reflect.TypeOf(models.LatencyReport{})},
+	{"projects", "projects --format json", reflect.TypeOf(models.ProjectsReport{})},
+	{"query", "query --format json", reflect.TypeOf(models.QueryResult{})},
+	{"record", "export (NDJSON)", reflect.TypeOf(models.ExportRecord{})},
+	{"rollup", "export --rollup daily (NDJSON)", reflect.TypeOf(models.ExportRollup{})},
+	{"snapshot", "export --write-snapshot", reflect.TypeOf(models.Snapshot{})},
+	{"tools", "tools --format json", reflect.TypeOf(models.ToolsReport{})},
+	{"users", "users --format json", reflect.TypeOf(models.UsersReport{})},
+}
+
+// Lookup 按名称查找输出
+func Lookup(name string) (Report, bool) {
+	for _, report := range Reports {
+		if report.Name == name {
+			return report, true
+		}
+	}
+	return Report{}, false
+}
+
+// Names 返回全部输出名称
+func Names() []string {
+	names := make([]string, len(Reports))
+	for i, report := range Reports {
+		names[i] = report.Name
+	}
+	return names
+}
+
+// Generate 生成输出的 JSON Schema 文档；结构体类型放在 $defs 中，报告本身的字段直接展开
+func Generate(report Report) ([]byte, error) {
+	g := &generator{defs: make(map[string]interface{})}
+	doc, err := g.object(report.Type)
+	if err != nil {
+		return nil, err
+	}
+	doc["$schema"] = draft
+	doc["$id"] = fmt.Sprintf("%sv%d/%s%s", idBase, models.SchemaVersion, report.Name, fileExt)
+	doc["title"] = report.Type.Name()
+	doc["description"] = "claude-stats " + report.Command
+	if len(g.defs) > 0 {
+		doc["$defs"] = g.defs
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// generator 递归生成类型的 schema，defs 收集遇到的结构体定义
+type generator struct {
+	defs map[string]interface{}
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// schema 返回类型的 schema；nil 切片和 map 序列化为 null，因此允许 null
+func (g *generator) schema(t reflect.Type) (map[string]interface{}, error) {
+	switch {
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+	case t == durationType:
+		return map[string]interface{}{"type": "integer", "description": "nanoseconds"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		inner, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nullable(inner), nil
+	case reflect.Struct:
+		name := t.Name()
+		if _, ok := g.defs[name]; !ok {
+			g.defs[name] = nil // 先占位，避免递归类型无限展开
+			def, err := g.object(t)
+			if err != nil {
+				return nil, err
+			}
+			g.defs[name] = def
+		}
+		return map[string]interface{}{"$ref": "#/$defs/" + name}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": []string{"array", "null"}, "items": items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, i18n.Errorf("err.schema_type", t.String())
+		}
+		values, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": []string{"object", "null"}, "additionalProperties": values}, nil
+	case reflect.Interface:
+		return map[string]interface{}{}, nil
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	}
+	return nil, i18n.Errorf("err.schema_type", t.String())
+}
+
+// object 生成结构体的 object schema；匿名嵌入的结构体字段按 encoding/json 的规则展开到外层
+func (g *generator) object(t reflect.Type) (map[string]interface{}, error) {
+	properties := make(map[string]interface{})
+	required := []string{}
+	if err := g.fields(t, properties, &required); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}, nil
+}
+
+// fields 收集结构体字段；没有 json 标签的导出字段会以 Go 字段名输出，视为错误
+func (g *generator) fields(t reflect.Type, properties map[string]interface{}, required *[]string) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			if err := g.fields(field.Type, properties, required); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			return i18n.Errorf("err.schema_untagged", t.Name(), field.Name)
+		}
+
+		var property map[string]interface{}
+		if name == "schema_version" {
+			property = map[string]interface{}{"type": "integer", "const": models.SchemaVersion}
+		} else {
+			var err error
+			if property, err = g.schema(field.Type); err != nil {
+				return err
+			}
+		}
+		properties[name] = property
+		if !strings.Contains(","+options+",", ",omitempty,") {
+			*required = append(*required, name)
+		}
+	}
+	return nil
+}
+
+// nullable 允许值为 null
+func nullable(schema map[string]interface{}) map[string]interface{} {
+	if typ, ok := schema["type"].(string); ok {
+		copied := make(map[string]interface{}, len(schema))
+		for key, value := range schema {
+			copied[key] = value
+		}
+		copied["type"] = []string{typ, "null"}
+		return copied
+	}
+	if _, ok := schema["type"]; ok || len(schema) == 0 {
+		return schema // 已允许 null，或任意值
+	}
+	return map[string]interface{}{"anyOf": []interface{}{schema, map[string]interface{}{"type": "null"}}}
+}
+
+// Write 将全部输出的 schema 写入目录（<名称>.schema.json），返回写入的文件数
+func Write(dir string) (int, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return 0, err
+	}
+	for i, report := range Reports {
+		data, err := Generate(report)
+		if err != nil {
+			return i, err
+		}
+		if err := os.WriteFile(filepath.Join(dir, report.Name+fileExt), data, 0644); err != nil {
+			return i, err
+		}
+	}
+	return len(Reports), nil
+}
+
+//go:embed golden/*.schema.json
+var golden embed.FS
+
+// 对比 golden 文件的结果
+const (
+	CheckChanged  = "changed"  // schema 变化但 SchemaVersion 没有递增
+	CheckOutdated = "outdated" // SchemaVersion 已递增，golden 文件尚未重新生成
+	CheckMissing  = "missing"  // 没有 golden 文件
+)
+
+// Problem golden 对比发现的问题
+type Problem struct {
+	Report        string
+	Kind          string
+	GoldenVersion int
+}
+
+// goldenVersion 从 golden 文档的 $id 中读取格式版本
+var goldenVersion = regexp.MustCompile(`/schema/v(\d+)/`)
+
+// Check 将当前生成的 schema 与编译时嵌入的 golden 文件逐个对比
+func Check() ([]Problem, error) {
+	var problems []Problem
+	for _, report := range Reports {
+		generated, err := Generate(report)
+		if err != nil {
+			return nil, err
+		}
+		expected, err := golden.ReadFile("golden/" + report.Name + fileExt)
+		if err != nil {
+			problems = append(problems, Problem{Report: report.Name, Kind: CheckMissing})
+			continue
+		}
+		if string(generated) == string(expected) {
+			continue
+		}
+
+		version := 0
+		if match := goldenVersion.FindSubmatch(expected); match != nil {
+			version, _ = strconv.Atoi(string(match[1]))
+		}
+		kind := CheckChanged
+		if version != models.SchemaVersion {
+			kind = CheckOutdated
+		}
+		problems = append(problems, Problem{Report: report.Name, Kind: kind, GoldenVersion: version})
+	}
+	return problems, nil
+}
@@ -0,0 +1,28 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// TestGolden 报告结构与 golden 文件不一致时失败：结构变化必须递增 SchemaVersion 并重新生成 golden 文件
+func TestGolden(t *testing.T) {
+	problems, err := Check()
+	if err != nil {
+		t.Fatalf("生成 schema 失败: %v", err)
+	}
+
+	for _, problem := range problems {
+		switch problem.Kind {
+		case CheckChanged:
+			t.Errorf("%s: %s — 结构已变化，但 schema_version 仍为 %d；请递增 models.SchemaVersion 并运行 go generate ./pkg/schema",
+				problem.Report, problem.Kind, models.SchemaVersion)
+		case CheckOutdated:
+			t.Errorf("%s: %s — golden 文件为 v%d，当前 schema_version 为 %d；请运行 go generate ./pkg/schema",
+				problem.Report, problem.Kind, problem.GoldenVersion, models.SchemaVersion)
+		default:
+			t.Errorf("%s: %s — 缺少 golden 文件；请运行 go generate ./pkg/schema", problem.Report, problem.Kind)
+		}
+	}
+}
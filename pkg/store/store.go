@@ -0,0 +1,265 @@
+// Package store 提供可选的本地 SQLite 用量数据库，用于增量同步日志并执行只读 SQL 查询
+// 使用纯 Go 实现的 SQLite 驱动，不依赖 cgo
+package store
+
+import (
+	"database/sql"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+	_ "modernc.org/sqlite"
+)
+
+// DefaultFileName 默认数据库文件名，位于用户主目录下
+const DefaultFileName = ".claude-stats.db"
+
+// schema 数据库结构；entries 为逐条明细，sessions/projects/blocks 在每次同步后由 entries 重建
+const schema = `
+CREATE TABLE IF NOT EXISTS files (
+	path      TEXT PRIMARY KEY,
+	size      INTEGER NOT NULL,
+	offset    INTEGER NOT NULL,
+	synced_at TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS entries (
+	file                  TEXT NOT NULL,
+	line_offset           INTEGER NOT NULL,
+	timestamp             TEXT NOT NULL,
+	date                  TEXT NOT NULL,
+	block_start           TEXT NOT NULL,
+	session_id            TEXT NOT NULL,
+	request_id            TEXT NOT NULL,
+	project_path          TEXT NOT NULL,
+	git_branch            TEXT NOT NULL,
+	model                 TEXT NOT NULL,
+	input_tokens          INTEGER NOT NULL,
+	output_tokens         INTEGER NOT NULL,
+	cache_creation_tokens INTEGER NOT NULL,
+	cache_read_tokens     INTEGER NOT NULL,
+	cost_usd              REAL NOT NULL,
+	price_version         TEXT NOT NULL,
+	is_sidechain          INTEGER NOT NULL,
+	PRIMARY KEY (file, line_offset)
+);
+CREATE INDEX IF NOT EXISTS entries_timestamp ON entries (timestamp);
+CREATE INDEX IF NOT EXISTS entries_session ON entries (session_id);
+CREATE INDEX IF NOT EXISTS entries_project ON entries (project_path);
+
+CREATE TABLE IF NOT EXISTS sessions (
+	session_id            TEXT PRIMARY KEY,
+	project_path          TEXT NOT NULL,
+	start_time            TEXT NOT NULL,
+	end_time              TEXT NOT NULL,
+	entries               INTEGER NOT NULL,
+	input_tokens          INTEGER NOT NULL,
+	output_tokens         INTEGER NOT NULL,
+	cache_creation_tokens INTEGER NOT NULL,
+	cache_read_tokens     INTEGER NOT NULL,
+	cost_usd              REAL NOT NULL,
+	sidechain_cost_usd    REAL NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS projects (
+	project_path          TEXT PRIMARY KEY,
+	name                  TEXT NOT NULL,
+	group_name            TEXT NOT NULL,
+	first_activity        TEXT NOT NULL,
+	last_activity         TEXT NOT NULL,
+	sessions              INTEGER NOT NULL,
+	entries               INTEGER NOT NULL,
+	input_tokens          INTEGER NOT NULL,
+	output_tokens         INTEGER NOT NULL,
+	cache_creation_tokens INTEGER NOT NULL,
+	cache_read_tokens     INTEGER NOT NULL,
+	cost_usd              REAL NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS blocks (
+	start_time            TEXT PRIMARY KEY,
+	end_time              TEXT NOT NULL,
+	sessions              INTEGER NOT NULL,
+	entries               INTEGER NOT NULL,
+	input_tokens          INTEGER NOT NULL,
+	output_tokens         INTEGER NOT NULL,
+	cache_creation_tokens INTEGER NOT NULL,
+	cache_read_tokens     INTEGER NOT NULL,
+	cost_usd              REAL NOT NULL,
+	models                TEXT NOT NULL
+);
+`
+
+// Store 本地 SQLite 用量数据库
+type Store struct {
+	db *sql.DB
+}
+
+// DefaultPath 返回默认数据库路径（~/.claude-stats.db）
+func DefaultPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return DefaultFileName
+	}
+	return filepath.Join(home, DefaultFileName)
+}
+
+// Open 以读写方式打开数据库，不存在时创建并初始化表结构
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", dataSource(path, "rwc"))
+	if err != nil {
+		return nil, i18n.Errorf("err.db_open", path, err)
+	}
+	// SQLite 同一时间只允许一个写连接，避免驱动内部的锁等待
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, i18n.Errorf("err.db_open", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// OpenReadOnly 以只读方式打开已存在的数据库，任何写入语句都会失败
+func OpenReadOnly(path string) (*Store, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, i18n.Errorf("err.db_missing", path)
+	}
+
+	db, err := sql.Open("sqlite", dataSource(path, "ro")+"&_pragma=query_only(1)")
+	if err != nil {
+		return nil, i18n.Errorf("err.db_open", path, err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, i18n.Errorf("err.db_open", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close 关闭数据库
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Query 执行只读 SQL，返回列名和全部结果行
+func (s *Store) Query(query string) (*models.QueryResult, error) {
+	if err := checkReadOnlyQuery(query); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.QueryResult{
+		Type:    "query",
+		Columns: columns,
+		Rows:    [][]interface{}{},
+	}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		// 文本列可能以 []byte 返回，统一转为字符串便于格式化输出
+		for i, value := range values {
+			if b, ok := value.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	return result, rows.Err()
+}
+
+// readOnlyKeywords 允许作为查询开头的关键字
+var readOnlyKeywords = map[string]bool{"SELECT": true, "WITH": true, "VALUES": true}
+
+// checkReadOnlyQuery 执行前检查 SQL：只允许一条以 SELECT/WITH/VALUES 开头的语句
+// 只读连接仍可执行 ATTACH（会在磁盘上创建空文件）和 PRAGMA，多条语句时驱动也只返回最后一条的结果，因此都在这里拒绝
+func checkReadOnlyQuery(query string) error {
+	statements := 0
+	keyword := ""
+	inStatement := false
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`' || c == '[':
+			// 字符串和带引号的标识符，引号成对出现时表示转义
+			closing := c
+			if c == '[' {
+				closing = ']'
+			}
+			for i++; i < len(query); i++ {
+				if query[i] == closing {
+					if closing != ']' && i+1 < len(query) && query[i+1] == closing {
+						i++
+						continue
+					}
+					break
+				}
+			}
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+			continue
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				i = len(query)
+			} else {
+				i += end + 3
+			}
+			continue
+		case c == ';':
+			inStatement = false
+			continue
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			continue
+		}
+
+		if !inStatement {
+			inStatement = true
+			statements++
+			if statements > 1 {
+				return i18n.Errorf("err.query_multiple")
+			}
+			end := i
+			for end < len(query) && isKeywordChar(query[end]) {
+				end++
+			}
+			keyword = strings.ToUpper(query[i:end])
+		}
+	}
+
+	if statements == 1 && !readOnlyKeywords[keyword] {
+		return i18n.Errorf("err.query_not_select", keyword)
+	}
+	return nil
+}
+
+// isKeywordChar 是否为 SQL 关键字中的字符
+func isKeywordChar(c byte) bool {
+	return c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c == '_'
+}
+
+// dataSource 生成 SQLite URI 形式的连接串
+func dataSource(path, mode string) string {
+	return "file:" + (&url.URL{Path: path}).EscapedPath() + "?mode=" + mode + "&_pragma=busy_timeout(5000)"
+}
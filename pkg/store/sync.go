@@ -0,0 +1,237 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/zhuiye8/claude-stats/pkg/i18n"
+	"github.com/zhuiye8/claude-stats/pkg/models"
+	"github.com/zhuiye8/claude-stats/pkg/parser"
+)
+
+// timestampLayout 数据库中时间列的格式（UTC，按字典序即按时间排序）
+const timestampLayout = "2006-01-02T15:04:05.000Z"
+
+// SyncResult 一次同步的结果
+type SyncResult struct {
+	FilesScanned  int // 扫描到的日志文件数
+	FilesUpdated  int // 有新内容的文件数
+	FilesReset    int // 被截断或重写、需要从头重新读取的文件数
+	EntriesAdded  int // 新写入的明细条数
+	EntriesTotal  int // 同步后数据库中的明细总数
+	ProjectsTotal int
+	SessionsTotal int
+	BlocksTotal   int
+}
+
+// Sync 增量同步目录中的JSONL日志：每个文件只读取上次同步位置之后追加的内容
+// 文件变小时视为被重写，删除该文件的旧记录后从头读取；完成后重建 sessions/projects/blocks
+func (s *Store) Sync(p *parser.ClaudeParser, dirs []string) (*SyncResult, error) {
+	result := &SyncResult{}
+
+	for _, dir := range dirs {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			continue // 跳过不存在的目录
+		}
+
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || !strings.HasSuffix(strings.ToLower(info.Name()), ".jsonl") {
+				return nil
+			}
+
+			result.FilesScanned++
+			return s.syncFile(p, path, info.Size(), result)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.rebuildSummaries(p.Projects); err != nil {
+		return nil, err
+	}
+	if err := s.countRows(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// syncFile 在一个事务中同步单个文件的新增内容
+func (s *Store) syncFile(p *parser.ClaudeParser, path string, size int64, result *SyncResult) error {
+	var offset int64
+	err := s.db.QueryRow(`SELECT offset FROM files WHERE path = ?`, path).Scan(&offset)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if offset == size && err == nil {
+		return nil // 没有新内容
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if size < offset {
+		// 文件被截断或重写，旧的行位置已经失效
+		if _, err := tx.Exec(`DELETE FROM entries WHERE file = ?`, path); err != nil {
+			return err
+		}
+		offset = 0
+		result.FilesReset++
+	}
+
+	insert, err := tx.Prepare(`INSERT OR REPLACE INTO entries (
+		file, line_offset, timestamp, date, block_start, session_id, request_id, project_path, git_branch, model,
+		input_tokens, output_tokens, cache_creation_tokens, cache_read_tokens, cost_usd, price_version, is_sidechain
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+
+	// 写入失败不属于日志解析错误，单独记录以免被 SkipErrors 忽略
+	var insertErr error
+	added := 0
+	end, err := p.StreamFileFrom(path, offset, func(record models.UsageRecord, lineOffset int64) error {
+		added++
+		_, insertErr = insert.Exec(
+			path, lineOffset,
+			record.Timestamp.UTC().Format(timestampLayout),
+			record.Timestamp.Format("2006-01-02"),
+			blockStart(record.Timestamp).Format(timestampLayout),
+			record.SessionID, record.RequestID, record.ProjectPath, record.GitBranch, record.Model,
+			record.InputTokens, record.OutputTokens, record.CacheCreationTokens, record.CacheReadTokens,
+			record.CostUSD, record.PriceVersion, record.IsSidechain,
+		)
+		return insertErr
+	})
+	if insertErr != nil {
+		return insertErr
+	}
+	if err != nil {
+		if !p.SkipErrors {
+			return i18n.Errorf("parser.err_parse_file", path, err)
+		}
+		fmt.Fprintln(os.Stderr, i18n.T("parser.skip_file", path, err))
+		return nil
+	}
+
+	_, err = tx.Exec(`INSERT INTO files (path, size, offset, synced_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (path) DO UPDATE SET size = excluded.size, offset = excluded.offset, synced_at = excluded.synced_at`,
+		path, size, end, time.Now().UTC().Format(timestampLayout))
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if end > offset {
+		result.FilesUpdated++
+	}
+	result.EntriesAdded += added
+	return nil
+}
+
+// rebuildSummaries 由 entries 重建会话、项目和5小时窗口汇总表
+func (s *Store) rebuildSummaries(rules parser.ProjectRules) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	statements := []string{
+		`DELETE FROM sessions`,
+		// 会话所属项目取其第一条记录的项目（SQLite 中与 MIN() 同行的裸列）
+		`INSERT INTO sessions
+		SELECT session_id, project_path, MIN(timestamp), MAX(timestamp), COUNT(*),
+			SUM(input_tokens), SUM(output_tokens), SUM(cache_creation_tokens), SUM(cache_read_tokens),
+			SUM(cost_usd), TOTAL(CASE WHEN is_sidechain THEN cost_usd END)
+		FROM entries GROUP BY session_id`,
+		`DELETE FROM projects`,
+		`INSERT INTO projects
+		SELECT project_path, project_path, '', MIN(timestamp), MAX(timestamp), COUNT(DISTINCT session_id), COUNT(*),
+			SUM(input_tokens), SUM(output_tokens), SUM(cache_creation_tokens), SUM(cache_read_tokens), SUM(cost_usd)
+		FROM entries GROUP BY project_path`,
+		`DELETE FROM blocks`,
+		`INSERT INTO blocks
+		SELECT block_start, strftime('%Y-%m-%dT%H:%M:%S.000Z', block_start, '+5 hours'),
+			COUNT(DISTINCT session_id), COUNT(*),
+			SUM(input_tokens), SUM(output_tokens), SUM(cache_creation_tokens), SUM(cache_read_tokens), SUM(cost_usd),
+			(SELECT group_concat(model, ',') FROM (SELECT DISTINCT model FROM entries e WHERE e.block_start = entries.block_start ORDER BY model))
+		FROM entries GROUP BY block_start`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+
+	// 项目名称和分组来自配置中的别名/分组规则
+	paths, err := queryStrings(tx, `SELECT project_path FROM projects`)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		identity := rules.Resolve(path)
+		if _, err := tx.Exec(`UPDATE projects SET name = ?, group_name = ? WHERE project_path = ?`,
+			identity.Name, identity.Group, path); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// countRows 统计同步后各表的行数
+func (s *Store) countRows(result *SyncResult) error {
+	counts := []struct {
+		table  string
+		target *int
+	}{
+		{"entries", &result.EntriesTotal},
+		{"projects", &result.ProjectsTotal},
+		{"sessions", &result.SessionsTotal},
+		{"blocks", &result.BlocksTotal},
+	}
+	for _, count := range counts {
+		if err := s.db.QueryRow(`SELECT COUNT(*) FROM ` + count.table).Scan(count.target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queryStrings 读取单列字符串结果
+func queryStrings(tx *sql.Tx, query string) ([]string, error) {
+	rows, err := tx.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+// blockStart 返回时间点所在5小时计费窗口的起点，与 blocks 命令的窗口划分一致
+func blockStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), (t.Hour()/5)*5, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,99 @@
+package vcs
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/zhuiye8/claude-stats/pkg/models"
+)
+
+// 提交日志各字段之间的分隔符（ASCII单元分隔符，不会出现在提交信息中）
+const fieldSeparator = "\x1f"
+
+// RepoRoot 返回路径所在Git仓库的根目录
+func RepoRoot(path string) (string, error) {
+	out, err := runGit(path, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// RemoteURL 返回路径所在Git仓库 origin 远程的地址
+func RemoteURL(path string) (string, error) {
+	out, err := runGit(path, "remote", "get-url", "origin")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// CheckBranch 检查分支名是否合法；分支名来自日志或团队快照，不可信，不能被 git 当作选项解析
+func CheckBranch(repo, branch string) error {
+	if branch == "" || strings.HasPrefix(branch, "-") {
+		return &GitError{Args: []string{"check-ref-format", "--branch", branch}, Message: "invalid branch name"}
+	}
+	_, err := runGit(repo, "check-ref-format", "--branch", branch)
+	return err
+}
+
+// CommitsInWindow 返回分支上在指定时间段内提交的记录（按时间正序）
+func CommitsInWindow(repo, branch string, since, until time.Time) ([]models.GitCommit, error) {
+	if err := CheckBranch(repo, branch); err != nil {
+		return nil, err
+	}
+	out, err := runGit(repo, "log",
+		"--reverse",
+		"--since="+since.Format(time.RFC3339),
+		"--until="+until.Format(time.RFC3339),
+		"--format=%H"+fieldSeparator+"%an"+fieldSeparator+"%aI"+fieldSeparator+"%s",
+		"--end-of-options", branch,
+		"--",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []models.GitCommit
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(line, fieldSeparator, 4)
+		if len(fields) != 4 {
+			continue
+		}
+		commitTime, _ := time.Parse(time.RFC3339, fields[2])
+		commits = append(commits, models.GitCommit{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Time:    commitTime,
+			Subject: fields[3],
+		})
+	}
+	return commits, nil
+}
+
+// runGit 在指定目录下执行git命令
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", &GitError{Args: args, Message: msg}
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// GitError 代表git命令执行失败
+type GitError struct {
+	Args    []string
+	Message string
+}
+
+func (e *GitError) Error() string {
+	return "git " + strings.Join(e.Args, " ") + ": " + e.Message
+}